@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gihub.com/gadhittana01/book-project/pkg/book"
+	"gihub.com/gadhittana01/book-project/pkg/catalogstore"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+	httpClient "gihub.com/gadhittana01/book-project/pkg/http_client"
+	"gihub.com/gadhittana01/book-project/services"
+)
+
+type warmCacheRes struct {
+	Out      string         `json:"out"`
+	Subjects map[string]int `json:"subjects"`
+	Works    int            `json:"works"`
+}
+
+// warmCache asks the configured catalog providers for each subject through the book service and adds the books to
+// the local catalog, which the service reads in bookservice.mode local and cmd/catalog-import also fills.
+func warmCache(args []string) error {
+	flags := flag.NewFlagSet("warm-cache", flag.ContinueOnError)
+	subjects := flags.String("subjects", "", "comma separated subjects, e.g. love,science_fiction")
+	out := flags.String("out", "", "local catalog to update, defaults to bookservice.localcatalogpath")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	cfg := loadConfig()
+	if *out == "" {
+		*out = cfg.BookService.LocalCatalogPath
+	}
+	if *out == "" {
+		return errors.New("no local catalog, set -out or bookservice.localcatalogpath")
+	}
+	names := []string{}
+	for _, subject := range strings.Split(*subjects, ",") {
+		if subject = strings.TrimSpace(subject); subject != "" {
+			names = append(names, subject)
+		}
+	}
+	if len(names) == 0 {
+		return errors.New("nothing to warm, set -subjects")
+	}
+
+	// The local catalog is what is being filled, so the books always come from the remote providers.
+	remote := *cfg
+	remote.BookService.Mode = book.ModeRemote
+	client, err := httpClient.New(httpClient.HttpClientDep{Config: &remote})
+	if err != nil {
+		return err
+	}
+	bookPkg, err := book.New(&remote, client)
+	if err != nil {
+		return err
+	}
+	bs, err := services.NewBookService(services.BookDependencies{
+		BR:  bookPkg,
+		Cfg: &remote,
+	})
+	if err != nil {
+		return err
+	}
+
+	store, err := catalogstore.LoadOrNew(*out)
+	if err != nil {
+		return err
+	}
+	res := warmCacheRes{Out: *out, Subjects: map[string]int{}}
+	for _, subject := range names {
+		list, err := bs.GetListOfBooks(context.Background(), services.GetListOfBooksReq{Subject: subject})
+		if err != nil {
+			return err
+		}
+		for _, item := range list.Books {
+			authors := []domain.Author{}
+			for _, author := range item.Authors {
+				authors = append(authors, domain.Author{Name: author.Name})
+			}
+			store.Add(domain.Book{
+				Key:               item.Key,
+				Title:             item.Title,
+				EditionCount:      item.EditionCount,
+				Authors:           authors,
+				LendingIdentifier: item.LendingIdentifier,
+			}, subject)
+		}
+		res.Subjects[catalogstore.SubjectSlug(subject)] = len(list.Books)
+	}
+
+	if dir := filepath.Dir(*out); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	if err := store.Save(*out); err != nil {
+		return err
+	}
+	res.Works = len(store.Works)
+	return printJSON(res)
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/handler/resthttp"
+	"gihub.com/gadhittana01/book-project/pkg/book"
+	httpClient "gihub.com/gadhittana01/book-project/pkg/http_client"
+	"gihub.com/gadhittana01/book-project/pkg/notify"
+	"gopkg.in/yaml.v2"
+)
+
+var weekdays = map[string]bool{
+	"monday": true, "tuesday": true, "wednesday": true, "thursday": true, "friday": true, "saturday": true, "sunday": true,
+}
+
+type configReport struct {
+	File     string   `json:"file"`
+	Valid    bool     `json:"valid"`
+	Problems []string `json:"problems"`
+}
+
+// validateConfig reads the file the way the service does and then builds the modules whose constructors check
+// their part of the config. Branch settings are only read per request by the service, so they are checked here.
+func validateConfig(args []string) error {
+	flags := flag.NewFlagSet("validate-config", flag.ContinueOnError)
+	file := flags.String("file", "config/book-project.yaml", "config file to check")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	report := configReport{File: *file, Problems: []string{}}
+	problem := func(format string, a ...interface{}) {
+		report.Problems = append(report.Problems, fmt.Sprintf(format, a...))
+	}
+
+	data, err := ioutil.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+	cfg := &config.GlobalConfig{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		problem("%v", err)
+		return finishReport(report)
+	}
+	// Unknown keys are ignored by the service, they are usually typos of a setting that then keeps its default.
+	if err := yaml.UnmarshalStrict(data, &config.GlobalConfig{}); err != nil {
+		problem("%v", err)
+	}
+
+	if cfg.HTTP.Port <= 0 || cfg.HTTP.Port > 65535 {
+		problem("http.port %d is not a valid port", cfg.HTTP.Port)
+	}
+
+	client, err := httpClient.New(httpClient.HttpClientDep{Config: cfg})
+	if err != nil {
+		problem("httpclientconfig: %v", err)
+	} else if _, err := book.New(cfg, client); err != nil {
+		problem("bookservice: %v", err)
+	}
+
+	if cfg.Notification.Channel != "" {
+		if _, err := notify.New(cfg); err != nil {
+			problem("notification: %v", err)
+		}
+	}
+
+	for _, key := range cfg.RateLimit.KeyBy {
+		switch key {
+		case resthttp.RateLimitKeyAPIKey, resthttp.RateLimitKeyUser, resthttp.RateLimitKeyIP:
+		default:
+			problem("ratelimit.keyby: unknown identity %q", key)
+		}
+	}
+
+	codes := map[string]bool{}
+	for i, b := range cfg.Branches {
+		name := fmt.Sprintf("branches[%d]", i)
+		if b.Code == "" {
+			problem("%s: code is empty", name)
+		} else {
+			name = fmt.Sprintf("branch %s", b.Code)
+		}
+		if codes[b.Code] {
+			problem("%s: code is used by another branch", name)
+		}
+		codes[b.Code] = true
+
+		for day, hours := range b.OpeningHours {
+			if !weekdays[day] {
+				problem("%s: openinghours: %q is not a lower case weekday", name, day)
+			}
+			if err := checkHours(hours); err != nil {
+				problem("%s: openinghours %s: %v", name, day, err)
+			}
+		}
+		for _, date := range b.ClosedDates {
+			if _, err := time.Parse("2006-01-02", date); err != nil {
+				problem("%s: closeddates: %q is not a YYYY-MM-DD date", name, date)
+			}
+		}
+		if b.PickupCapacity < 0 || b.CopiesPerWork < 0 || b.SlotMinutes < 0 || b.SlotCapacity < 0 {
+			problem("%s: capacities and slotminutes cannot be negative", name)
+		}
+	}
+
+	return finishReport(report)
+}
+
+// checkHours parses opening hours like "09:00-17:00".
+func checkHours(hours string) error {
+	parts := strings.SplitN(hours, "-", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("%q is not HH:MM-HH:MM", hours)
+	}
+	open, err := time.Parse("15:04", strings.TrimSpace(parts[0]))
+	if err != nil {
+		return fmt.Errorf("%q is not HH:MM-HH:MM", hours)
+	}
+	closing, err := time.Parse("15:04", strings.TrimSpace(parts[1]))
+	if err != nil {
+		return fmt.Errorf("%q is not HH:MM-HH:MM", hours)
+	}
+	if !closing.After(open) {
+		return fmt.Errorf("%q closes before it opens", hours)
+	}
+	return nil
+}
+
+// finishReport prints the report and fails when it found problems.
+func finishReport(report configReport) error {
+	report.Valid = len(report.Problems) == 0
+	if err := printJSON(report); err != nil {
+		return err
+	}
+	if !report.Valid {
+		return fmt.Errorf("%s has %d problems", report.File, len(report.Problems))
+	}
+	return nil
+}
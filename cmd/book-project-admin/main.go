@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/helper"
+)
+
+// command is one subcommand of the admin CLI. run gets the arguments after the subcommand name, everything it
+// prints on stdout is JSON.
+type command struct {
+	usage string
+	run   func(args []string) error
+}
+
+var commands = map[string]command{
+	"reservations":    {"list and search reservations", listReservations},
+	"cancel":          {"cancel a reservation", cancelReservation},
+	"expire":          {"expire reservations not picked up in time", expireReservations},
+	"export":          {"export reservations to CSV", exportReservations},
+	"validate-config": {"check a config file", validateConfig},
+	"warm-cache":      {"copy subjects from the catalog provider into the local catalog", warmCache},
+	"migrate":         {"list pending schema migrations with -dry-run", migrate},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+
+	if err := cmd.run(os.Args[2:]); err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(2)
+		}
+		fail(err)
+	}
+}
+
+func usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "usage: book-project-admin <command> [flags]")
+	fmt.Fprintln(os.Stderr, "\ncommands:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-16s %s\n", name, commands[name].usage)
+	}
+	fmt.Fprintln(os.Stderr, "\nrun book-project-admin <command> -h for the flags of a command")
+}
+
+// loadConfig reads config/book-project.yaml like the service does.
+func loadConfig() *config.GlobalConfig {
+	cfg := &config.GlobalConfig{}
+	helper.LoadConfig(cfg)
+	return cfg
+}
+
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// fail reports err as JSON on stderr and exits, so scripts can tell failures from results on stdout.
+func fail(err error) {
+	enc := json.NewEncoder(os.Stderr)
+	enc.SetIndent("", "  ")
+	enc.Encode(map[string]string{"error": err.Error()})
+	os.Exit(1)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"gihub.com/gadhittana01/book-project/pkg/migration"
+	"gihub.com/gadhittana01/book-project/services"
+)
+
+// errInMemoryStore refuses applying migrations to an in-memory store from this process. The store lives in the
+// memory of the service, a migration recorded here would only land in a migrations table that is gone when the
+// command exits.
+var errInMemoryStore = errors.New("the store is in-memory, nothing applied: run with -dry-run to list the pending migrations")
+
+// migrate applies the migrations embedded in this binary that the configured store has not applied yet, the same
+// ones the service applies at startup with migration.runonstartup, or lists them with -dry-run.
+func migrate(args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "print the pending migrations and their SQL without applying them")
+	script := flags.Bool("sql", false, "with -dry-run, print the pending SQL as a script instead of JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *script && !*dryRun {
+		return errors.New("-sql needs -dry-run")
+	}
+
+	cfg := loadConfig()
+	migrationPkg, err := migration.New(cfg)
+	if err != nil {
+		return err
+	}
+	if !*dryRun && migrationPkg.InMemory() {
+		return errInMemoryStore
+	}
+	mg, err := services.NewMigrator(services.MigrationDependencies{
+		MR:  migrationPkg,
+		Cfg: cfg,
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := mg.Migrate(context.Background(), services.MigrateReq{DryRun: *dryRun})
+	if err != nil {
+		return err
+	}
+	if *script {
+		for _, m := range res.Pending {
+			fmt.Printf("-- %04d_%s.sql (checksum %s)\n%s\n", m.Version, m.Name, m.Checksum, strings.TrimRight(m.SQL, "\n"))
+			fmt.Println()
+		}
+		return nil
+	}
+	return printJSON(res)
+}
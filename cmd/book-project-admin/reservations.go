@@ -0,0 +1,277 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/handler/resthttp"
+	"gihub.com/gadhittana01/book-project/services"
+)
+
+// Reservations live in the memory of the running service, a store opened by this process would be empty. So these
+// commands go through its API with the request and response types of the services layer.
+type apiClient struct {
+	addr   string
+	token  string
+	cfg    *config.GlobalConfig
+	client *http.Client
+}
+
+// newAPIClient adds -addr and -token to flags, the client is usable once flags are parsed.
+func newAPIClient(flags *flag.FlagSet) *apiClient {
+	c := &apiClient{client: &http.Client{Timeout: 5 * time.Minute}}
+	flags.StringVar(&c.addr, "addr", "", "address of the running service, defaults to http://localhost:<http.port>")
+	flags.StringVar(&c.token, "token", "", "admin token of the running service, defaults to http.admintoken")
+	return c
+}
+
+// serviceConfig loads the config file once, only for the flags left empty.
+func (c *apiClient) serviceConfig() *config.GlobalConfig {
+	if c.cfg == nil {
+		c.cfg = loadConfig()
+	}
+	return c.cfg
+}
+
+func (c *apiClient) url(path string, query url.Values) string {
+	if c.addr == "" {
+		c.addr = fmt.Sprintf("http://localhost:%d", c.serviceConfig().HTTP.Port)
+	}
+	u := strings.TrimRight(c.addr, "/") + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	return u
+}
+
+// do sends req with the admin token, which the admin routes of the service require.
+func (c *apiClient) do(req *http.Request) (*http.Response, error) {
+	if c.token == "" {
+		c.token = c.serviceConfig().HTTP.AdminToken
+	}
+	if c.token != "" {
+		req.Header.Set(resthttp.HeaderAdminToken, c.token)
+	}
+	return c.client.Do(req)
+}
+
+// post sends body as JSON and decodes the data of the answer into out.
+func (c *apiClient) post(path string, body, out interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, c.url(path, nil), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return decodeResponse(resp, out)
+}
+
+// stream opens a GET whose answer is read as it arrives, the caller closes the body.
+func (c *apiClient) stream(path string, query url.Values) (io.ReadCloser, error) {
+	req, err := http.NewRequest(http.MethodGet, c.url(path, query), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, decodeResponse(resp, nil)
+	}
+	return resp.Body, nil
+}
+
+func decodeResponse(resp *http.Response, out interface{}) error {
+	var res struct {
+		Data struct {
+			Data         json.RawMessage `json:"data"`
+			ErrorMessage string          `json:"error_message"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return fmt.Errorf("%s: %v", resp.Status, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", resp.Status, res.Data.ErrorMessage)
+	}
+	if out == nil || len(res.Data.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(res.Data.Data, out)
+}
+
+// reservationQuery adds the reservation filters to flags, the service applies them while it pages through the store.
+func reservationQuery(flags *flag.FlagSet) func() url.Values {
+	userID := flags.Int("user", 0, "only reservations of this user ID")
+	filters := []struct {
+		param string
+		value *string
+	}{
+		{"branch", flags.String("branch", "", "only reservations of this branch code")},
+		{"status", flags.String("status", "", "only reservations in this status, e.g. active or provisional")},
+		{"key", flags.String("key", "", "only reservations of this work key")},
+		{"q", flags.String("q", "", "search title, author and work key, case insensitive")},
+		{"from", flags.String("from", "", "only pickups on or after this YYYY-MM-DD date")},
+		{"to", flags.String("to", "", "only pickups on or before this YYYY-MM-DD date")},
+	}
+	return func() url.Values {
+		query := url.Values{}
+		if *userID != 0 {
+			query.Set("user_id", strconv.Itoa(*userID))
+		}
+		for _, f := range filters {
+			if *f.value != "" {
+				query.Set(f.param, *f.value)
+			}
+		}
+		return query
+	}
+}
+
+func listReservations(args []string) error {
+	flags := flag.NewFlagSet("reservations", flag.ContinueOnError)
+	api := newAPIClient(flags)
+	query := reservationQuery(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	q := query()
+	q.Set("format", "ndjson")
+	body, err := api.stream("/get-book-reservation", q)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	rows := []services.ReservationExportRow{}
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var row services.ReservationExportRow
+		if err := json.Unmarshal(line, &row); err != nil {
+			return err
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return printJSON(rows)
+}
+
+func cancelReservation(args []string) error {
+	flags := flag.NewFlagSet("cancel", flag.ContinueOnError)
+	api := newAPIClient(flags)
+	var req services.CancelReservationReq
+	flags.IntVar(&req.ID, "id", 0, "reservation ID")
+	flags.IntVar(&req.UserID, "user", 0, "ID of the user holding the reservation")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if req.ID == 0 || req.UserID == 0 {
+		return errors.New("-id and -user are required")
+	}
+
+	if err := api.post("/cancel-reservation", req, nil); err != nil {
+		return err
+	}
+	return printJSON(map[string]interface{}{
+		"id":      req.ID,
+		"user_id": req.UserID,
+		"status":  "cancelled",
+	})
+}
+
+func expireReservations(args []string) error {
+	flags := flag.NewFlagSet("expire", flag.ContinueOnError)
+	api := newAPIClient(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var res services.SweepRes
+	if err := api.post("/expire-reservations", nil, &res); err != nil {
+		return err
+	}
+	return printJSON(res)
+}
+
+func exportReservations(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	api := newAPIClient(flags)
+	query := reservationQuery(flags)
+	out := flags.String("out", "", "CSV file to write, the CSV goes to stdout when empty")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	q := query()
+	q.Set("format", "csv")
+	body, err := api.stream("/get-book-reservation", q)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if *out == "" {
+		_, err := io.Copy(os.Stdout, body)
+		return err
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	// The CSV is read while it is written so the rows can be counted, titles may hold quoted line breaks.
+	r := csv.NewReader(io.TeeReader(body, f))
+	r.FieldsPerRecord = -1
+	rows := -1 // header
+	for {
+		_, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			f.Close()
+			return err
+		}
+		rows++
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if rows < 0 {
+		rows = 0
+	}
+	return printJSON(map[string]interface{}{
+		"out":  *out,
+		"rows": rows,
+	})
+}
@@ -1,27 +1,196 @@
 package main
 
 import (
+	"context"
+	"log"
+
 	"gihub.com/gadhittana01/book-project/config"
 	"gihub.com/gadhittana01/book-project/handler/resthttp"
 	"gihub.com/gadhittana01/book-project/pkg/book"
 	httpClient "gihub.com/gadhittana01/book-project/pkg/http_client"
+	"gihub.com/gadhittana01/book-project/pkg/idempotency"
+	"gihub.com/gadhittana01/book-project/pkg/migration"
+	"gihub.com/gadhittana01/book-project/pkg/notify"
+	"gihub.com/gadhittana01/book-project/pkg/user"
+	"gihub.com/gadhittana01/book-project/pkg/webhook"
 	"gihub.com/gadhittana01/book-project/services"
 )
 
 func initApp(c *config.GlobalConfig) error {
-	httpClient := httpClient.New(httpClient.HttpClientDep{
+	if c.Migration.RunOnStartup {
+		if err := migrate(c); err != nil {
+			return err
+		}
+	}
+
+	httpClient, err := httpClient.New(httpClient.HttpClientDep{
 		Config: c,
 	})
-	bookPkg := book.New(c, httpClient)
+	if err != nil {
+		return err
+	}
+
+	bookPkg, err := book.New(c, httpClient)
+	if err != nil {
+		return err
+	}
+
+	userPkg, err := user.New(c)
+	if err != nil {
+		return err
+	}
+
+	var notifier services.Notifier
+	if c.Notification.Channel != "" {
+		notifier, err = notify.New(c)
+		if err != nil {
+			return err
+		}
+	}
 
 	bs, err := services.NewBookService(services.BookDependencies{
-		BR: bookPkg,
+		BR:  bookPkg,
+		UR:  userPkg,
+		Cfg: c,
+	})
+	if err != nil {
+		return err
+	}
+
+	us, err := services.NewUserService(services.UserDependencies{
+		UR: userPkg,
 	})
 	if err != nil {
 		return err
 	}
 
+	idempotencyPkg, err := idempotency.New(c)
+	if err != nil {
+		return err
+	}
+
+	is, err := services.NewIdempotencyService(services.IdempotencyDependencies{
+		IR:  idempotencyPkg,
+		Cfg: c,
+	})
+	if err != nil {
+		return err
+	}
+
+	sw, err := services.NewSweeper(services.SweeperDependencies{
+		BR:  bookPkg,
+		Cfg: c,
+	})
+	if err != nil {
+		return err
+	}
+	if c.Sweeper.Enabled {
+		go sw.Run(context.Background())
+	}
+
+	webhookPkg, err := webhook.New(c)
+	if err != nil {
+		return err
+	}
+
+	ws, err := services.NewWebhookService(services.WebhookDependencies{
+		WR:  webhookPkg,
+		Cfg: c,
+	})
+	if err != nil {
+		return err
+	}
+
+	wh, err := services.NewWebhookEventHandler(services.WebhookDependencies{
+		WR:  webhookPkg,
+		Cfg: c,
+	})
+	if err != nil {
+		return err
+	}
+	rs, err := services.NewReservationStream(services.ReservationStreamDependencies{
+		Cfg: c,
+	})
+	if err != nil {
+		return err
+	}
+	handlers := []services.EventHandler{wh, rs}
+
+	wd, err := services.NewWebhookDeliverer(services.WebhookDependencies{
+		WR:  webhookPkg,
+		Cfg: c,
+	})
+	if err != nil {
+		return err
+	}
+	go wd.Run(context.Background())
+
+	if notifier != nil {
+		nh, err := services.NewNotificationHandler(services.NotificationDependencies{
+			UR:       userPkg,
+			Notifier: notifier,
+			Cfg:      c,
+		})
+		if err != nil {
+			return err
+		}
+		handlers = append(handlers, nh)
+
+		rm, err := services.NewReminder(services.ReminderDependencies{
+			BR:       bookPkg,
+			UR:       userPkg,
+			Notifier: notifier,
+			Cfg:      c,
+		})
+		if err != nil {
+			return err
+		}
+		go rm.Run(context.Background())
+	}
+
+	dp, err := services.NewDispatcher(services.DispatcherDependencies{
+		BR:       bookPkg,
+		Cfg:      c,
+		Handlers: handlers,
+	})
+	if err != nil {
+		return err
+	}
+	go dp.Run(context.Background())
+
 	return startHTTPServer(resthttp.NewRoutes(resthttp.RouterDependencies{
-		BS: bs,
-	}), c)
+		BS:          bs,
+		US:          us,
+		WS:          ws,
+		RS:          rs,
+		IS:          is,
+		SW:          sw,
+		EventStream: c.EventStream,
+		RateLimit:   c.RateLimit,
+		Idempotency: c.Idempotency,
+		AdminToken:  c.HTTP.AdminToken,
+	}), resthttp.NewInternalRoutes(), c)
+}
+
+// migrate brings the store schema up to date before anything reads or writes it.
+func migrate(c *config.GlobalConfig) error {
+	migrationPkg, err := migration.New(c)
+	if err != nil {
+		return err
+	}
+
+	mg, err := services.NewMigrator(services.MigrationDependencies{
+		MR:  migrationPkg,
+		Cfg: c,
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := mg.Migrate(context.Background(), services.MigrateReq{})
+	if err != nil {
+		return err
+	}
+	log.Printf("schema at version %d, applied %d migrations", res.Version, len(res.Applied))
+	return nil
 }
@@ -9,7 +9,16 @@ import (
 	"gihub.com/gadhittana01/book-project/config"
 )
 
-func startHTTPServer(handler http.Handler, c *config.GlobalConfig) error {
+func startHTTPServer(handler, internal http.Handler, c *config.GlobalConfig) error {
+	if c.HTTP.InternalAddr != "" {
+		go func() {
+			log.Println("Serving internal HTTP on " + c.HTTP.InternalAddr)
+			if err := http.ListenAndServe(c.HTTP.InternalAddr, internal); err != nil {
+				log.Println(err)
+			}
+		}()
+	}
+
 	log.Println("Serving HTTP on ports :" + strconv.Itoa(c.HTTP.Port))
 	port := fmt.Sprintf(":%d", c.HTTP.Port)
 	return http.ListenAndServe(port, handler)
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"compress/gzip"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/helper"
+	"gihub.com/gadhittana01/book-project/pkg/catalogstore"
+)
+
+func main() {
+	var (
+		dumps    = flag.String("dump", "", "comma separated Open Library dump files (works/authors, JSON-lines or tab separated, .gz allowed)")
+		subjects = flag.String("subjects", "", "comma separated subject JSON files or globs, e.g. exports/*.json")
+		out      = flag.String("out", "", "catalog store to write, defaults to bookservice.localcatalogpath")
+	)
+	flag.Parse()
+
+	if *out == "" {
+		cfg := &config.GlobalConfig{}
+		helper.LoadConfig(cfg)
+		*out = cfg.BookService.LocalCatalogPath
+	}
+	if *out == "" {
+		log.Fatal("no output path, set -out or bookservice.localcatalogpath")
+	}
+	if *dumps == "" && *subjects == "" {
+		log.Fatal("nothing to import, set -dump and/or -subjects")
+	}
+
+	store, err := catalogstore.LoadOrNew(*out)
+	if err != nil {
+		log.Fatalf("load %s: %v", *out, err)
+	}
+
+	for _, path := range splitList(*dumps) {
+		n, err := importFile(path, store.ImportDump)
+		if err != nil {
+			log.Fatalf("import dump %s: %v", path, err)
+		}
+		log.Printf("imported %d works from %s", n, path)
+	}
+
+	for _, pattern := range splitList(*subjects) {
+		paths, err := filepath.Glob(pattern)
+		if err != nil {
+			log.Fatalf("subjects %s: %v", pattern, err)
+		}
+		for _, path := range paths {
+			n, err := importFile(path, store.ImportSubject)
+			if err != nil {
+				log.Fatalf("import subject %s: %v", path, err)
+			}
+			log.Printf("imported %d works from %s", n, path)
+		}
+	}
+
+	if dir := filepath.Dir(*out); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			log.Fatalf("create %s: %v", dir, err)
+		}
+	}
+	if err := store.Save(*out); err != nil {
+		log.Fatalf("save %s: %v", *out, err)
+	}
+	log.Printf("catalog written to %s (%d works, %d subjects)", *out, len(store.Works), len(store.Subjects))
+}
+
+func importFile(path string, fn func(r io.Reader) (int, error)) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return 0, err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	return fn(r)
+}
+
+func splitList(s string) []string {
+	res := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+	return res
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+
+	"gihub.com/gadhittana01/book-project/pkg/fakeopenlibrary"
+)
+
+func main() {
+	var (
+		opts      fakeopenlibrary.Options
+		addr      = flag.String("addr", ":8081", "listen address")
+		failPaths = flag.String("fail-paths", "", "comma separated path prefixes that always fail, e.g. /subjects/love")
+	)
+	flag.StringVar(&opts.FixtureDir, "fixtures", "", "fixture directory with subjects/ and works/, defaults to the embedded fixtures")
+	flag.DurationVar(&opts.Latency, "latency", 0, "latency added to every response, e.g. 300ms")
+	flag.DurationVar(&opts.Jitter, "jitter", 0, "random extra latency up to this duration")
+	flag.Float64Var(&opts.ErrorRate, "error-rate", 0, "chance (0..1) that a request fails")
+	flag.IntVar(&opts.ErrorStatus, "error-status", 500, "status code used for injected failures")
+	flag.IntVar(&opts.RateLimit, "rate-limit", 0, "requests allowed per rate window, 0 disables rate limiting")
+	flag.DurationVar(&opts.RateWindow, "rate-window", 0, "rate limit window, defaults to 1s")
+	flag.Int64Var(&opts.Seed, "seed", 0, "seed for jitter and error injection")
+	flag.Parse()
+
+	for _, item := range strings.Split(*failPaths, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			opts.FailPaths = append(opts.FailPaths, item)
+		}
+	}
+
+	srv, err := fakeopenlibrary.New(opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Println("Serving fake Open Library on " + *addr)
+	log.Println("Set bookservice.address to " + serviceAddress(*addr) + " to use it")
+	log.Fatal(http.ListenAndServe(*addr, srv))
+}
+
+// serviceAddress turns the listen address into the URL the service reaches it at, ":8081" listens on localhost too.
+func serviceAddress(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "http://" + addr
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return "http://" + net.JoinHostPort(host, port)
+}
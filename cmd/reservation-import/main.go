@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/helper"
+	"gihub.com/gadhittana01/book-project/services"
+)
+
+// requiredColumns must be in the header of the CSV file, pickup_slot and subject are optional.
+var requiredColumns = []string{"key", "branch", "pickup_date", "user_id"}
+
+func main() {
+	var (
+		file   = flag.String("file", "", "CSV file with the columns key, branch, pickup_date, user_id and optionally pickup_slot, subject")
+		addr   = flag.String("addr", "", "address of the running service, defaults to http://localhost:<http.port>")
+		batch  = flag.Int("batch", 0, "reservations per request, defaults to reservation.batchmaxitems")
+		atomic = flag.Bool("atomic", false, "store every reservation of the file or none of them")
+	)
+	flag.Parse()
+
+	cfg := &config.GlobalConfig{}
+	helper.LoadConfig(cfg)
+	if *addr == "" {
+		*addr = fmt.Sprintf("http://localhost:%d", cfg.HTTP.Port)
+	}
+	if *batch <= 0 {
+		*batch = cfg.Reservation.BatchMaxItems
+	}
+	if *batch <= 0 {
+		*batch = 50
+	}
+	if *file == "" {
+		log.Fatal("nothing to import, set -file")
+	}
+
+	items, err := readItems(*file)
+	if err != nil {
+		log.Fatalf("read %s: %v", *file, err)
+	}
+	if *atomic && len(items) > *batch {
+		log.Fatalf("-atomic needs the %d reservations in one batch, raise -batch up to reservation.batchmaxitems", len(items))
+	}
+
+	// Reservations go through /reservations:batch so they get the same checks as every other reservation and land in
+	// the store of the running service.
+	client := &http.Client{Timeout: time.Minute}
+	created, failed := 0, 0
+	for start := 0; start < len(items); start += *batch {
+		end := start + *batch
+		if end > len(items) {
+			end = len(items)
+		}
+		res, err := send(client, *addr, services.BorrowBooksReq{Items: items[start:end], Atomic: *atomic})
+		if err != nil {
+			log.Fatalf("import rows %d-%d: %v", start+2, end+1, err)
+		}
+		for _, item := range res.Items {
+			// Data rows start on line 2, after the header.
+			line := start + item.Index + 2
+			switch {
+			case item.Reservation != nil:
+				log.Printf("line %d: reservation %d created", line, item.Reservation.ID)
+			case item.Error != nil:
+				log.Printf("line %d: %s: %s", line, item.Error.Code, item.Error.Message)
+			default:
+				log.Printf("line %d: %s", line, item.Status)
+			}
+		}
+		created += res.Created
+		failed += res.Failed
+	}
+
+	log.Printf("imported %d of %d reservations from %s, %d failed", created, len(items), *file, failed)
+	if created != len(items) {
+		os.Exit(1)
+	}
+}
+
+func readItems(path string) ([]services.BorrowBookReq, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	header, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+	column := map[string]int{}
+	for i, name := range header {
+		column[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range requiredColumns {
+		if _, ok := column[name]; !ok {
+			return nil, fmt.Errorf("missing column %s", name)
+		}
+	}
+	get := func(record []string, name string) string {
+		i, ok := column[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	items := []services.BorrowBookReq{}
+	for line := 2; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			return items, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		uid, err := strconv.Atoi(get(record, "user_id"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: user_id: %v", line, err)
+		}
+		items = append(items, services.BorrowBookReq{
+			BookKey:    get(record, "key"),
+			Branch:     get(record, "branch"),
+			PickUpDate: get(record, "pickup_date"),
+			PickUpSlot: get(record, "pickup_slot"),
+			Subject:    get(record, "subject"),
+			UserID:     uid,
+		})
+	}
+}
+
+func send(client *http.Client, addr string, req services.BorrowBooksReq) (services.BorrowBooksRes, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return services.BorrowBooksRes{}, err
+	}
+	resp, err := client.Post(strings.TrimRight(addr, "/")+"/reservations:batch", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return services.BorrowBooksRes{}, err
+	}
+	defer resp.Body.Close()
+
+	var res struct {
+		Data struct {
+			Data         services.BorrowBooksRes `json:"data"`
+			ErrorMessage string                  `json:"error_message"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return services.BorrowBooksRes{}, fmt.Errorf("%s: %v", resp.Status, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return services.BorrowBooksRes{}, fmt.Errorf("%s: %s", resp.Status, res.Data.ErrorMessage)
+	}
+	return res.Data.Data, nil
+}
@@ -1,23 +1,246 @@
 package config
 
+import "strings"
+
 type GlobalConfig struct {
 	HTTP             HTTPConfig       `yaml:"http"`
 	BookService      BookService      `yaml:"bookservice"`
 	HttpClientConfig HttpClientConfig `yaml:"httpclientconfig"`
+	RateLimit        RateLimitConfig  `yaml:"ratelimit"`
+	Reservation      Reservation      `yaml:"reservation"`
+	Loan             Loan             `yaml:"loan"`
+	Fine             Fine             `yaml:"fine"`
+	Policy           Policy           `yaml:"policy"`
+	Branches         []Branch         `yaml:"branches"`
+	Sweeper          Sweeper          `yaml:"sweeper"`
+	Notification     Notification     `yaml:"notification"`
+	Outbox           Outbox           `yaml:"outbox"`
+	Webhooks         Webhooks         `yaml:"webhooks"`
+	EventStream      EventStream      `yaml:"eventstream"`
+	Calendar         Calendar         `yaml:"calendar"`
+	Idempotency      Idempotency      `yaml:"idempotency"`
+	Migration        Migration        `yaml:"migration"`
 }
 
+// HTTP operational endpoints such as /debug/vars are only served on InternalAddr, keep it off the public network.
+// They are not served at all when it is empty. Admin routes need AdminToken and are closed while it is empty.
 type HTTPConfig struct {
-	Port int `yaml:"port"`
+	Port         int    `yaml:"port"`
+	InternalAddr string `yaml:"internaladdr"`
+	AdminToken   string `yaml:"admintoken"`
 }
 
+// BookService Address and Provider name a single catalog provider, Providers an ordered failover chain instead.
+// Only one of the two forms may be set.
 type BookService struct {
-	Address string `yaml:"address"`
+	Address          string                  `yaml:"address"`
+	Provider         string                  `yaml:"provider"`
+	Providers        []CatalogProviderConfig `yaml:"providers"`
+	Mode             string                  `yaml:"mode"`
+	LocalCatalogPath string                  `yaml:"localcatalogpath"`
+}
+
+type CatalogProviderConfig struct {
+	Name      string `yaml:"name"`
+	Address   string `yaml:"address"`
+	TimeoutMS int    `yaml:"timeoutms"`
 }
 
 type HttpClientConfig struct {
-	TimeoutMS           int `yaml:"timeoutms"`
-	MaxIdleConns        int `yaml:"maxidleconns"`
-	MaxIdleConnsPerHost int `yaml:"maxidleconnsperhost"`
-	MaxConnsPerHost     int `yaml:"maxconnsperhost"`
-	IdleConnTimeoutSec  int `yaml:"idleconntimeoutsec"`
+	TimeoutMS           int            `yaml:"timeoutms"`
+	MaxIdleConns        int            `yaml:"maxidleconns"`
+	MaxIdleConnsPerHost int            `yaml:"maxidleconnsperhost"`
+	MaxConnsPerHost     int            `yaml:"maxconnsperhost"`
+	IdleConnTimeoutSec  int            `yaml:"idleconntimeoutsec"`
+	UserAgent           string         `yaml:"useragent"`
+	Contact             string         `yaml:"contact"`
+	RateLimit           UpstreamLimit  `yaml:"ratelimit"`
+	Recorder            RecorderConfig `yaml:"recorder"`
+}
+
+type UpstreamLimit struct {
+	RequestsPerSecond float64                  `yaml:"requestspersecond"`
+	Burst             int                      `yaml:"burst"`
+	MaxConcurrent     int                      `yaml:"maxconcurrent"`
+	MaxWaitMS         int                      `yaml:"maxwaitms"`
+	Hosts             map[string]HostRateLimit `yaml:"hosts"`
+}
+
+type HostRateLimit struct {
+	RequestsPerSecond float64 `yaml:"requestspersecond"`
+	Burst             int     `yaml:"burst"`
+}
+
+type RecorderConfig struct {
+	Mode              string   `yaml:"mode"`
+	CassettePath      string   `yaml:"cassettepath"`
+	MatchOn           []string `yaml:"matchon"`
+	RedactHeaders     []string `yaml:"redactheaders"`
+	RedactQueryParams []string `yaml:"redactqueryparams"`
+}
+
+// RateLimitConfig KeyBy only trusts identities the service can check: an API key listed in APIKeys, or the ID of a
+// registered user, which is counted per IP since it is not authenticated. Requests without one are counted by IP.
+type RateLimitConfig struct {
+	Enabled           bool                      `yaml:"enabled"`
+	KeyBy             []string                  `yaml:"keyby"`
+	APIKeys           []string                  `yaml:"apikeys"`
+	TrustProxyHeaders bool                      `yaml:"trustproxyheaders"`
+	Groups            map[string]RateLimitGroup `yaml:"groups"`
+}
+
+type RateLimitGroup struct {
+	Limit     int `yaml:"limit"`
+	WindowSec int `yaml:"windowsec"`
+}
+
+type Reservation struct {
+	CopiesPerWork    int `yaml:"copiesperwork"`
+	HoldConfirmHours int `yaml:"holdconfirmhours"`
+	BatchMaxItems    int `yaml:"batchmaxitems"`
+}
+
+type Loan struct {
+	PeriodDays  int `yaml:"perioddays"`
+	MaxRenewals int `yaml:"maxrenewals"`
+}
+
+// Fine amounts are in minor units of Currency, e.g. cents.
+type Fine struct {
+	Currency       string `yaml:"currency"`
+	DailyRate      int64  `yaml:"dailyrate"`
+	GraceDays      int    `yaml:"gracedays"`
+	MaxPerItem     int64  `yaml:"maxperitem"`
+	MaxOutstanding int64  `yaml:"maxoutstanding"`
+}
+
+// Policy limits are disabled when zero.
+type Policy struct {
+	MaxActiveReservations int   `yaml:"maxactivereservations"`
+	MaxPerSubject         int   `yaml:"maxpersubject"`
+	OnePerWork            bool  `yaml:"oneperwork"`
+	BlockedUsers          []int `yaml:"blockedusers"`
+}
+
+// Branch opening hours are keyed by lower case weekday, e.g. "monday": "09:00-17:00". Days without hours are closed.
+type Branch struct {
+	Code           string            `yaml:"code"`
+	Name           string            `yaml:"name"`
+	Address        string            `yaml:"address"`
+	OpeningHours   map[string]string `yaml:"openinghours"`
+	ClosedDates    []string          `yaml:"closeddates"`
+	PickupCapacity int               `yaml:"pickupcapacity"`
+	CopiesPerWork  int               `yaml:"copiesperwork"`
+	SlotMinutes    int               `yaml:"slotminutes"`
+	SlotCapacity   int               `yaml:"slotcapacity"`
+}
+
+// UnmarshalYAML lower cases the weekdays of OpeningHours, so "Monday" in the config file is found as "monday".
+func (b *Branch) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain Branch
+	if err := unmarshal((*plain)(b)); err != nil {
+		return err
+	}
+	hours := make(map[string]string, len(b.OpeningHours))
+	for day, h := range b.OpeningHours {
+		hours[strings.ToLower(strings.TrimSpace(day))] = h
+	}
+	b.OpeningHours = hours
+	return nil
+}
+
+// Sweeper expires reservations not picked up within GraceDays after their pickup date. Only the replica holding
+// the lease sweeps, the lease lasts LeaseSec and is renewed on every run.
+type Sweeper struct {
+	Enabled     bool `yaml:"enabled"`
+	IntervalSec int  `yaml:"intervalsec"`
+	GraceDays   int  `yaml:"gracedays"`
+	LeaseSec    int  `yaml:"leasesec"`
+}
+
+// Notification Channel is one of log, file, smtp or webhook, empty turns notifications off. Templates are keyed by
+// event and use text/template, events without one use the built in text.
+type Notification struct {
+	Channel             string                          `yaml:"channel"`
+	ReminderIntervalSec int                             `yaml:"reminderintervalsec"`
+	File                string                          `yaml:"file"`
+	SMTP                SMTPConfig                      `yaml:"smtp"`
+	Webhook             NotificationWebhook             `yaml:"webhook"`
+	Templates           map[string]NotificationTemplate `yaml:"templates"`
+}
+
+type SMTPConfig struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	From     string `yaml:"from"`
+}
+
+type NotificationWebhook struct {
+	URL       string `yaml:"url"`
+	TimeoutMS int    `yaml:"timeoutms"`
+}
+
+type NotificationTemplate struct {
+	Subject string `yaml:"subject"`
+	Body    string `yaml:"body"`
+}
+
+// Outbox controls delivery of reservation events. A failed event is retried after BackoffMS, doubling up to
+// MaxBackoffMS, and dead-lettered after MaxAttempts. Delivered events are kept for RetentionHours.
+type Outbox struct {
+	IntervalMS     int `yaml:"intervalms"`
+	BatchSize      int `yaml:"batchsize"`
+	MaxAttempts    int `yaml:"maxattempts"`
+	BackoffMS      int `yaml:"backoffms"`
+	MaxBackoffMS   int `yaml:"maxbackoffms"`
+	ClaimSec       int `yaml:"claimsec"`
+	RetentionHours int `yaml:"retentionhours"`
+}
+
+// Webhooks controls delivery to webhook subscriptions. A failed delivery is retried after BackoffMS, doubling up
+// to MaxBackoffMS, MaxAttempts times. A subscription is disabled after DisableAfter failures in a row, 0 never
+// disables it. AllowedHosts lists the addresses or CIDR ranges on an internal network webhooks may be sent to, other
+// loopback, link-local and private addresses are refused.
+type Webhooks struct {
+	IntervalMS   int      `yaml:"intervalms"`
+	BatchSize    int      `yaml:"batchsize"`
+	TimeoutMS    int      `yaml:"timeoutms"`
+	MaxAttempts  int      `yaml:"maxattempts"`
+	BackoffMS    int      `yaml:"backoffms"`
+	MaxBackoffMS int      `yaml:"maxbackoffms"`
+	ClaimSec     int      `yaml:"claimsec"`
+	DisableAfter int      `yaml:"disableafter"`
+	AllowedHosts []string `yaml:"allowedhosts"`
+}
+
+// EventStream ReplayBuffer is how many recent reservation events a reconnecting client can resume from,
+// ClientBuffer how many events a slow client may fall behind before it is disconnected.
+type EventStream struct {
+	ReplayBuffer int `yaml:"replaybuffer"`
+	ClientBuffer int `yaml:"clientbuffer"`
+	HeartbeatSec int `yaml:"heartbeatsec"`
+}
+
+// Calendar AlarmMinutes is how long before a pickup the calendar export reminds the user.
+type Calendar struct {
+	AlarmMinutes int `yaml:"alarmminutes"`
+}
+
+// Idempotency responses to requests with an Idempotency-Key are replayed for TTLHours. LockSec is how long a retry
+// waits for the first request before it may run again, when that one never finished. Requests with a key are read
+// into memory to fingerprint them, MaxBodyBytes caps their body.
+type Idempotency struct {
+	TTLHours     int `yaml:"ttlhours"`
+	LockSec      int `yaml:"locksec"`
+	MaxBodyBytes int `yaml:"maxbodybytes"`
+}
+
+// Migration RunOnStartup applies pending schema migrations before the service starts. A replica migrating holds a
+// lock for LockSec, the others wait up to WaitSec for it and then find nothing left to apply.
+type Migration struct {
+	RunOnStartup bool `yaml:"runonstartup"`
+	LockSec      int  `yaml:"locksec"`
+	WaitSec      int  `yaml:"waitsec"`
 }
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func Test_BranchOpeningHours(t *testing.T) {
+	var c GlobalConfig
+	err := yaml.UnmarshalStrict([]byte(`
+branches:
+  - code: central
+    openinghours:
+      Monday: "09:00-17:00"
+      " TUESDAY ": "10:00-16:00"
+`), &c)
+	if err != nil {
+		t.Fatalf("UnmarshalStrict() error = %v", err)
+	}
+	hours := c.Branches[0].OpeningHours
+	if len(hours) != 2 || hours["monday"] != "09:00-17:00" || hours["tuesday"] != "10:00-16:00" {
+		t.Errorf("OpeningHours = %v", hours)
+	}
+}
@@ -3,9 +3,12 @@ module gihub.com/gadhittana01/book-project
 go 1.19
 
 require (
-	github.com/go-chi/chi v1.5.4 // indirect
-	github.com/golang/mock v1.6.0 // indirect
+	github.com/go-chi/chi v1.5.4
+	github.com/golang/mock v1.6.0
+	gopkg.in/yaml.v2 v2.4.0
+)
+
+require (
 	github.com/h2non/parth v0.0.0-20190131123155-b4df798d6542 // indirect
 	gopkg.in/h2non/gock.v1 v1.1.2 // indirect
-	gopkg.in/yaml.v2 v2.4.0 // indirect
 )
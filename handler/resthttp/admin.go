@@ -0,0 +1,33 @@
+package resthttp
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"time"
+)
+
+const HeaderAdminToken = "X-Admin-Token"
+
+type adminAuth struct {
+	token string
+}
+
+func newAdminAuth(token string) *adminAuth {
+	return &adminAuth{
+		token: token,
+	}
+}
+
+// middleware only lets requests carrying the configured admin token through. Admin routes stay closed while no
+// token is configured.
+func (a *adminAuth) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get(HeaderAdminToken)
+		if a.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(a.token)) != 1 {
+			resp := newResponse(time.Now())
+			resp.setUnauthorized("Admin token is missing or invalid", w)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
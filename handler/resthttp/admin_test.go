@@ -0,0 +1,66 @@
+package resthttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/services"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_adminAuth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		adminToken string
+		token      string
+		wantCode   int
+	}{
+		{name: "valid token", method: "GET", path: "/get-webhooks", adminToken: "s3cret", token: "s3cret", wantCode: http.StatusOK},
+		{name: "wrong token", method: "GET", path: "/get-webhooks", adminToken: "s3cret", token: "guess", wantCode: http.StatusUnauthorized},
+		{name: "missing token", method: "GET", path: "/get-webhooks", adminToken: "s3cret", wantCode: http.StatusUnauthorized},
+		{name: "no token configured", method: "GET", path: "/get-webhooks", wantCode: http.StatusUnauthorized},
+		{name: "expire with token", method: "POST", path: "/expire-reservations", adminToken: "s3cret", token: "s3cret", wantCode: http.StatusOK},
+		{name: "expire without token", method: "POST", path: "/expire-reservations", adminToken: "s3cret", wantCode: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhookMock := NewMockWebhookService(ctrl)
+			sweeperMock := NewMockSweeperService(ctrl)
+			if tt.wantCode == http.StatusOK {
+				webhookMock.EXPECT().GetWebhooks(gomock.Any()).Return([]services.WebhookRes{}, nil).AnyTimes()
+				sweeperMock.EXPECT().Sweep(gomock.Any()).Return(services.SweepRes{}, nil).AnyTimes()
+			}
+			router := NewRoutes(RouterDependencies{WS: webhookMock, SW: sweeperMock, AdminToken: tt.adminToken})
+
+			r := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.token != "" {
+				r.Header.Set(HeaderAdminToken, tt.token)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+			if w.Code != tt.wantCode {
+				t.Errorf("status = %v, want %v", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+// testAdminOnly checks that every route of routes ("METHOD /path") answers 401 without the admin token.
+func testAdminOnly(t *testing.T, routes ...string) {
+	t.Helper()
+	router := NewRoutes(RouterDependencies{AdminToken: "s3cret"})
+	for _, route := range routes {
+		parts := strings.SplitN(route, " ", 2)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(parts[0], parts[1], nil))
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("%s without admin token status = %v, want %v", route, w.Code, http.StatusUnauthorized)
+		}
+	}
+}
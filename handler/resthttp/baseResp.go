@@ -2,9 +2,12 @@ package resthttp
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"time"
+
+	"gihub.com/gadhittana01/book-project/services"
 )
 
 type baseResp struct {
@@ -81,6 +84,57 @@ func (br *baseResp) setOK(data interface{}, w http.ResponseWriter) {
 	w.Write(respBytes)
 }
 
+func (br *baseResp) setServiceUnavailable(data interface{}, w http.ResponseWriter) {
+	br.Data = data
+	br.setElapsedTime()
+	br.IsError = true
+	respBytes, err := json.Marshal(br)
+	if err != nil {
+		log.Println(br.RequestID, "setServiceUnavailable error : %+v", err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusServiceUnavailable)
+	w.Write(respBytes)
+}
+
+func (br *baseResp) setTooManyRequests(msg string, w http.ResponseWriter) {
+	if msg == "" {
+		msg = "Too many requests"
+	}
+	br.Data = map[string]interface{}{
+		"error_message": msg,
+		"status":        http.StatusTooManyRequests,
+	}
+	br.setElapsedTime()
+	br.IsError = true
+	respBytes, err := json.Marshal(br)
+	if err != nil {
+		log.Println(br.RequestID, "setTooManyRequests error : %+v", err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+	w.Write(respBytes)
+}
+
+func (br *baseResp) setRequestEntityTooLarge(msg string, w http.ResponseWriter) {
+	if msg == "" {
+		msg = "Request body too large"
+	}
+	br.Data = map[string]interface{}{
+		"error_message": msg,
+		"status":        http.StatusRequestEntityTooLarge,
+	}
+	br.setElapsedTime()
+	br.IsError = true
+	respBytes, err := json.Marshal(br)
+	if err != nil {
+		log.Println(br.RequestID, "setRequestEntityTooLarge error : %+v", err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusRequestEntityTooLarge)
+	w.Write(respBytes)
+}
+
 func (br *baseResp) setNotFound(msg string, w http.ResponseWriter) {
 	if msg == "" {
 		msg = "Not found"
@@ -99,6 +153,25 @@ func (br *baseResp) setNotFound(msg string, w http.ResponseWriter) {
 	w.Write(respBytes)
 }
 
+func (br *baseResp) setUnauthorized(msg string, w http.ResponseWriter) {
+	if msg == "" {
+		msg = "Unauthorized"
+	}
+	br.Data = map[string]interface{}{
+		"error_message": msg,
+		"status":        http.StatusUnauthorized,
+	}
+	br.setElapsedTime()
+	br.IsError = true
+	respBytes, err := json.Marshal(br)
+	if err != nil {
+		log.Println(br.RequestID, "setUnauthorized error : %+v", err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnauthorized)
+	w.Write(respBytes)
+}
+
 func (br *baseResp) setForbidden(msg string, w http.ResponseWriter) {
 	if msg == "" {
 		msg = "Forbidden."
@@ -151,3 +224,103 @@ func (br *baseResp) setInternalServerErrorWithStatus(msg string, w http.Response
 	w.WriteHeader(http.StatusInternalServerError)
 	w.Write(respBytes)
 }
+
+func (br *baseResp) setConflict(msg string, w http.ResponseWriter) {
+	if msg == "" {
+		msg = "Conflict"
+	}
+	br.Data = map[string]interface{}{
+		"error_message": msg,
+		"status":        http.StatusConflict,
+	}
+	br.setElapsedTime()
+	br.IsError = true
+	respBytes, err := json.Marshal(br)
+	if err != nil {
+		log.Println(br.RequestID, "setConflict error : %+v", err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusConflict)
+	w.Write(respBytes)
+}
+
+func (br *baseResp) setUnprocessableEntity(msg string, w http.ResponseWriter) {
+	if msg == "" {
+		msg = "Unprocessable entity"
+	}
+	br.Data = map[string]interface{}{
+		"error_message": msg,
+		"status":        http.StatusUnprocessableEntity,
+	}
+	br.setElapsedTime()
+	br.IsError = true
+	respBytes, err := json.Marshal(br)
+	if err != nil {
+		log.Println(br.RequestID, "setUnprocessableEntity error : %+v", err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	w.Write(respBytes)
+}
+
+// setError writes a service error with the status matching its code, anything else is an internal server error.
+func (br *baseResp) setError(err error, w http.ResponseWriter) {
+	var se *services.ServiceError
+	if !errors.As(err, &se) {
+		br.setInternalServerError(err.Error(), w)
+		return
+	}
+
+	if len(se.Details) > 0 {
+		br.setErrorWithDetails(serviceErrorStatus(se.Code), se.Message, se.Details, w)
+		return
+	}
+
+	switch se.Code {
+	case services.ErrCodeNotFound:
+		br.setNotFound(se.Message, w)
+	case services.ErrCodeConflict:
+		br.setConflict(se.Message, w)
+	case services.ErrCodeInvalidRequest:
+		br.setBadRequest(se.Message, w)
+	case services.ErrCodeForbidden:
+		br.setForbidden(se.Message, w)
+	case services.ErrCodeUnprocessable:
+		br.setUnprocessableEntity(se.Message, w)
+	default:
+		br.setInternalServerError(se.Message, w)
+	}
+}
+
+func (br *baseResp) setErrorWithDetails(status int, msg string, details interface{}, w http.ResponseWriter) {
+	br.Data = map[string]interface{}{
+		"error_message": msg,
+		"status":        status,
+		"details":       details,
+	}
+	br.setElapsedTime()
+	br.IsError = true
+	respBytes, err := json.Marshal(br)
+	if err != nil {
+		log.Println(br.RequestID, "setErrorWithDetails error : %+v", err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(respBytes)
+}
+
+func serviceErrorStatus(code string) int {
+	switch code {
+	case services.ErrCodeNotFound:
+		return http.StatusNotFound
+	case services.ErrCodeConflict:
+		return http.StatusConflict
+	case services.ErrCodeInvalidRequest:
+		return http.StatusBadRequest
+	case services.ErrCodeForbidden:
+		return http.StatusForbidden
+	case services.ErrCodeUnprocessable:
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusInternalServerError
+}
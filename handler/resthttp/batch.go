@@ -0,0 +1,39 @@
+package resthttp
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/services"
+)
+
+func (p bookHandler) BorrowBooks(w http.ResponseWriter, r *http.Request) {
+	resp := newResponse(time.Now())
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+
+	reqBody := services.BorrowBooksReq{}
+	err = json.Unmarshal(body, &reqBody)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+
+	res, err := p.service.BorrowBooks(context.Background(), reqBody)
+	if err != nil {
+		resp.setError(err, w)
+		return
+	}
+
+	resp.setOK(map[string]interface{}{
+		"data": res,
+	}, w)
+	return
+}
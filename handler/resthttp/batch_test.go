@@ -0,0 +1,75 @@
+package resthttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/services"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_BorrowBooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		body     string
+		mock     func() BookService
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "test normal flow",
+			body: `{"atomic": true, "items": [{"key": "123", "branch": "central", "pickup_date": "2022-01-24", "user_id": 1}]}`,
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().BorrowBooks(gomock.Any(), services.BorrowBooksReq{
+					Atomic: true,
+					Items:  []services.BorrowBookReq{{BookKey: "123", Branch: "central", PickUpDate: "2022-01-24", UserID: 1}},
+				}).Return(services.BorrowBooksRes{
+					Atomic:  true,
+					Created: 1,
+					Items:   []services.BorrowBookItemRes{{Index: 0, Status: services.BatchItemCreated, Reservation: &services.BorrowBookRes{ID: 1}}},
+				}, nil)
+				return bookMock
+			},
+			wantCode: http.StatusOK,
+			wantBody: `"created":1`,
+		},
+		{
+			name: "test bad request",
+			body: `{"items": "boba"}`,
+			mock: func() BookService {
+				return NewMockBookService(ctrl)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "test invalid batch",
+			body: `{"items": []}`,
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().BorrowBooks(gomock.Any(), gomock.Any()).Return(services.BorrowBooksRes{}, &services.ServiceError{Code: services.ErrCodeInvalidRequest, Message: "Items are empty"})
+				return bookMock
+			},
+			wantCode: http.StatusBadRequest,
+			wantBody: "Items are empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRoutes(RouterDependencies{BS: tt.mock()})
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/reservations:batch", strings.NewReader(tt.body)))
+
+			if w.Code != tt.wantCode {
+				t.Errorf("BorrowBooks() code = %v, want %v", w.Code, tt.wantCode)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("BorrowBooks() body = %v, want it to contain %v", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
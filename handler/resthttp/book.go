@@ -39,7 +39,8 @@ func (p bookHandler) GetListOfBooks(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp.setOK(map[string]interface{}{
-		"books": res.Books,
+		"books":    res.Books,
+		"provider": res.Provider,
 	}, w)
 	return
 }
@@ -61,12 +62,13 @@ func (p bookHandler) BorrowBook(w http.ResponseWriter, r *http.Request) {
 	}
 	res, err := p.service.BorrowBook(context.Background(), reqBody)
 	if err != nil {
-		resp.setInternalServerError(err.Error(), w)
+		resp.setError(err, w)
 		return
 	}
 
 	resp.setOK(map[string]interface{}{
-		"data": fmt.Sprintf("Book with key %s successfully reserved at %s", res.Book.Key, res.PickUpDate),
+		"data":     fmt.Sprintf("Book with key %s successfully reserved at %s", res.Book.Key, res.PickUpDate),
+		"provider": res.Provider,
 	}, w)
 	return
 }
@@ -89,8 +91,27 @@ func (p bookHandler) GetBookReservation(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
+	format, err := exportFormat(r)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+	if format != exportFormatJSON {
+		p.exportBookReservation(w, r, format, services.ExportBookReservationReq{
+			UserID: uid,
+			Branch: strings.TrimSpace(query.Get("branch")),
+			Status: strings.TrimSpace(query.Get("status")),
+			Key:    strings.TrimSpace(query.Get("key")),
+			Query:  strings.TrimSpace(query.Get("q")),
+			From:   strings.TrimSpace(query.Get("from")),
+			To:     strings.TrimSpace(query.Get("to")),
+		})
+		return
+	}
+
 	res, err := p.service.GetBookReservation(context.Background(), services.GetBookReservationReq{
 		UserID: uid,
+		Branch: strings.TrimSpace(query.Get("branch")),
 	})
 	if err != nil {
 		resp.setInternalServerError(err.Error(), w)
@@ -102,3 +123,101 @@ func (p bookHandler) GetBookReservation(w http.ResponseWriter, r *http.Request)
 	}, w)
 	return
 }
+
+func (p bookHandler) GetReadiness(w http.ResponseWriter, r *http.Request) {
+	resp := newResponse(time.Now())
+
+	res, err := p.service.GetCatalogHealth(context.Background())
+	if err != nil {
+		resp.setInternalServerError(err.Error(), w)
+		return
+	}
+
+	if !res.Ready {
+		resp.setServiceUnavailable(res, w)
+		return
+	}
+
+	resp.setOK(res, w)
+	return
+}
+
+func (p bookHandler) JoinWaitlist(w http.ResponseWriter, r *http.Request) {
+	resp := newResponse(time.Now())
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+
+	reqBody := services.JoinWaitlistReq{}
+	err = json.Unmarshal(body, &reqBody)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+	res, err := p.service.JoinWaitlist(context.Background(), reqBody)
+	if err != nil {
+		resp.setError(err, w)
+		return
+	}
+
+	resp.setOK(map[string]interface{}{
+		"data":           fmt.Sprintf("Joined the waitlist for book with key %s at position %d", res.BookKey, res.QueuePosition),
+		"queue_position": res.QueuePosition,
+	}, w)
+	return
+}
+
+func (p bookHandler) CancelReservation(w http.ResponseWriter, r *http.Request) {
+	resp := newResponse(time.Now())
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+
+	reqBody := services.CancelReservationReq{}
+	err = json.Unmarshal(body, &reqBody)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+	if err := p.service.CancelReservation(context.Background(), reqBody); err != nil {
+		resp.setError(err, w)
+		return
+	}
+
+	resp.setOK(map[string]interface{}{
+		"data": fmt.Sprintf("Reservation %d successfully cancelled", reqBody.ID),
+	}, w)
+	return
+}
+
+func (p bookHandler) ConfirmReservation(w http.ResponseWriter, r *http.Request) {
+	resp := newResponse(time.Now())
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+
+	reqBody := services.ConfirmReservationReq{}
+	err = json.Unmarshal(body, &reqBody)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+	if err := p.service.ConfirmReservation(context.Background(), reqBody); err != nil {
+		resp.setError(err, w)
+		return
+	}
+
+	resp.setOK(map[string]interface{}{
+		"data": fmt.Sprintf("Reservation %d successfully confirmed", reqBody.ID),
+	}, w)
+	return
+}
@@ -355,3 +355,269 @@ func Test_GetBookReservation(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetReadiness(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	type fields struct {
+		service BookService
+	}
+	tests := []struct {
+		name       string
+		fields     func() fields
+		wantStatus int
+	}{
+		{
+			name: "test ready",
+			fields: func() fields {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().GetCatalogHealth(gomock.Any()).Return(services.GetCatalogHealthRes{
+					Ready: true,
+					Providers: []services.ProviderHealth{
+						{
+							Name:    "openlibrary",
+							Healthy: true,
+						},
+					},
+				}, nil)
+				return fields{
+					service: bookMock,
+				}
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "test not ready",
+			fields: func() fields {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().GetCatalogHealth(gomock.Any()).Return(services.GetCatalogHealthRes{
+					Ready: false,
+					Providers: []services.ProviderHealth{
+						{
+							Name:                "openlibrary",
+							ConsecutiveFailures: 2,
+							LastError:           "timeout",
+						},
+					},
+				}, nil)
+				return fields{
+					service: bookMock,
+				}
+			},
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name: "test internal server error",
+			fields: func() fields {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().GetCatalogHealth(gomock.Any()).Return(services.GetCatalogHealthRes{}, errors.New("error"))
+				return fields{
+					service: bookMock,
+				}
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := tt.fields()
+			i := bookHandler{
+				service: field.service,
+			}
+			w := httptest.NewRecorder()
+			i.GetReadiness(w, httptest.NewRequest("GET", "http://localhost:8000/readiness", strings.NewReader("")))
+			if w.Code != tt.wantStatus {
+				t.Errorf("GetReadiness() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_JoinWaitlist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	body := `{
+		"key" : "/works/OL98501W",
+		"pickup_date" : "2022-02-26",
+		"subject" : "love",
+		"user_id" : 2
+	}`
+
+	tests := []struct {
+		name     string
+		body     string
+		mock     func() BookService
+		wantCode int
+	}{
+		{
+			name: "test normal flow",
+			body: body,
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().JoinWaitlist(gomock.Any(), services.JoinWaitlistReq{
+					BookKey:    "/works/OL98501W",
+					PickUpDate: "2022-02-26",
+					Subject:    "love",
+					UserID:     2,
+				}).Return(services.JoinWaitlistRes{BookKey: "/works/OL98501W", QueuePosition: 1}, nil)
+				return bookMock
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "test bad request",
+			body: "",
+			mock: func() BookService {
+				return NewMockBookService(ctrl)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "test conflict",
+			body: body,
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().JoinWaitlist(gomock.Any(), gomock.Any()).Return(services.JoinWaitlistRes{}, &services.ServiceError{
+					Code:    services.ErrCodeConflict,
+					Message: "Book is available, borrow it instead of joining the waitlist",
+				})
+				return bookMock
+			},
+			wantCode: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			i := bookHandler{
+				service: tt.mock(),
+			}
+			i.JoinWaitlist(w, httptest.NewRequest("POST", "http://localhost:8000/join-waitlist", strings.NewReader(tt.body)))
+			if w.Code != tt.wantCode {
+				t.Errorf("JoinWaitlist() status = %v, want %v", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_CancelReservation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		body     string
+		mock     func() BookService
+		wantCode int
+	}{
+		{
+			name: "test normal flow",
+			body: `{"id": 3, "user_id": 2}`,
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().CancelReservation(gomock.Any(), services.CancelReservationReq{ID: 3, UserID: 2}).Return(nil)
+				return bookMock
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "test not found",
+			body: `{"id": 3, "user_id": 2}`,
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().CancelReservation(gomock.Any(), gomock.Any()).Return(&services.ServiceError{Code: services.ErrCodeNotFound, Message: "Reservation not found"})
+				return bookMock
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "test internal server error",
+			body: `{"id": 3, "user_id": 2}`,
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().CancelReservation(gomock.Any(), gomock.Any()).Return(errors.New("error"))
+				return bookMock
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			i := bookHandler{
+				service: tt.mock(),
+			}
+			i.CancelReservation(w, httptest.NewRequest("POST", "http://localhost:8000/cancel-reservation", strings.NewReader(tt.body)))
+			if w.Code != tt.wantCode {
+				t.Errorf("CancelReservation() status = %v, want %v", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_ConfirmReservation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		body     string
+		mock     func() BookService
+		wantCode int
+	}{
+		{
+			name: "test normal flow",
+			body: `{"id": 3, "user_id": 2}`,
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().ConfirmReservation(gomock.Any(), services.ConfirmReservationReq{ID: 3, UserID: 2}).Return(nil)
+				return bookMock
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "test invalid request",
+			body: `{"id": 3}`,
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().ConfirmReservation(gomock.Any(), gomock.Any()).Return(&services.ServiceError{Code: services.ErrCodeInvalidRequest, Message: "Reservation ID and user ID are required"})
+				return bookMock
+			},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			i := bookHandler{
+				service: tt.mock(),
+			}
+			i.ConfirmReservation(w, httptest.NewRequest("POST", "http://localhost:8000/confirm-reservation", strings.NewReader(tt.body)))
+			if w.Code != tt.wantCode {
+				t.Errorf("ConfirmReservation() status = %v, want %v", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_BorrowBookPolicyDenied(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	bookMock := NewMockBookService(ctrl)
+	bookMock.EXPECT().BorrowBook(gomock.Any(), gomock.Any()).Return(services.BorrowBookRes{}, &services.ServiceError{
+		Code:    services.ErrCodeForbidden,
+		Message: "Reservation denied by borrowing policy",
+		Details: []services.ErrorDetail{
+			{Rule: services.PolicyOnePerWork, Reason: "User already has a reservation for book with key /works/OL98501W"},
+		},
+	})
+
+	w := httptest.NewRecorder()
+	i := bookHandler{
+		service: bookMock,
+	}
+	i.BorrowBook(w, httptest.NewRequest("POST", "http://localhost:8000/borrow-book", strings.NewReader(`{"key": "/works/OL98501W", "user_id": 2}`)))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("BorrowBook() status = %v, want %v", w.Code, http.StatusForbidden)
+	}
+	if !strings.Contains(w.Body.String(), `"details":[{"rule":"one_per_work"`) {
+		t.Errorf("BorrowBook() body = %v", w.Body.String())
+	}
+}
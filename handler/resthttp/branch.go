@@ -0,0 +1,67 @@
+package resthttp
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/services"
+)
+
+func (p bookHandler) GetBranches(w http.ResponseWriter, r *http.Request) {
+	resp := newResponse(time.Now())
+
+	res, err := p.service.GetBranches(context.Background())
+	if err != nil {
+		resp.setError(err, w)
+		return
+	}
+
+	resp.setOK(map[string]interface{}{
+		"data": res,
+	}, w)
+	return
+}
+
+func (p bookHandler) GetBookAvailability(w http.ResponseWriter, r *http.Request) {
+	resp := newResponse(time.Now())
+
+	key := strings.TrimSpace(r.URL.Query().Get("key"))
+	if key == "" {
+		resp.setBadRequest(InvalidRequestParam, w)
+		return
+	}
+
+	res, err := p.service.GetBookAvailability(context.Background(), services.GetBookAvailabilityReq{
+		BookKey: key,
+	})
+	if err != nil {
+		resp.setError(err, w)
+		return
+	}
+
+	resp.setOK(map[string]interface{}{
+		"data": res,
+	}, w)
+	return
+}
+
+func (p bookHandler) GetPickupSlots(w http.ResponseWriter, r *http.Request) {
+	resp := newResponse(time.Now())
+
+	query := r.URL.Query()
+	res, err := p.service.GetPickupSlots(context.Background(), services.GetPickupSlotsReq{
+		Branch: strings.TrimSpace(query.Get("branch")),
+		Date:   strings.TrimSpace(query.Get("date")),
+	})
+	if err != nil {
+		resp.setError(err, w)
+		return
+	}
+
+	resp.setOK(map[string]interface{}{
+		"data": res,
+	}, w)
+	return
+}
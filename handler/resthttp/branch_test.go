@@ -0,0 +1,130 @@
+package resthttp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/services"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_GetBranches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	bookMock := NewMockBookService(ctrl)
+	bookMock.EXPECT().GetBranches(gomock.Any()).Return([]services.BranchRes{{Code: "central"}}, nil)
+
+	w := httptest.NewRecorder()
+	bookHandler{service: bookMock}.GetBranches(w, httptest.NewRequest("GET", "http://localhost:8000/get-branches", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("GetBranches() status = %v, want %v", w.Code, http.StatusOK)
+	}
+}
+
+func Test_GetBookAvailability(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		url      string
+		mock     func() BookService
+		wantCode int
+	}{
+		{
+			name: "test normal flow",
+			url:  "http://localhost:8000/get-book-availability?key=/works/OL98501W",
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().GetBookAvailability(gomock.Any(), services.GetBookAvailabilityReq{BookKey: "/works/OL98501W"}).Return([]services.BranchAvailabilityRes{{Branch: "central", Available: 1}}, nil)
+				return bookMock
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "test missing key",
+			url:  "http://localhost:8000/get-book-availability",
+			mock: func() BookService {
+				return NewMockBookService(ctrl)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "test error",
+			url:  "http://localhost:8000/get-book-availability?key=/works/OL98501W",
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().GetBookAvailability(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+				return bookMock
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			i := bookHandler{
+				service: tt.mock(),
+			}
+			i.GetBookAvailability(w, httptest.NewRequest("GET", tt.url, nil))
+			if w.Code != tt.wantCode {
+				t.Errorf("GetBookAvailability() status = %v, want %v", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_GetPickupSlots(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		url      string
+		mock     func() BookService
+		wantCode int
+	}{
+		{
+			name: "test normal flow",
+			url:  "http://localhost:8000/get-pickup-slots?branch=central&date=2022-01-24",
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().GetPickupSlots(gomock.Any(), services.GetPickupSlotsReq{Branch: "central", Date: "2022-01-24"}).Return([]services.PickupSlotRes{{Start: "09:00", End: "09:30"}}, nil)
+				return bookMock
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "test bad request",
+			url:  "http://localhost:8000/get-pickup-slots?branch=central",
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().GetPickupSlots(gomock.Any(), gomock.Any()).Return(nil, &services.ServiceError{Code: services.ErrCodeInvalidRequest, Message: "Branch and date are required"})
+				return bookMock
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "test branch closed",
+			url:  "http://localhost:8000/get-pickup-slots?branch=central&date=2022-01-23",
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().GetPickupSlots(gomock.Any(), gomock.Any()).Return(nil, &services.ServiceError{Code: services.ErrCodeConflict, Message: "Branch is closed on the pickup date"})
+				return bookMock
+			},
+			wantCode: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			i := bookHandler{
+				service: tt.mock(),
+			}
+			i.GetPickupSlots(w, httptest.NewRequest("GET", tt.url, nil))
+			if w.Code != tt.wantCode {
+				t.Errorf("GetPickupSlots() status = %v, want %v", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package resthttp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/services"
+	"github.com/go-chi/chi"
+)
+
+const (
+	icsDateLayout      = "20060102"
+	icsLocalTimeLayout = "20060102T150405"
+	icsUTCTimeLayout   = "20060102T150405Z"
+	// icsLineLimit is the longest content line RFC 5545 allows, in octets without the line break.
+	icsLineLimit = 75
+)
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func (p bookHandler) GetReservationCalendar(w http.ResponseWriter, r *http.Request) {
+	resp := newResponse(time.Now())
+
+	uid, err := strconv.Atoi(strings.TrimSpace(chi.URLParam(r, "id")))
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+
+	res, err := p.service.GetReservationCalendar(context.Background(), services.GetReservationCalendarReq{
+		UserID: uid,
+	})
+	if err != nil {
+		resp.setError(err, w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="reservations-%d.ics"`, uid))
+	w.WriteHeader(http.StatusOK)
+	w.Write(encodeCalendar(res))
+	return
+}
+
+// encodeCalendar writes the events as an RFC 5545 calendar. Pickup times are floating local times, they mean the
+// same wall clock time wherever the calendar is opened, which is the time at the branch.
+func encodeCalendar(res services.ReservationCalendarRes) []byte {
+	var buf bytes.Buffer
+	line := func(name, value string) {
+		writeICSLine(&buf, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//book-project//reservations//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("X-WR-CALNAME", "Book pickups")
+	for _, event := range res.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", event.UID)
+		line("SEQUENCE", strconv.Itoa(event.Sequence))
+		line("DTSTAMP", event.Stamp.UTC().Format(icsUTCTimeLayout))
+		if event.AllDay {
+			writeICSLine(&buf, "DTSTART;VALUE=DATE:"+event.Start.Format(icsDateLayout))
+			writeICSLine(&buf, "DTEND;VALUE=DATE:"+event.End.Format(icsDateLayout))
+		} else {
+			line("DTSTART", event.Start.Format(icsLocalTimeLayout))
+			line("DTEND", event.End.Format(icsLocalTimeLayout))
+		}
+		line("SUMMARY", icsEscaper.Replace(event.Summary))
+		line("DESCRIPTION", icsEscaper.Replace(event.Description))
+		line("LOCATION", icsEscaper.Replace(event.Location))
+		line("STATUS", event.Status)
+		if event.Status != services.CalendarStatusCancelled {
+			line("BEGIN", "VALARM")
+			line("ACTION", "DISPLAY")
+			line("DESCRIPTION", icsEscaper.Replace(event.Summary))
+			line("TRIGGER", fmt.Sprintf("-PT%dM", event.AlarmMinutes))
+			line("END", "VALARM")
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return buf.Bytes()
+}
+
+// writeICSLine folds content lines longer than 75 octets, never splitting a UTF-8 character.
+func writeICSLine(buf *bytes.Buffer, content string) {
+	limit := icsLineLimit
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && content[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		buf.WriteString(content[:cut])
+		buf.WriteString("\r\n ")
+		content = content[cut:]
+		// Continuation lines start with a space that counts towards the limit.
+		limit = icsLineLimit - 1
+	}
+	buf.WriteString(content)
+	buf.WriteString("\r\n")
+}
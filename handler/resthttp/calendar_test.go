@@ -0,0 +1,165 @@
+package resthttp
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/services"
+	"github.com/go-chi/chi"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_GetReservationCalendar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	stamp := time.Date(2022, 1, 20, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		path     string
+		mock     func() BookService
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "test normal flow",
+			path: "/users/1/reservations.ics",
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().GetReservationCalendar(gomock.Any(), services.GetReservationCalendarReq{UserID: 1}).Return(services.ReservationCalendarRes{
+					UserID: 1,
+					Events: []services.CalendarEventRes{
+						{
+							UID:          "reservation-4@book-project",
+							Sequence:     1,
+							Status:       services.CalendarStatusConfirmed,
+							Summary:      "Pick up Matilda",
+							Description:  "Pick up Matilda at Central Library. Reservation #4",
+							Location:     "Central Library, 1 Main St",
+							Start:        time.Date(2022, 1, 24, 9, 30, 0, 0, time.UTC),
+							End:          time.Date(2022, 1, 24, 9, 45, 0, 0, time.UTC),
+							AlarmMinutes: 60,
+							Stamp:        stamp,
+						},
+						{
+							UID:      "reservation-7@book-project",
+							Sequence: 2,
+							Status:   services.CalendarStatusCancelled,
+							Summary:  "Pick up Dune",
+							Location: "north",
+							Start:    time.Date(2022, 1, 26, 0, 0, 0, 0, time.UTC),
+							End:      time.Date(2022, 1, 27, 0, 0, 0, 0, time.UTC),
+							AllDay:   true,
+							Stamp:    stamp,
+						},
+					},
+				}, nil)
+				return bookMock
+			},
+			wantCode: http.StatusOK,
+			wantBody: "BEGIN:VCALENDAR\r\n" +
+				"VERSION:2.0\r\n" +
+				"PRODID:-//book-project//reservations//EN\r\n" +
+				"CALSCALE:GREGORIAN\r\n" +
+				"X-WR-CALNAME:Book pickups\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"UID:reservation-4@book-project\r\n" +
+				"SEQUENCE:1\r\n" +
+				"DTSTAMP:20220120T080000Z\r\n" +
+				"DTSTART:20220124T093000\r\n" +
+				"DTEND:20220124T094500\r\n" +
+				"SUMMARY:Pick up Matilda\r\n" +
+				"DESCRIPTION:Pick up Matilda at Central Library. Reservation #4\r\n" +
+				"LOCATION:Central Library\\, 1 Main St\r\n" +
+				"STATUS:CONFIRMED\r\n" +
+				"BEGIN:VALARM\r\n" +
+				"ACTION:DISPLAY\r\n" +
+				"DESCRIPTION:Pick up Matilda\r\n" +
+				"TRIGGER:-PT60M\r\n" +
+				"END:VALARM\r\n" +
+				"END:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"UID:reservation-7@book-project\r\n" +
+				"SEQUENCE:2\r\n" +
+				"DTSTAMP:20220120T080000Z\r\n" +
+				"DTSTART;VALUE=DATE:20220126\r\n" +
+				"DTEND;VALUE=DATE:20220127\r\n" +
+				"SUMMARY:Pick up Dune\r\n" +
+				"DESCRIPTION:\r\n" +
+				"LOCATION:north\r\n" +
+				"STATUS:CANCELLED\r\n" +
+				"END:VEVENT\r\n" +
+				"END:VCALENDAR\r\n",
+		},
+		{
+			name: "test invalid user id",
+			path: "/users/abc/reservations.ics",
+			mock: func() BookService {
+				return NewMockBookService(ctrl)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "test unknown user",
+			path: "/users/2/reservations.ics",
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().GetReservationCalendar(gomock.Any(), gomock.Any()).Return(services.ReservationCalendarRes{}, &services.ServiceError{Code: services.ErrCodeNotFound})
+				return bookMock
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := chi.NewRouter()
+			router.Get("/users/{id}/reservations.ics", newBookHandler(tt.mock()).GetReservationCalendar)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if w.Code != tt.wantCode {
+				t.Errorf("GetReservationCalendar() code = %v, want %v", w.Code, tt.wantCode)
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Errorf("GetReservationCalendar() body = %q, want %q", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func Test_writeICSLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "short line", content: "SUMMARY:Pick up Matilda"},
+		{name: "long line", content: "DESCRIPTION:" + strings.Repeat("a", 200)},
+		{name: "multi byte characters", content: "SUMMARY:" + strings.Repeat("é", 100)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writeICSLine(&buf, tt.content)
+			b := buf.String()
+			lines := strings.Split(strings.TrimSuffix(b, "\r\n"), "\r\n")
+			unfolded := lines[0]
+			for _, line := range lines {
+				if len(line) > icsLineLimit {
+					t.Errorf("writeICSLine() line of %d octets: %q", len(line), line)
+				}
+			}
+			for _, line := range lines[1:] {
+				if !strings.HasPrefix(line, " ") {
+					t.Errorf("writeICSLine() continuation %q does not start with a space", line)
+				}
+				unfolded += strings.TrimPrefix(line, " ")
+			}
+			if unfolded != tt.content {
+				t.Errorf("writeICSLine() unfolded = %q, want %q", unfolded, tt.content)
+			}
+		})
+	}
+}
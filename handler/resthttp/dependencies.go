@@ -2,6 +2,7 @@ package resthttp
 
 import (
 	"context"
+	"time"
 
 	"gihub.com/gadhittana01/book-project/services"
 )
@@ -11,5 +12,59 @@ type (
 		GetListOfBooks(ctx context.Context, req services.GetListOfBooksReq) (services.GetListOfBooksResp, error)
 		BorrowBook(ctx context.Context, req services.BorrowBookReq) (services.BorrowBookRes, error)
 		GetBookReservation(ctx context.Context, req services.GetBookReservationReq) (map[int][]services.GetBookReservationRes, error)
+		GetCatalogHealth(ctx context.Context) (services.GetCatalogHealthRes, error)
+		JoinWaitlist(ctx context.Context, req services.JoinWaitlistReq) (services.JoinWaitlistRes, error)
+		CancelReservation(ctx context.Context, req services.CancelReservationReq) error
+		ConfirmReservation(ctx context.Context, req services.ConfirmReservationReq) error
+		CheckoutBook(ctx context.Context, req services.CheckoutBookReq) (services.LoanRes, error)
+		ReturnBook(ctx context.Context, req services.ReturnBookReq) (services.LoanRes, error)
+		RenewLoan(ctx context.Context, req services.RenewLoanReq) (services.LoanRes, error)
+		GetLoans(ctx context.Context, req services.GetLoansReq) (map[int][]services.LoanRes, error)
+		GetFines(ctx context.Context, req services.GetFinesReq) (services.FineLedgerRes, error)
+		SettleFines(ctx context.Context, req services.SettleFinesReq) (services.FineLedgerRes, error)
+		WaiveFine(ctx context.Context, req services.WaiveFineReq) (services.FineLedgerRes, error)
+		GetBranches(ctx context.Context) ([]services.BranchRes, error)
+		GetBookAvailability(ctx context.Context, req services.GetBookAvailabilityReq) ([]services.BranchAvailabilityRes, error)
+		GetPickupSlots(ctx context.Context, req services.GetPickupSlotsReq) ([]services.PickupSlotRes, error)
+		GetOutboxEvents(ctx context.Context, req services.GetOutboxEventsReq) ([]services.OutboxEventRes, error)
+		RequeueOutboxEvent(ctx context.Context, req services.RequeueOutboxEventReq) (services.OutboxEventRes, error)
+		GetReservationCalendar(ctx context.Context, req services.GetReservationCalendarReq) (services.ReservationCalendarRes, error)
+		ExportBookReservation(ctx context.Context, req services.ExportBookReservationReq, write func(services.ReservationExportRow) error) error
+		BorrowBooks(ctx context.Context, req services.BorrowBooksReq) (services.BorrowBooksRes, error)
+	}
+
+	UserService interface {
+		RegisterUser(ctx context.Context, req services.RegisterUserReq) (services.UserRes, error)
+		GetUser(ctx context.Context, req services.GetUserReq) (services.UserRes, error)
+		UpdateUserProfile(ctx context.Context, req services.UpdateUserProfileReq) (services.UserRes, error)
+		DeactivateUser(ctx context.Context, req services.DeactivateUserReq) (services.UserRes, error)
+		SetNotifyOptOut(ctx context.Context, req services.SetNotifyOptOutReq) (services.UserRes, error)
+	}
+
+	WebhookService interface {
+		CreateWebhook(ctx context.Context, req services.CreateWebhookReq) (services.WebhookRes, error)
+		GetWebhooks(ctx context.Context) ([]services.WebhookRes, error)
+		UpdateWebhook(ctx context.Context, req services.UpdateWebhookReq) (services.WebhookRes, error)
+		DeleteWebhook(ctx context.Context, req services.DeleteWebhookReq) error
+		GetWebhookDeliveries(ctx context.Context, req services.GetWebhookDeliveriesReq) ([]services.WebhookDeliveryRes, error)
+		ReplayWebhookDelivery(ctx context.Context, req services.ReplayWebhookDeliveryReq) (services.WebhookDeliveryRes, error)
+	}
+
+	ReservationStreamService interface {
+		SubscribeReservationEvents(ctx context.Context, req services.SubscribeReservationEventsReq) (services.ReservationEventSubscription, error)
+	}
+
+	SweeperService interface {
+		Sweep(ctx context.Context) (services.SweepRes, error)
+	}
+
+	IdempotencyService interface {
+		BeginIdempotentRequest(ctx context.Context, req services.BeginIdempotentRequestReq) (services.IdempotentRequestRes, error)
+		CompleteIdempotentRequest(ctx context.Context, req services.CompleteIdempotentRequestReq) error
+		ReleaseIdempotentRequest(ctx context.Context, req services.ReleaseIdempotentRequestReq) error
+	}
+
+	RateLimitStore interface {
+		Take(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error)
 	}
 )
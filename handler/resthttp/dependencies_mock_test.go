@@ -7,6 +7,7 @@ package resthttp
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	services "gihub.com/gadhittana01/book-project/services"
 	gomock "github.com/golang/mock/gomock"
@@ -50,6 +51,93 @@ func (mr *MockBookServiceMockRecorder) BorrowBook(ctx, req interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BorrowBook", reflect.TypeOf((*MockBookService)(nil).BorrowBook), ctx, req)
 }
 
+// BorrowBooks mocks base method.
+func (m *MockBookService) BorrowBooks(ctx context.Context, req services.BorrowBooksReq) (services.BorrowBooksRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BorrowBooks", ctx, req)
+	ret0, _ := ret[0].(services.BorrowBooksRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BorrowBooks indicates an expected call of BorrowBooks.
+func (mr *MockBookServiceMockRecorder) BorrowBooks(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BorrowBooks", reflect.TypeOf((*MockBookService)(nil).BorrowBooks), ctx, req)
+}
+
+// CancelReservation mocks base method.
+func (m *MockBookService) CancelReservation(ctx context.Context, req services.CancelReservationReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelReservation", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelReservation indicates an expected call of CancelReservation.
+func (mr *MockBookServiceMockRecorder) CancelReservation(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelReservation", reflect.TypeOf((*MockBookService)(nil).CancelReservation), ctx, req)
+}
+
+// CheckoutBook mocks base method.
+func (m *MockBookService) CheckoutBook(ctx context.Context, req services.CheckoutBookReq) (services.LoanRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckoutBook", ctx, req)
+	ret0, _ := ret[0].(services.LoanRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckoutBook indicates an expected call of CheckoutBook.
+func (mr *MockBookServiceMockRecorder) CheckoutBook(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckoutBook", reflect.TypeOf((*MockBookService)(nil).CheckoutBook), ctx, req)
+}
+
+// ConfirmReservation mocks base method.
+func (m *MockBookService) ConfirmReservation(ctx context.Context, req services.ConfirmReservationReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmReservation", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmReservation indicates an expected call of ConfirmReservation.
+func (mr *MockBookServiceMockRecorder) ConfirmReservation(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmReservation", reflect.TypeOf((*MockBookService)(nil).ConfirmReservation), ctx, req)
+}
+
+// ExportBookReservation mocks base method.
+func (m *MockBookService) ExportBookReservation(ctx context.Context, req services.ExportBookReservationReq, write func(services.ReservationExportRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportBookReservation", ctx, req, write)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportBookReservation indicates an expected call of ExportBookReservation.
+func (mr *MockBookServiceMockRecorder) ExportBookReservation(ctx, req, write interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportBookReservation", reflect.TypeOf((*MockBookService)(nil).ExportBookReservation), ctx, req, write)
+}
+
+// GetBookAvailability mocks base method.
+func (m *MockBookService) GetBookAvailability(ctx context.Context, req services.GetBookAvailabilityReq) ([]services.BranchAvailabilityRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookAvailability", ctx, req)
+	ret0, _ := ret[0].([]services.BranchAvailabilityRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookAvailability indicates an expected call of GetBookAvailability.
+func (mr *MockBookServiceMockRecorder) GetBookAvailability(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookAvailability", reflect.TypeOf((*MockBookService)(nil).GetBookAvailability), ctx, req)
+}
+
 // GetBookReservation mocks base method.
 func (m *MockBookService) GetBookReservation(ctx context.Context, req services.GetBookReservationReq) (map[int][]services.GetBookReservationRes, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +153,51 @@ func (mr *MockBookServiceMockRecorder) GetBookReservation(ctx, req interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookReservation", reflect.TypeOf((*MockBookService)(nil).GetBookReservation), ctx, req)
 }
 
+// GetBranches mocks base method.
+func (m *MockBookService) GetBranches(ctx context.Context) ([]services.BranchRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBranches", ctx)
+	ret0, _ := ret[0].([]services.BranchRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBranches indicates an expected call of GetBranches.
+func (mr *MockBookServiceMockRecorder) GetBranches(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranches", reflect.TypeOf((*MockBookService)(nil).GetBranches), ctx)
+}
+
+// GetCatalogHealth mocks base method.
+func (m *MockBookService) GetCatalogHealth(ctx context.Context) (services.GetCatalogHealthRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCatalogHealth", ctx)
+	ret0, _ := ret[0].(services.GetCatalogHealthRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCatalogHealth indicates an expected call of GetCatalogHealth.
+func (mr *MockBookServiceMockRecorder) GetCatalogHealth(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogHealth", reflect.TypeOf((*MockBookService)(nil).GetCatalogHealth), ctx)
+}
+
+// GetFines mocks base method.
+func (m *MockBookService) GetFines(ctx context.Context, req services.GetFinesReq) (services.FineLedgerRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFines", ctx, req)
+	ret0, _ := ret[0].(services.FineLedgerRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFines indicates an expected call of GetFines.
+func (mr *MockBookServiceMockRecorder) GetFines(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFines", reflect.TypeOf((*MockBookService)(nil).GetFines), ctx, req)
+}
+
 // GetListOfBooks mocks base method.
 func (m *MockBookService) GetListOfBooks(ctx context.Context, req services.GetListOfBooksReq) (services.GetListOfBooksResp, error) {
 	m.ctrl.T.Helper()
@@ -78,4 +211,544 @@ func (m *MockBookService) GetListOfBooks(ctx context.Context, req services.GetLi
 func (mr *MockBookServiceMockRecorder) GetListOfBooks(ctx, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListOfBooks", reflect.TypeOf((*MockBookService)(nil).GetListOfBooks), ctx, req)
+}
+
+// GetLoans mocks base method.
+func (m *MockBookService) GetLoans(ctx context.Context, req services.GetLoansReq) (map[int][]services.LoanRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoans", ctx, req)
+	ret0, _ := ret[0].(map[int][]services.LoanRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoans indicates an expected call of GetLoans.
+func (mr *MockBookServiceMockRecorder) GetLoans(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoans", reflect.TypeOf((*MockBookService)(nil).GetLoans), ctx, req)
+}
+
+// GetOutboxEvents mocks base method.
+func (m *MockBookService) GetOutboxEvents(ctx context.Context, req services.GetOutboxEventsReq) ([]services.OutboxEventRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutboxEvents", ctx, req)
+	ret0, _ := ret[0].([]services.OutboxEventRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutboxEvents indicates an expected call of GetOutboxEvents.
+func (mr *MockBookServiceMockRecorder) GetOutboxEvents(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboxEvents", reflect.TypeOf((*MockBookService)(nil).GetOutboxEvents), ctx, req)
+}
+
+// GetPickupSlots mocks base method.
+func (m *MockBookService) GetPickupSlots(ctx context.Context, req services.GetPickupSlotsReq) ([]services.PickupSlotRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPickupSlots", ctx, req)
+	ret0, _ := ret[0].([]services.PickupSlotRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPickupSlots indicates an expected call of GetPickupSlots.
+func (mr *MockBookServiceMockRecorder) GetPickupSlots(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPickupSlots", reflect.TypeOf((*MockBookService)(nil).GetPickupSlots), ctx, req)
+}
+
+// GetReservationCalendar mocks base method.
+func (m *MockBookService) GetReservationCalendar(ctx context.Context, req services.GetReservationCalendarReq) (services.ReservationCalendarRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReservationCalendar", ctx, req)
+	ret0, _ := ret[0].(services.ReservationCalendarRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReservationCalendar indicates an expected call of GetReservationCalendar.
+func (mr *MockBookServiceMockRecorder) GetReservationCalendar(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservationCalendar", reflect.TypeOf((*MockBookService)(nil).GetReservationCalendar), ctx, req)
+}
+
+// JoinWaitlist mocks base method.
+func (m *MockBookService) JoinWaitlist(ctx context.Context, req services.JoinWaitlistReq) (services.JoinWaitlistRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JoinWaitlist", ctx, req)
+	ret0, _ := ret[0].(services.JoinWaitlistRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// JoinWaitlist indicates an expected call of JoinWaitlist.
+func (mr *MockBookServiceMockRecorder) JoinWaitlist(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JoinWaitlist", reflect.TypeOf((*MockBookService)(nil).JoinWaitlist), ctx, req)
+}
+
+// RenewLoan mocks base method.
+func (m *MockBookService) RenewLoan(ctx context.Context, req services.RenewLoanReq) (services.LoanRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewLoan", ctx, req)
+	ret0, _ := ret[0].(services.LoanRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenewLoan indicates an expected call of RenewLoan.
+func (mr *MockBookServiceMockRecorder) RenewLoan(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewLoan", reflect.TypeOf((*MockBookService)(nil).RenewLoan), ctx, req)
+}
+
+// RequeueOutboxEvent mocks base method.
+func (m *MockBookService) RequeueOutboxEvent(ctx context.Context, req services.RequeueOutboxEventReq) (services.OutboxEventRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueOutboxEvent", ctx, req)
+	ret0, _ := ret[0].(services.OutboxEventRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequeueOutboxEvent indicates an expected call of RequeueOutboxEvent.
+func (mr *MockBookServiceMockRecorder) RequeueOutboxEvent(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueOutboxEvent", reflect.TypeOf((*MockBookService)(nil).RequeueOutboxEvent), ctx, req)
+}
+
+// ReturnBook mocks base method.
+func (m *MockBookService) ReturnBook(ctx context.Context, req services.ReturnBookReq) (services.LoanRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReturnBook", ctx, req)
+	ret0, _ := ret[0].(services.LoanRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReturnBook indicates an expected call of ReturnBook.
+func (mr *MockBookServiceMockRecorder) ReturnBook(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnBook", reflect.TypeOf((*MockBookService)(nil).ReturnBook), ctx, req)
+}
+
+// SettleFines mocks base method.
+func (m *MockBookService) SettleFines(ctx context.Context, req services.SettleFinesReq) (services.FineLedgerRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SettleFines", ctx, req)
+	ret0, _ := ret[0].(services.FineLedgerRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SettleFines indicates an expected call of SettleFines.
+func (mr *MockBookServiceMockRecorder) SettleFines(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettleFines", reflect.TypeOf((*MockBookService)(nil).SettleFines), ctx, req)
+}
+
+// WaiveFine mocks base method.
+func (m *MockBookService) WaiveFine(ctx context.Context, req services.WaiveFineReq) (services.FineLedgerRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaiveFine", ctx, req)
+	ret0, _ := ret[0].(services.FineLedgerRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaiveFine indicates an expected call of WaiveFine.
+func (mr *MockBookServiceMockRecorder) WaiveFine(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaiveFine", reflect.TypeOf((*MockBookService)(nil).WaiveFine), ctx, req)
+}
+
+// MockUserService is a mock of UserService interface.
+type MockUserService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserServiceMockRecorder
+}
+
+// MockUserServiceMockRecorder is the mock recorder for MockUserService.
+type MockUserServiceMockRecorder struct {
+	mock *MockUserService
+}
+
+// NewMockUserService creates a new mock instance.
+func NewMockUserService(ctrl *gomock.Controller) *MockUserService {
+	mock := &MockUserService{ctrl: ctrl}
+	mock.recorder = &MockUserServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
+	return m.recorder
+}
+
+// DeactivateUser mocks base method.
+func (m *MockUserService) DeactivateUser(ctx context.Context, req services.DeactivateUserReq) (services.UserRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateUser", ctx, req)
+	ret0, _ := ret[0].(services.UserRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateUser indicates an expected call of DeactivateUser.
+func (mr *MockUserServiceMockRecorder) DeactivateUser(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateUser", reflect.TypeOf((*MockUserService)(nil).DeactivateUser), ctx, req)
+}
+
+// GetUser mocks base method.
+func (m *MockUserService) GetUser(ctx context.Context, req services.GetUserReq) (services.UserRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, req)
+	ret0, _ := ret[0].(services.UserRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockUserServiceMockRecorder) GetUser(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserService)(nil).GetUser), ctx, req)
+}
+
+// RegisterUser mocks base method.
+func (m *MockUserService) RegisterUser(ctx context.Context, req services.RegisterUserReq) (services.UserRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterUser", ctx, req)
+	ret0, _ := ret[0].(services.UserRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterUser indicates an expected call of RegisterUser.
+func (mr *MockUserServiceMockRecorder) RegisterUser(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockUserService)(nil).RegisterUser), ctx, req)
+}
+
+// SetNotifyOptOut mocks base method.
+func (m *MockUserService) SetNotifyOptOut(ctx context.Context, req services.SetNotifyOptOutReq) (services.UserRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNotifyOptOut", ctx, req)
+	ret0, _ := ret[0].(services.UserRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetNotifyOptOut indicates an expected call of SetNotifyOptOut.
+func (mr *MockUserServiceMockRecorder) SetNotifyOptOut(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNotifyOptOut", reflect.TypeOf((*MockUserService)(nil).SetNotifyOptOut), ctx, req)
+}
+
+// UpdateUserProfile mocks base method.
+func (m *MockUserService) UpdateUserProfile(ctx context.Context, req services.UpdateUserProfileReq) (services.UserRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserProfile", ctx, req)
+	ret0, _ := ret[0].(services.UserRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserProfile indicates an expected call of UpdateUserProfile.
+func (mr *MockUserServiceMockRecorder) UpdateUserProfile(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserProfile", reflect.TypeOf((*MockUserService)(nil).UpdateUserProfile), ctx, req)
+}
+
+// MockWebhookService is a mock of WebhookService interface.
+type MockWebhookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookServiceMockRecorder
+}
+
+// MockWebhookServiceMockRecorder is the mock recorder for MockWebhookService.
+type MockWebhookServiceMockRecorder struct {
+	mock *MockWebhookService
+}
+
+// NewMockWebhookService creates a new mock instance.
+func NewMockWebhookService(ctrl *gomock.Controller) *MockWebhookService {
+	mock := &MockWebhookService{ctrl: ctrl}
+	mock.recorder = &MockWebhookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookService) EXPECT() *MockWebhookServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhook mocks base method.
+func (m *MockWebhookService) CreateWebhook(ctx context.Context, req services.CreateWebhookReq) (services.WebhookRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", ctx, req)
+	ret0, _ := ret[0].(services.WebhookRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockWebhookServiceMockRecorder) CreateWebhook(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockWebhookService)(nil).CreateWebhook), ctx, req)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhookService) DeleteWebhook(ctx context.Context, req services.DeleteWebhookReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookServiceMockRecorder) DeleteWebhook(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhookService)(nil).DeleteWebhook), ctx, req)
+}
+
+// GetWebhookDeliveries mocks base method.
+func (m *MockWebhookService) GetWebhookDeliveries(ctx context.Context, req services.GetWebhookDeliveriesReq) ([]services.WebhookDeliveryRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDeliveries", ctx, req)
+	ret0, _ := ret[0].([]services.WebhookDeliveryRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDeliveries indicates an expected call of GetWebhookDeliveries.
+func (mr *MockWebhookServiceMockRecorder) GetWebhookDeliveries(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDeliveries", reflect.TypeOf((*MockWebhookService)(nil).GetWebhookDeliveries), ctx, req)
+}
+
+// GetWebhooks mocks base method.
+func (m *MockWebhookService) GetWebhooks(ctx context.Context) ([]services.WebhookRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", ctx)
+	ret0, _ := ret[0].([]services.WebhookRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks.
+func (mr *MockWebhookServiceMockRecorder) GetWebhooks(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*MockWebhookService)(nil).GetWebhooks), ctx)
+}
+
+// ReplayWebhookDelivery mocks base method.
+func (m *MockWebhookService) ReplayWebhookDelivery(ctx context.Context, req services.ReplayWebhookDeliveryReq) (services.WebhookDeliveryRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayWebhookDelivery", ctx, req)
+	ret0, _ := ret[0].(services.WebhookDeliveryRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplayWebhookDelivery indicates an expected call of ReplayWebhookDelivery.
+func (mr *MockWebhookServiceMockRecorder) ReplayWebhookDelivery(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayWebhookDelivery", reflect.TypeOf((*MockWebhookService)(nil).ReplayWebhookDelivery), ctx, req)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockWebhookService) UpdateWebhook(ctx context.Context, req services.UpdateWebhookReq) (services.WebhookRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", ctx, req)
+	ret0, _ := ret[0].(services.WebhookRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockWebhookServiceMockRecorder) UpdateWebhook(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockWebhookService)(nil).UpdateWebhook), ctx, req)
+}
+
+// MockReservationStreamService is a mock of ReservationStreamService interface.
+type MockReservationStreamService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReservationStreamServiceMockRecorder
+}
+
+// MockReservationStreamServiceMockRecorder is the mock recorder for MockReservationStreamService.
+type MockReservationStreamServiceMockRecorder struct {
+	mock *MockReservationStreamService
+}
+
+// NewMockReservationStreamService creates a new mock instance.
+func NewMockReservationStreamService(ctrl *gomock.Controller) *MockReservationStreamService {
+	mock := &MockReservationStreamService{ctrl: ctrl}
+	mock.recorder = &MockReservationStreamServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReservationStreamService) EXPECT() *MockReservationStreamServiceMockRecorder {
+	return m.recorder
+}
+
+// SubscribeReservationEvents mocks base method.
+func (m *MockReservationStreamService) SubscribeReservationEvents(ctx context.Context, req services.SubscribeReservationEventsReq) (services.ReservationEventSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeReservationEvents", ctx, req)
+	ret0, _ := ret[0].(services.ReservationEventSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeReservationEvents indicates an expected call of SubscribeReservationEvents.
+func (mr *MockReservationStreamServiceMockRecorder) SubscribeReservationEvents(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeReservationEvents", reflect.TypeOf((*MockReservationStreamService)(nil).SubscribeReservationEvents), ctx, req)
+}
+
+// MockSweeperService is a mock of SweeperService interface.
+type MockSweeperService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSweeperServiceMockRecorder
+}
+
+// MockSweeperServiceMockRecorder is the mock recorder for MockSweeperService.
+type MockSweeperServiceMockRecorder struct {
+	mock *MockSweeperService
+}
+
+// NewMockSweeperService creates a new mock instance.
+func NewMockSweeperService(ctrl *gomock.Controller) *MockSweeperService {
+	mock := &MockSweeperService{ctrl: ctrl}
+	mock.recorder = &MockSweeperServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSweeperService) EXPECT() *MockSweeperServiceMockRecorder {
+	return m.recorder
+}
+
+// Sweep mocks base method.
+func (m *MockSweeperService) Sweep(ctx context.Context) (services.SweepRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sweep", ctx)
+	ret0, _ := ret[0].(services.SweepRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sweep indicates an expected call of Sweep.
+func (mr *MockSweeperServiceMockRecorder) Sweep(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sweep", reflect.TypeOf((*MockSweeperService)(nil).Sweep), ctx)
+}
+
+// MockIdempotencyService is a mock of IdempotencyService interface.
+type MockIdempotencyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyServiceMockRecorder
+}
+
+// MockIdempotencyServiceMockRecorder is the mock recorder for MockIdempotencyService.
+type MockIdempotencyServiceMockRecorder struct {
+	mock *MockIdempotencyService
+}
+
+// NewMockIdempotencyService creates a new mock instance.
+func NewMockIdempotencyService(ctrl *gomock.Controller) *MockIdempotencyService {
+	mock := &MockIdempotencyService{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyService) EXPECT() *MockIdempotencyServiceMockRecorder {
+	return m.recorder
+}
+
+// BeginIdempotentRequest mocks base method.
+func (m *MockIdempotencyService) BeginIdempotentRequest(ctx context.Context, req services.BeginIdempotentRequestReq) (services.IdempotentRequestRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginIdempotentRequest", ctx, req)
+	ret0, _ := ret[0].(services.IdempotentRequestRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginIdempotentRequest indicates an expected call of BeginIdempotentRequest.
+func (mr *MockIdempotencyServiceMockRecorder) BeginIdempotentRequest(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginIdempotentRequest", reflect.TypeOf((*MockIdempotencyService)(nil).BeginIdempotentRequest), ctx, req)
+}
+
+// CompleteIdempotentRequest mocks base method.
+func (m *MockIdempotencyService) CompleteIdempotentRequest(ctx context.Context, req services.CompleteIdempotentRequestReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteIdempotentRequest", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteIdempotentRequest indicates an expected call of CompleteIdempotentRequest.
+func (mr *MockIdempotencyServiceMockRecorder) CompleteIdempotentRequest(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteIdempotentRequest", reflect.TypeOf((*MockIdempotencyService)(nil).CompleteIdempotentRequest), ctx, req)
+}
+
+// ReleaseIdempotentRequest mocks base method.
+func (m *MockIdempotencyService) ReleaseIdempotentRequest(ctx context.Context, req services.ReleaseIdempotentRequestReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseIdempotentRequest", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseIdempotentRequest indicates an expected call of ReleaseIdempotentRequest.
+func (mr *MockIdempotencyServiceMockRecorder) ReleaseIdempotentRequest(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseIdempotentRequest", reflect.TypeOf((*MockIdempotencyService)(nil).ReleaseIdempotentRequest), ctx, req)
+}
+
+// MockRateLimitStore is a mock of RateLimitStore interface.
+type MockRateLimitStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateLimitStoreMockRecorder
+}
+
+// MockRateLimitStoreMockRecorder is the mock recorder for MockRateLimitStore.
+type MockRateLimitStoreMockRecorder struct {
+	mock *MockRateLimitStore
+}
+
+// NewMockRateLimitStore creates a new mock instance.
+func NewMockRateLimitStore(ctrl *gomock.Controller) *MockRateLimitStore {
+	mock := &MockRateLimitStore{ctrl: ctrl}
+	mock.recorder = &MockRateLimitStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateLimitStore) EXPECT() *MockRateLimitStoreMockRecorder {
+	return m.recorder
+}
+
+// Take mocks base method.
+func (m *MockRateLimitStore) Take(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Take", ctx, key, limit, window)
+	ret0, _ := ret[0].(RateLimitResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Take indicates an expected call of Take.
+func (mr *MockRateLimitStoreMockRecorder) Take(ctx, key, limit, window interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Take", reflect.TypeOf((*MockRateLimitStore)(nil).Take), ctx, key, limit, window)
 }
\ No newline at end of file
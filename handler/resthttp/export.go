@@ -0,0 +1,147 @@
+package resthttp
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/services"
+)
+
+const (
+	exportFormatJSON   = "json"
+	exportFormatCSV    = "csv"
+	exportFormatNDJSON = "ndjson"
+
+	contentTypeCSV    = "text/csv"
+	contentTypeNDJSON = "application/x-ndjson"
+)
+
+var reservationExportHeader = []string{"id", "title", "author", "key", "branch", "pickup_date", "pickup_slot", "user_id", "status", "created_at"}
+
+// exportFormat picks the listing format from the format parameter, falling back to the Accept header and then JSON.
+// Of the Accept entries the one with the highest q-value wins, the first listed on a tie; q=0 rules a type out.
+func exportFormat(r *http.Request) (string, error) {
+	switch format := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format"))); format {
+	case "":
+	case exportFormatJSON, exportFormatCSV, exportFormatNDJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("Unknown format %q, use json, csv or ndjson", format)
+	}
+
+	best, bestQ := exportFormatJSON, 0.0
+	for _, item := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(item))
+		if err != nil {
+			continue
+		}
+		var format string
+		switch mediaType {
+		case contentTypeCSV:
+			format = exportFormatCSV
+		case contentTypeNDJSON:
+			format = exportFormatNDJSON
+		case "application/json", "*/*":
+			format = exportFormatJSON
+		default:
+			continue
+		}
+		if q := acceptQuality(params); q > bestQ {
+			best, bestQ = format, q
+		}
+	}
+	return best, nil
+}
+
+// acceptQuality reads the q parameter of an Accept entry, a missing or malformed one counts as 1.
+func acceptQuality(params map[string]string) float64 {
+	q, err := strconv.ParseFloat(params["q"], 64)
+	if err != nil || q > 1 {
+		return 1
+	}
+	if q < 0 {
+		return 0
+	}
+	return q
+}
+
+// exportBookReservation streams the reservation listing row by row. The response starts with the first row, so an
+// error before it is still answered with a status, a later one can only cut the export short.
+func (p bookHandler) exportBookReservation(w http.ResponseWriter, r *http.Request, format string, req services.ExportBookReservationReq) {
+	resp := newResponse(time.Now())
+
+	var (
+		started bool
+		cw      *csv.Writer
+		enc     *json.Encoder
+	)
+	start := func() {
+		started = true
+		if format == exportFormatCSV {
+			w.Header().Set("Content-Type", contentTypeCSV+"; charset=utf-8")
+			w.Header().Set("Content-Disposition", `attachment; filename="reservations.csv"`)
+			w.WriteHeader(http.StatusOK)
+			cw = csv.NewWriter(w)
+			cw.Write(reservationExportHeader)
+			return
+		}
+		w.Header().Set("Content-Type", contentTypeNDJSON)
+		w.WriteHeader(http.StatusOK)
+		enc = json.NewEncoder(w)
+	}
+
+	// The export follows the request context so it stops paging once the client goes away.
+	err := p.service.ExportBookReservation(r.Context(), req, func(row services.ReservationExportRow) error {
+		if !started {
+			start()
+		}
+		if cw != nil {
+			cw.Write(reservationExportRecord(row))
+			return cw.Error()
+		}
+		return enc.Encode(row)
+	})
+	if err != nil && !started {
+		resp.setError(err, w)
+		return
+	}
+	if err != nil {
+		log.Printf("export reservations: %v", err)
+	}
+	if !started {
+		start()
+	}
+	if cw != nil {
+		cw.Flush()
+	}
+}
+
+func reservationExportRecord(row services.ReservationExportRow) []string {
+	return []string{
+		strconv.Itoa(row.ID),
+		csvText(row.Title),
+		csvText(row.Author),
+		row.BookKey,
+		csvText(row.Branch),
+		row.PickUpDate,
+		row.PickUpSlot,
+		strconv.Itoa(row.UserID),
+		row.Status,
+		row.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// csvText keeps spreadsheets from running catalog text as a formula.
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
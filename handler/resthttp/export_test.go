@@ -0,0 +1,152 @@
+package resthttp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/services"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_exportFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		accept  string
+		want    string
+		wantErr bool
+	}{
+		{name: "default", url: "/get-book-reservation", want: exportFormatJSON},
+		{name: "format parameter", url: "/get-book-reservation?format=CSV", accept: contentTypeNDJSON, want: exportFormatCSV},
+		{name: "accept csv", url: "/get-book-reservation", accept: "text/csv; charset=utf-8", want: exportFormatCSV},
+		{name: "accept ndjson", url: "/get-book-reservation", accept: "text/html, application/x-ndjson;q=0.9", want: exportFormatNDJSON},
+		{name: "accept anything", url: "/get-book-reservation", accept: "*/*", want: exportFormatJSON},
+		{name: "csv ruled out", url: "/get-book-reservation", accept: "text/csv;q=0, application/json", want: exportFormatJSON},
+		{name: "highest q wins", url: "/get-book-reservation", accept: "application/json;q=0.5, text/csv;q=0.8", want: exportFormatCSV},
+		{name: "everything ruled out", url: "/get-book-reservation", accept: "text/csv;q=0, application/x-ndjson;q=0", want: exportFormatJSON},
+		{name: "unknown format", url: "/get-book-reservation?format=xlsx", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			req.Header.Set("Accept", tt.accept)
+			got, err := exportFormat(req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("exportFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("exportFormat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_GetBookReservationExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rows := []services.ReservationExportRow{
+		{ID: 1, Title: "Matilda", Author: "Roald Dahl", BookKey: "123", Branch: "central", PickUpDate: "2022-01-24", PickUpSlot: "09:30", UserID: 1, Status: "active", CreatedAt: time.Date(2022, 1, 20, 8, 0, 0, 0, time.UTC)},
+		{ID: 2, Title: "=HYPERLINK(\"x\")", Author: "A, B", BookKey: "456", Branch: "central", PickUpDate: "2022-01-25", UserID: 2, Status: "cancelled", CreatedAt: time.Date(2022, 1, 21, 8, 0, 0, 0, time.UTC)},
+	}
+	exportRows := func(rows []services.ReservationExportRow, err error) func(ctx context.Context, req services.ExportBookReservationReq, write func(services.ReservationExportRow) error) error {
+		return func(ctx context.Context, req services.ExportBookReservationReq, write func(services.ReservationExportRow) error) error {
+			for _, row := range rows {
+				if err := write(row); err != nil {
+					return err
+				}
+			}
+			return err
+		}
+	}
+
+	tests := []struct {
+		name            string
+		url             string
+		accept          string
+		mock            func() BookService
+		wantCode        int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name: "test csv",
+			url:  "/get-book-reservation?branch=central&status=active&q=dahl&from=2022-01-20&to=2022-01-31&format=csv",
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().ExportBookReservation(gomock.Any(), services.ExportBookReservationReq{Branch: "central", Status: "active", Query: "dahl", From: "2022-01-20", To: "2022-01-31"}, gomock.Any()).DoAndReturn(exportRows(rows, nil))
+				return bookMock
+			},
+			wantCode:        http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantBody: "id,title,author,key,branch,pickup_date,pickup_slot,user_id,status,created_at\n" +
+				"1,Matilda,Roald Dahl,123,central,2022-01-24,09:30,1,active,2022-01-20T08:00:00Z\n" +
+				"2,\"'=HYPERLINK(\"\"x\"\")\",\"A, B\",456,central,2022-01-25,,2,cancelled,2022-01-21T08:00:00Z\n",
+		},
+		{
+			name:   "test ndjson",
+			url:    "/get-book-reservation?user_id=1",
+			accept: "application/x-ndjson",
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().ExportBookReservation(gomock.Any(), services.ExportBookReservationReq{UserID: 1}, gomock.Any()).DoAndReturn(exportRows(rows[:1], nil))
+				return bookMock
+			},
+			wantCode:        http.StatusOK,
+			wantContentType: "application/x-ndjson",
+			wantBody:        `{"id":1,"title":"Matilda","author":"Roald Dahl","key":"123","branch":"central","pickup_date":"2022-01-24","pickup_slot":"09:30","user_id":1,"status":"active","created_at":"2022-01-20T08:00:00Z"}` + "\n",
+		},
+		{
+			name: "test empty csv keeps the header",
+			url:  "/get-book-reservation?format=csv",
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().ExportBookReservation(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(exportRows(nil, nil))
+				return bookMock
+			},
+			wantCode:        http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantBody:        "id,title,author,key,branch,pickup_date,pickup_slot,user_id,status,created_at\n",
+		},
+		{
+			name: "test error before the first row",
+			url:  "/get-book-reservation?format=ndjson",
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().ExportBookReservation(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(exportRows(nil, errors.New("error")))
+				return bookMock
+			},
+			wantCode:        http.StatusInternalServerError,
+			wantContentType: "application/json",
+		},
+		{
+			name: "test unknown format",
+			url:  "/get-book-reservation?format=xlsx",
+			mock: func() BookService {
+				return NewMockBookService(ctrl)
+			},
+			wantCode:        http.StatusBadRequest,
+			wantContentType: "application/json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			req.Header.Set("Accept", tt.accept)
+			w := httptest.NewRecorder()
+			newBookHandler(tt.mock()).GetBookReservation(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("GetBookReservation() code = %v, want %v", w.Code, tt.wantCode)
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("GetBookReservation() content type = %v, want %v", got, tt.wantContentType)
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Errorf("GetBookReservation() body = %q, want %q", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
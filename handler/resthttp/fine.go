@@ -0,0 +1,90 @@
+package resthttp
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/services"
+)
+
+func (p bookHandler) GetFines(w http.ResponseWriter, r *http.Request) {
+	resp := newResponse(time.Now())
+
+	uid, err := strconv.Atoi(strings.TrimSpace(r.URL.Query().Get("user_id")))
+	if err != nil {
+		resp.setBadRequest(InvalidRequestParam, w)
+		return
+	}
+
+	res, err := p.service.GetFines(context.Background(), services.GetFinesReq{
+		UserID: uid,
+	})
+	if err != nil {
+		resp.setError(err, w)
+		return
+	}
+
+	resp.setOK(map[string]interface{}{
+		"data": res,
+	}, w)
+	return
+}
+
+func (p bookHandler) SettleFines(w http.ResponseWriter, r *http.Request) {
+	resp := newResponse(time.Now())
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+
+	reqBody := services.SettleFinesReq{}
+	err = json.Unmarshal(body, &reqBody)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+	res, err := p.service.SettleFines(context.Background(), reqBody)
+	if err != nil {
+		resp.setError(err, w)
+		return
+	}
+
+	resp.setOK(map[string]interface{}{
+		"data": res,
+	}, w)
+	return
+}
+
+func (p bookHandler) WaiveFine(w http.ResponseWriter, r *http.Request) {
+	resp := newResponse(time.Now())
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+
+	reqBody := services.WaiveFineReq{}
+	err = json.Unmarshal(body, &reqBody)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+	res, err := p.service.WaiveFine(context.Background(), reqBody)
+	if err != nil {
+		resp.setError(err, w)
+		return
+	}
+
+	resp.setOK(map[string]interface{}{
+		"data": res,
+	}, w)
+	return
+}
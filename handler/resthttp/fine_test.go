@@ -0,0 +1,143 @@
+package resthttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/services"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_fineRoutesAdminOnly(t *testing.T) {
+	testAdminOnly(t, "POST /settle-fines", "POST /waive-fine")
+}
+
+func Test_GetFines(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		url      string
+		mock     func() BookService
+		wantCode int
+	}{
+		{
+			name: "test normal flow",
+			url:  "http://localhost:8000/get-fines?user_id=2",
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().GetFines(gomock.Any(), services.GetFinesReq{UserID: 2}).Return(services.FineLedgerRes{UserID: 2}, nil)
+				return bookMock
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "test missing user id",
+			url:  "http://localhost:8000/get-fines",
+			mock: func() BookService {
+				return NewMockBookService(ctrl)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			i := bookHandler{
+				service: tt.mock(),
+			}
+			i.GetFines(w, httptest.NewRequest("GET", tt.url, nil))
+			if w.Code != tt.wantCode {
+				t.Errorf("GetFines() status = %v, want %v", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_SettleFines(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		body     string
+		mock     func() BookService
+		wantCode int
+	}{
+		{
+			name: "test normal flow",
+			body: `{"user_id": 2, "amount": 75, "currency": "USD"}`,
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().SettleFines(gomock.Any(), services.SettleFinesReq{UserID: 2, Amount: 75, Currency: "USD"}).Return(services.FineLedgerRes{UserID: 2}, nil)
+				return bookMock
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "test invalid amount",
+			body: `{"user_id": 2, "amount": -5}`,
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().SettleFines(gomock.Any(), gomock.Any()).Return(services.FineLedgerRes{}, &services.ServiceError{Code: services.ErrCodeInvalidRequest, Message: "Amount must be positive and not above the outstanding balance"})
+				return bookMock
+			},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			i := bookHandler{
+				service: tt.mock(),
+			}
+			i.SettleFines(w, httptest.NewRequest("POST", "http://localhost:8000/settle-fines", strings.NewReader(tt.body)))
+			if w.Code != tt.wantCode {
+				t.Errorf("SettleFines() status = %v, want %v", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_WaiveFine(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		body     string
+		mock     func() BookService
+		wantCode int
+	}{
+		{
+			name: "test normal flow",
+			body: `{"fine_id": 1, "note": "first offence"}`,
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().WaiveFine(gomock.Any(), services.WaiveFineReq{FineID: 1, Note: "first offence"}).Return(services.FineLedgerRes{UserID: 2}, nil)
+				return bookMock
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "test bad request",
+			body: "",
+			mock: func() BookService {
+				return NewMockBookService(ctrl)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			i := bookHandler{
+				service: tt.mock(),
+			}
+			i.WaiveFine(w, httptest.NewRequest("POST", "http://localhost:8000/waive-fine", strings.NewReader(tt.body)))
+			if w.Code != tt.wantCode {
+				t.Errorf("WaiveFine() status = %v, want %v", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
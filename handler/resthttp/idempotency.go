@@ -0,0 +1,145 @@
+package resthttp
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/services"
+)
+
+const (
+	HeaderIdempotencyKey      = "Idempotency-Key"
+	HeaderIdempotencyReplayed = "Idempotency-Replayed"
+
+	defaultIdempotencyMaxBodyBytes = 1 << 20
+)
+
+type idempotency struct {
+	service      IdempotencyService
+	maxBodyBytes int64
+	// identity names the client sending a request, keys of different clients never meet.
+	identity func(r *http.Request) string
+}
+
+func newIdempotency(service IdempotencyService, cfg config.Idempotency, identity func(r *http.Request) string) *idempotency {
+	maxBodyBytes := int64(cfg.MaxBodyBytes)
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = defaultIdempotencyMaxBodyBytes
+	}
+	return &idempotency{
+		service:      service,
+		maxBodyBytes: maxBodyBytes,
+		identity:     identity,
+	}
+}
+
+// middleware answers a retry of a request sent with an Idempotency-Key with the stored first response. Keys are
+// scoped to the client and the route, so two clients picking the same key don't get each other's answers. Requests
+// without the header, or without a service to keep the keys, pass straight through.
+func (p *idempotency) middleware(next http.Handler) http.Handler {
+	if p.service == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimSpace(r.Header.Get(HeaderIdempotencyKey))
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		resp := newResponse(time.Now())
+
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, p.maxBodyBytes))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				resp.setRequestEntityTooLarge("", w)
+				return
+			}
+			resp.setBadRequest(err.Error(), w)
+			return
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		scope := p.identity(r) + " " + r.URL.Path
+		res, err := p.service.BeginIdempotentRequest(context.Background(), services.BeginIdempotentRequestReq{
+			Scope:       scope,
+			Key:         key,
+			Fingerprint: requestFingerprint(r, body),
+		})
+		if err != nil {
+			resp.setError(err, w)
+			return
+		}
+		if res.Replay {
+			if res.ContentType != "" {
+				w.Header().Set("Content-Type", res.ContentType)
+			}
+			w.Header().Set(HeaderIdempotencyReplayed, "true")
+			w.WriteHeader(res.StatusCode)
+			w.Write(res.Body)
+			return
+		}
+
+		rec := &recordingWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		// A server error is not an answer worth keeping, the client may retry the request with the same key.
+		if rec.status >= http.StatusInternalServerError {
+			err = p.service.ReleaseIdempotentRequest(context.Background(), services.ReleaseIdempotentRequestReq{
+				Scope: scope,
+				Key:   key,
+			})
+		} else {
+			err = p.service.CompleteIdempotentRequest(context.Background(), services.CompleteIdempotentRequestReq{
+				Scope:       scope,
+				Key:         key,
+				StatusCode:  rec.status,
+				ContentType: rec.Header().Get("Content-Type"),
+				Body:        rec.body.Bytes(),
+			})
+		}
+		if err != nil {
+			log.Printf("idempotency key %s on %s: %v", key, scope, err)
+		}
+	})
+}
+
+// requestFingerprint tells a retry from another request reusing its key.
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "?" + r.URL.RawQuery + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// recordingWriter keeps a copy of the response it writes through.
+type recordingWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (w *recordingWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
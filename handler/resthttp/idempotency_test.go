@@ -0,0 +1,189 @@
+package resthttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/services"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_idempotencyMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	body := `{"key": "123", "branch": "central", "user_id": 1}`
+	rl := newRateLimiter(config.RateLimitConfig{KeyBy: []string{RateLimitKeyAPIKey, RateLimitKeyIP}, APIKeys: []string{"kiosk"}}, nil, nil)
+
+	tests := []struct {
+		name         string
+		key          string
+		apiKey       string
+		maxBodyBytes int
+		mock         func() IdempotencyService
+		nextStatus   int
+		wantCalls    int
+		wantCode     int
+		wantBody     string
+		wantReplayed string
+	}{
+		{
+			name: "test without key",
+			mock: func() IdempotencyService {
+				return NewMockIdempotencyService(ctrl)
+			},
+			nextStatus: http.StatusOK,
+			wantCalls:  1,
+			wantCode:   http.StatusOK,
+			wantBody:   "created",
+		},
+		{
+			name: "test first request is stored",
+			key:  "3f1c",
+			mock: func() IdempotencyService {
+				idemMock := NewMockIdempotencyService(ctrl)
+				idemMock.EXPECT().BeginIdempotentRequest(gomock.Any(), services.BeginIdempotentRequestReq{
+					Scope:       "ip:192.0.2.1 /borrow-book",
+					Key:         "3f1c",
+					Fingerprint: requestFingerprint(httptest.NewRequest(http.MethodPost, "/borrow-book", nil), []byte(body)),
+				}).Return(services.IdempotentRequestRes{}, nil)
+				idemMock.EXPECT().CompleteIdempotentRequest(gomock.Any(), services.CompleteIdempotentRequestReq{
+					Scope:       "ip:192.0.2.1 /borrow-book",
+					Key:         "3f1c",
+					StatusCode:  http.StatusConflict,
+					ContentType: "application/json",
+					Body:        []byte("created"),
+				}).Return(nil)
+				return idemMock
+			},
+			nextStatus: http.StatusConflict,
+			wantCalls:  1,
+			wantCode:   http.StatusConflict,
+			wantBody:   "created",
+		},
+		{
+			name:   "test key of another client",
+			key:    "3f1c",
+			apiKey: "kiosk",
+			mock: func() IdempotencyService {
+				idemMock := NewMockIdempotencyService(ctrl)
+				idemMock.EXPECT().BeginIdempotentRequest(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req services.BeginIdempotentRequestReq) (services.IdempotentRequestRes, error) {
+					if req.Scope != "apikey:kiosk /borrow-book" {
+						t.Errorf("BeginIdempotentRequest() scope = %q, want the API key", req.Scope)
+					}
+					return services.IdempotentRequestRes{}, nil
+				})
+				idemMock.EXPECT().CompleteIdempotentRequest(gomock.Any(), gomock.Any()).Return(nil)
+				return idemMock
+			},
+			nextStatus: http.StatusOK,
+			wantCalls:  1,
+			wantCode:   http.StatusOK,
+			wantBody:   "created",
+		},
+		{
+			name:         "test body too large",
+			key:          "3f1c",
+			maxBodyBytes: 16,
+			mock: func() IdempotencyService {
+				return NewMockIdempotencyService(ctrl)
+			},
+			wantCalls: 0,
+			wantCode:  http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "test retry is replayed",
+			key:  "3f1c",
+			mock: func() IdempotencyService {
+				idemMock := NewMockIdempotencyService(ctrl)
+				idemMock.EXPECT().BeginIdempotentRequest(gomock.Any(), gomock.Any()).Return(services.IdempotentRequestRes{
+					Replay:      true,
+					StatusCode:  http.StatusOK,
+					ContentType: "application/json",
+					Body:        []byte("stored"),
+				}, nil)
+				return idemMock
+			},
+			wantCalls:    0,
+			wantCode:     http.StatusOK,
+			wantBody:     "stored",
+			wantReplayed: "true",
+		},
+		{
+			name: "test server error releases the key",
+			key:  "3f1c",
+			mock: func() IdempotencyService {
+				idemMock := NewMockIdempotencyService(ctrl)
+				idemMock.EXPECT().BeginIdempotentRequest(gomock.Any(), gomock.Any()).Return(services.IdempotentRequestRes{}, nil)
+				idemMock.EXPECT().ReleaseIdempotentRequest(gomock.Any(), services.ReleaseIdempotentRequestReq{Scope: "ip:192.0.2.1 /borrow-book", Key: "3f1c"}).Return(nil)
+				return idemMock
+			},
+			nextStatus: http.StatusInternalServerError,
+			wantCalls:  1,
+			wantCode:   http.StatusInternalServerError,
+		},
+		{
+			name: "test key reused with another body",
+			key:  "3f1c",
+			mock: func() IdempotencyService {
+				idemMock := NewMockIdempotencyService(ctrl)
+				idemMock.EXPECT().BeginIdempotentRequest(gomock.Any(), gomock.Any()).Return(services.IdempotentRequestRes{}, &services.ServiceError{Code: services.ErrCodeUnprocessable, Message: "reused"})
+				return idemMock
+			},
+			wantCalls: 0,
+			wantCode:  http.StatusUnprocessableEntity,
+			wantBody:  "reused",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if got := readBody(r); got != body {
+					t.Errorf("next handler body = %q, want %q", got, body)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.nextStatus)
+				w.Write([]byte("created"))
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/borrow-book", strings.NewReader(body))
+			if tt.key != "" {
+				req.Header.Set(HeaderIdempotencyKey, tt.key)
+			}
+			if tt.apiKey != "" {
+				req.Header.Set(HeaderAPIKey, tt.apiKey)
+			}
+			w := httptest.NewRecorder()
+			newIdempotency(tt.mock(), config.Idempotency{MaxBodyBytes: tt.maxBodyBytes}, rl.clientKey).middleware(next).ServeHTTP(w, req)
+
+			if calls != tt.wantCalls {
+				t.Errorf("middleware() ran the handler %d times, want %d", calls, tt.wantCalls)
+			}
+			if w.Code != tt.wantCode {
+				t.Errorf("middleware() code = %v, want %v", w.Code, tt.wantCode)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("middleware() body = %v, want it to contain %v", w.Body.String(), tt.wantBody)
+			}
+			if got := w.Header().Get(HeaderIdempotencyReplayed); got != tt.wantReplayed {
+				t.Errorf("middleware() %s = %q, want %q", HeaderIdempotencyReplayed, got, tt.wantReplayed)
+			}
+		})
+	}
+}
+
+func readBody(r *http.Request) string {
+	var buf strings.Builder
+	b := make([]byte, 512)
+	for {
+		n, err := r.Body.Read(b)
+		buf.Write(b[:n])
+		if err != nil {
+			return buf.String()
+		}
+	}
+}
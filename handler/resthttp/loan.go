@@ -0,0 +1,125 @@
+package resthttp
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/services"
+)
+
+func (p bookHandler) CheckoutBook(w http.ResponseWriter, r *http.Request) {
+	resp := newResponse(time.Now())
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+
+	reqBody := services.CheckoutBookReq{}
+	err = json.Unmarshal(body, &reqBody)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+	res, err := p.service.CheckoutBook(context.Background(), reqBody)
+	if err != nil {
+		resp.setError(err, w)
+		return
+	}
+
+	resp.setOK(map[string]interface{}{
+		"data": res,
+	}, w)
+	return
+}
+
+func (p bookHandler) ReturnBook(w http.ResponseWriter, r *http.Request) {
+	resp := newResponse(time.Now())
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+
+	reqBody := services.ReturnBookReq{}
+	err = json.Unmarshal(body, &reqBody)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+	res, err := p.service.ReturnBook(context.Background(), reqBody)
+	if err != nil {
+		resp.setError(err, w)
+		return
+	}
+
+	resp.setOK(map[string]interface{}{
+		"data": res,
+	}, w)
+	return
+}
+
+func (p bookHandler) RenewLoan(w http.ResponseWriter, r *http.Request) {
+	resp := newResponse(time.Now())
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+
+	reqBody := services.RenewLoanReq{}
+	err = json.Unmarshal(body, &reqBody)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+	res, err := p.service.RenewLoan(context.Background(), reqBody)
+	if err != nil {
+		resp.setError(err, w)
+		return
+	}
+
+	resp.setOK(map[string]interface{}{
+		"data": res,
+	}, w)
+	return
+}
+
+func (p bookHandler) GetLoans(w http.ResponseWriter, r *http.Request) {
+	var (
+		uid int
+		err error
+	)
+
+	resp := newResponse(time.Now())
+
+	userIDString := strings.TrimSpace(r.URL.Query().Get("user_id"))
+	if userIDString != "" {
+		uid, err = strconv.Atoi(userIDString)
+		if err != nil {
+			resp.setBadRequest(err.Error(), w)
+			return
+		}
+	}
+
+	res, err := p.service.GetLoans(context.Background(), services.GetLoansReq{
+		UserID: uid,
+	})
+	if err != nil {
+		resp.setError(err, w)
+		return
+	}
+
+	resp.setOK(map[string]interface{}{
+		"data": res,
+	}, w)
+	return
+}
@@ -0,0 +1,208 @@
+package resthttp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/services"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_loanRoutesAdminOnly(t *testing.T) {
+	testAdminOnly(t, "POST /checkout-book", "POST /return-book", "POST /renew-loan", "GET /get-loans")
+}
+
+func Test_CheckoutBook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		body     string
+		mock     func() BookService
+		wantCode int
+	}{
+		{
+			name: "test normal flow",
+			body: `{"reservation_id": 3}`,
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().CheckoutBook(gomock.Any(), services.CheckoutBookReq{ReservationID: 3}).Return(services.LoanRes{ID: 1}, nil)
+				return bookMock
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "test bad request",
+			body: "",
+			mock: func() BookService {
+				return NewMockBookService(ctrl)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "test conflict",
+			body: `{"reservation_id": 3}`,
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().CheckoutBook(gomock.Any(), gomock.Any()).Return(services.LoanRes{}, &services.ServiceError{Code: services.ErrCodeConflict, Message: "Reservation cannot be changed in its current status"})
+				return bookMock
+			},
+			wantCode: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			i := bookHandler{
+				service: tt.mock(),
+			}
+			i.CheckoutBook(w, httptest.NewRequest("POST", "http://localhost:8000/checkout-book", strings.NewReader(tt.body)))
+			if w.Code != tt.wantCode {
+				t.Errorf("CheckoutBook() status = %v, want %v", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_ReturnBook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		body     string
+		mock     func() BookService
+		wantCode int
+	}{
+		{
+			name: "test normal flow",
+			body: `{"loan_id": 1}`,
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().ReturnBook(gomock.Any(), services.ReturnBookReq{LoanID: 1}).Return(services.LoanRes{ID: 1}, nil)
+				return bookMock
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "test not found",
+			body: `{"loan_id": 1}`,
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().ReturnBook(gomock.Any(), gomock.Any()).Return(services.LoanRes{}, &services.ServiceError{Code: services.ErrCodeNotFound, Message: "Loan not found"})
+				return bookMock
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			i := bookHandler{
+				service: tt.mock(),
+			}
+			i.ReturnBook(w, httptest.NewRequest("POST", "http://localhost:8000/return-book", strings.NewReader(tt.body)))
+			if w.Code != tt.wantCode {
+				t.Errorf("ReturnBook() status = %v, want %v", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_RenewLoan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		body     string
+		mock     func() BookService
+		wantCode int
+	}{
+		{
+			name: "test normal flow",
+			body: `{"loan_id": 1}`,
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().RenewLoan(gomock.Any(), services.RenewLoanReq{LoanID: 1}).Return(services.LoanRes{ID: 1, Renewals: 1}, nil)
+				return bookMock
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "test renewal refused",
+			body: `{"loan_id": 1}`,
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().RenewLoan(gomock.Any(), gomock.Any()).Return(services.LoanRes{}, &services.ServiceError{Code: services.ErrCodeConflict, Message: "Loan renewal limit reached"})
+				return bookMock
+			},
+			wantCode: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			i := bookHandler{
+				service: tt.mock(),
+			}
+			i.RenewLoan(w, httptest.NewRequest("POST", "http://localhost:8000/renew-loan", strings.NewReader(tt.body)))
+			if w.Code != tt.wantCode {
+				t.Errorf("RenewLoan() status = %v, want %v", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_GetLoans(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		url      string
+		mock     func() BookService
+		wantCode int
+	}{
+		{
+			name: "test normal flow",
+			url:  "http://localhost:8000/get-loans?user_id=2",
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().GetLoans(gomock.Any(), services.GetLoansReq{UserID: 2}).Return(map[int][]services.LoanRes{}, nil)
+				return bookMock
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "test bad user id",
+			url:  "http://localhost:8000/get-loans?user_id=abc",
+			mock: func() BookService {
+				return NewMockBookService(ctrl)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "test internal server error",
+			url:  "http://localhost:8000/get-loans",
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().GetLoans(gomock.Any(), services.GetLoansReq{}).Return(nil, errors.New("error"))
+				return bookMock
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			i := bookHandler{
+				service: tt.mock(),
+			}
+			i.GetLoans(w, httptest.NewRequest("GET", tt.url, nil))
+			if w.Code != tt.wantCode {
+				t.Errorf("GetLoans() status = %v, want %v", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package resthttp
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/services"
+)
+
+func (p bookHandler) GetOutboxEvents(w http.ResponseWriter, r *http.Request) {
+	resp := newResponse(time.Now())
+
+	res, err := p.service.GetOutboxEvents(context.Background(), services.GetOutboxEventsReq{
+		Status: strings.TrimSpace(r.URL.Query().Get("status")),
+	})
+	if err != nil {
+		resp.setError(err, w)
+		return
+	}
+
+	resp.setOK(map[string]interface{}{
+		"data": res,
+	}, w)
+	return
+}
+
+func (p bookHandler) RequeueOutboxEvent(w http.ResponseWriter, r *http.Request) {
+	resp := newResponse(time.Now())
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+
+	reqBody := services.RequeueOutboxEventReq{}
+	err = json.Unmarshal(body, &reqBody)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+	res, err := p.service.RequeueOutboxEvent(context.Background(), reqBody)
+	if err != nil {
+		resp.setError(err, w)
+		return
+	}
+
+	resp.setOK(map[string]interface{}{
+		"data": res,
+	}, w)
+	return
+}
@@ -0,0 +1,83 @@
+package resthttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/services"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_outboxRoutesAdminOnly(t *testing.T) {
+	testAdminOnly(t, "GET /get-outbox-events", "POST /requeue-outbox-event")
+}
+
+func Test_GetOutboxEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	bookMock := NewMockBookService(ctrl)
+	bookMock.EXPECT().GetOutboxEvents(gomock.Any(), services.GetOutboxEventsReq{Status: "dead"}).Return([]services.OutboxEventRes{{ID: 3, Status: "dead"}}, nil)
+
+	w := httptest.NewRecorder()
+	i := bookHandler{
+		service: bookMock,
+	}
+	i.GetOutboxEvents(w, httptest.NewRequest("GET", "http://localhost:8000/get-outbox-events?status=dead", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("GetOutboxEvents() status = %v, want %v", w.Code, http.StatusOK)
+	}
+}
+
+func Test_RequeueOutboxEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		body     string
+		mock     func() BookService
+		wantCode int
+	}{
+		{
+			name: "test normal flow",
+			body: `{"id": 3}`,
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().RequeueOutboxEvent(gomock.Any(), services.RequeueOutboxEventReq{ID: 3}).Return(services.OutboxEventRes{ID: 3, Status: "pending"}, nil)
+				return bookMock
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "test bad request",
+			body: "",
+			mock: func() BookService {
+				return NewMockBookService(ctrl)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "test not dead",
+			body: `{"id": 3}`,
+			mock: func() BookService {
+				bookMock := NewMockBookService(ctrl)
+				bookMock.EXPECT().RequeueOutboxEvent(gomock.Any(), gomock.Any()).Return(services.OutboxEventRes{}, &services.ServiceError{Code: services.ErrCodeConflict, Message: "Only dead-lettered outbox events can be requeued"})
+				return bookMock
+			},
+			wantCode: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			i := bookHandler{
+				service: tt.mock(),
+			}
+			i.RequeueOutboxEvent(w, httptest.NewRequest("POST", "http://localhost:8000/requeue-outbox-event", strings.NewReader(tt.body)))
+			if w.Code != tt.wantCode {
+				t.Errorf("RequeueOutboxEvent() status = %v, want %v", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
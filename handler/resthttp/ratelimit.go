@@ -0,0 +1,216 @@
+package resthttp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/services"
+)
+
+const (
+	RateLimitGroupCatalog     = "catalog"
+	RateLimitGroupReservation = "reservation"
+
+	RateLimitKeyAPIKey = "apikey"
+	RateLimitKeyUser   = "user"
+	RateLimitKeyIP     = "ip"
+
+	HeaderAPIKey = "X-API-Key"
+	HeaderUserID = "X-User-ID"
+)
+
+var defaultRateLimitKeyBy = []string{RateLimitKeyIP}
+
+type RateLimitResult struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+type rateLimiter struct {
+	cfg     config.RateLimitConfig
+	store   RateLimitStore
+	apiKeys map[string]bool
+	users   UserService
+}
+
+// newRateLimiter checks user identities against users, without it requests are never counted per user.
+func newRateLimiter(cfg config.RateLimitConfig, store RateLimitStore, users UserService) *rateLimiter {
+	if len(cfg.KeyBy) == 0 {
+		cfg.KeyBy = defaultRateLimitKeyBy
+	}
+	if store == nil {
+		store = NewMemoryRateLimitStore()
+	}
+	apiKeys := map[string]bool{}
+	for _, key := range cfg.APIKeys {
+		apiKeys[key] = true
+	}
+	return &rateLimiter{
+		cfg:     cfg,
+		store:   store,
+		apiKeys: apiKeys,
+		users:   users,
+	}
+}
+
+// middleware limits each client to the budget configured for group. Groups without a budget are not limited.
+func (rl *rateLimiter) middleware(group string) func(http.Handler) http.Handler {
+	budget, ok := rl.cfg.Groups[group]
+	if !rl.cfg.Enabled || !ok || budget.Limit <= 0 || budget.WindowSec <= 0 {
+		return func(next http.Handler) http.Handler {
+			return next
+		}
+	}
+	window := time.Duration(budget.WindowSec) * time.Second
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := group + ":" + rl.clientKey(r)
+			res, err := rl.store.Take(r.Context(), key, budget.Limit, window)
+			if err != nil {
+				// A broken store should not take the API down with it.
+				log.Printf("rate limit store error for %s: %v", key, err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(res.Reset.Unix(), 10))
+
+			if !res.Allowed {
+				retryAfter := int(time.Until(res.Reset).Seconds() + 0.999)
+				if retryAfter < 1 {
+					retryAfter = 1
+				}
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				resp := newResponse(time.Now())
+				resp.setTooManyRequests(fmt.Sprintf("Rate limit exceeded, retry in %d seconds", retryAfter), w)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientKey identifies the client by the first identity of KeyBy that checks out. API keys and user IDs come from
+// the client, so they only count when the key is configured or the user is registered, anything else is counted by
+// IP. Otherwise a client could send a new made-up value with every request and never run out of budget. A user ID
+// is not authenticated, so it splits the budget of an IP between its users but never leaves that IP: nobody can
+// drain the bucket of another user from elsewhere.
+func (rl *rateLimiter) clientKey(r *http.Request) string {
+	for _, kind := range rl.cfg.KeyBy {
+		switch kind {
+		case RateLimitKeyAPIKey:
+			if v := strings.TrimSpace(r.Header.Get(HeaderAPIKey)); v != "" && rl.apiKeys[v] {
+				return "apikey:" + v
+			}
+		case RateLimitKeyUser:
+			v := strings.TrimSpace(r.Header.Get(HeaderUserID))
+			if v == "" {
+				v = strings.TrimSpace(r.URL.Query().Get("user_id"))
+			}
+			if rl.registeredUser(r.Context(), v) {
+				return "user:" + v + "@" + rl.clientIP(r)
+			}
+		case RateLimitKeyIP:
+			return "ip:" + rl.clientIP(r)
+		}
+	}
+	return "ip:" + rl.clientIP(r)
+}
+
+func (rl *rateLimiter) registeredUser(ctx context.Context, v string) bool {
+	if rl.users == nil || v == "" {
+		return false
+	}
+	id, err := strconv.Atoi(v)
+	if err != nil || id <= 0 {
+		return false
+	}
+	_, err = rl.users.GetUser(ctx, services.GetUserReq{ID: id})
+	return err == nil
+}
+
+func (rl *rateLimiter) clientIP(r *http.Request) string {
+	if rl.cfg.TrustProxyHeaders {
+		if v := r.Header.Get("X-Forwarded-For"); v != "" {
+			return strings.TrimSpace(strings.Split(v, ",")[0])
+		}
+		if v := strings.TrimSpace(r.Header.Get("X-Real-IP")); v != "" {
+			return v
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// memoryRateLimitStore counts requests in fixed windows, it is the default store for a single replica.
+type memoryRateLimitStore struct {
+	mu      sync.Mutex
+	windows map[string]*rateLimitWindow
+	calls   int
+}
+
+type rateLimitWindow struct {
+	count int
+	reset time.Time
+}
+
+const memoryRateLimitSweepEvery = 1024
+
+func NewMemoryRateLimitStore() RateLimitStore {
+	return &memoryRateLimitStore{
+		windows: make(map[string]*rateLimitWindow),
+	}
+}
+
+func (s *memoryRateLimitStore) Take(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.calls++
+	if s.calls%memoryRateLimitSweepEvery == 0 {
+		for k, w := range s.windows {
+			if !now.Before(w.reset) {
+				delete(s.windows, k)
+			}
+		}
+	}
+
+	w, ok := s.windows[key]
+	if !ok || !now.Before(w.reset) {
+		w = &rateLimitWindow{
+			reset: now.Add(window),
+		}
+		s.windows[key] = w
+	}
+
+	res := RateLimitResult{
+		Limit: limit,
+		Reset: w.reset,
+	}
+	if w.count >= limit {
+		return res, nil
+	}
+
+	w.count++
+	res.Allowed = true
+	res.Remaining = limit - w.count
+	return res, nil
+}
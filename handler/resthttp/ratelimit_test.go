@@ -0,0 +1,236 @@
+package resthttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/services"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_memoryRateLimitStore(t *testing.T) {
+	s := NewMemoryRateLimitStore()
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		res, _ := s.Take(ctx, "k", 2, time.Minute)
+		if !res.Allowed || res.Remaining != 1-i {
+			t.Fatalf("Take() %d = %+v", i, res)
+		}
+	}
+	if res, _ := s.Take(ctx, "k", 2, time.Minute); res.Allowed || res.Remaining != 0 {
+		t.Errorf("Take() over limit = %+v", res)
+	}
+	if res, _ := s.Take(ctx, "other", 2, time.Minute); !res.Allowed {
+		t.Errorf("Take() other key = %+v", res)
+	}
+
+	if res, _ := s.Take(ctx, "short", 1, time.Millisecond); !res.Allowed {
+		t.Fatalf("Take() short window = %+v", res)
+	}
+	time.Sleep(2 * time.Millisecond)
+	if res, _ := s.Take(ctx, "short", 1, time.Millisecond); !res.Allowed {
+		t.Errorf("Take() after window reset = %+v", res)
+	}
+}
+
+func Test_rateLimiterClientKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	userMock := NewMockUserService(ctrl)
+	userMock.EXPECT().GetUser(gomock.Any(), services.GetUserReq{ID: 7}).Return(services.UserRes{ID: 7}, nil).AnyTimes()
+	userMock.EXPECT().GetUser(gomock.Any(), services.GetUserReq{ID: 8}).Return(services.UserRes{}, errors.New("User not found")).AnyTimes()
+
+	all := []string{RateLimitKeyAPIKey, RateLimitKeyUser, RateLimitKeyIP}
+	tests := []struct {
+		name   string
+		cfg    config.RateLimitConfig
+		header http.Header
+		url    string
+		want   string
+	}{
+		{
+			name:   "ip by default",
+			header: http.Header{HeaderAPIKey: {"kiosk"}, HeaderUserID: {"7"}},
+			url:    "/get-books",
+			want:   "ip:192.0.2.1",
+		},
+		{
+			name:   "configured api key first",
+			cfg:    config.RateLimitConfig{KeyBy: all, APIKeys: []string{"kiosk"}},
+			header: http.Header{HeaderAPIKey: {"kiosk"}, HeaderUserID: {"7"}},
+			url:    "/get-books",
+			want:   "apikey:kiosk",
+		},
+		{
+			name:   "unknown api key falls through",
+			cfg:    config.RateLimitConfig{KeyBy: all, APIKeys: []string{"kiosk"}},
+			header: http.Header{HeaderAPIKey: {"made-up"}},
+			url:    "/get-books",
+			want:   "ip:192.0.2.1",
+		},
+		{
+			name: "registered user from query",
+			cfg:  config.RateLimitConfig{KeyBy: all},
+			url:  "/get-book-reservation?user_id=7",
+			want: "user:7@192.0.2.1",
+		},
+		{
+			name:   "unknown user falls back to ip",
+			cfg:    config.RateLimitConfig{KeyBy: []string{RateLimitKeyUser}},
+			header: http.Header{HeaderUserID: {"8"}},
+			url:    "/get-books",
+			want:   "ip:192.0.2.1",
+		},
+		{
+			name:   "invalid user falls back to ip",
+			cfg:    config.RateLimitConfig{KeyBy: all},
+			header: http.Header{HeaderUserID: {"abc"}},
+			url:    "/get-books",
+			want:   "ip:192.0.2.1",
+		},
+		{
+			name:   "forwarded for when trusted",
+			cfg:    config.RateLimitConfig{KeyBy: []string{RateLimitKeyIP}, TrustProxyHeaders: true},
+			header: http.Header{"X-Forwarded-For": {"203.0.113.9, 10.0.0.1"}, HeaderAPIKey: {"kiosk"}},
+			url:    "/get-books",
+			want:   "ip:203.0.113.9",
+		},
+		{
+			name:   "forwarded for ignored by default",
+			header: http.Header{"X-Forwarded-For": {"203.0.113.9"}},
+			url:    "/get-books",
+			want:   "ip:192.0.2.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rl := newRateLimiter(tt.cfg, nil, userMock)
+			r := httptest.NewRequest("GET", tt.url, nil)
+			for k, v := range tt.header {
+				r.Header.Set(k, v[0])
+			}
+			if got := rl.clientKey(r); got != tt.want {
+				t.Errorf("clientKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_RateLimitMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	bookMock := NewMockBookService(ctrl)
+	bookMock.EXPECT().GetListOfBooks(gomock.Any(), gomock.Any()).Return(services.GetListOfBooksResp{}, nil).Times(3)
+	bookMock.EXPECT().GetBookReservation(gomock.Any(), gomock.Any()).Return(map[int][]services.GetBookReservationRes{}, nil).Times(1)
+
+	router := NewRoutes(RouterDependencies{
+		BS: bookMock,
+		RateLimit: config.RateLimitConfig{
+			Enabled: true,
+			KeyBy:   []string{RateLimitKeyAPIKey, RateLimitKeyIP},
+			APIKeys: []string{"a", "b"},
+			Groups: map[string]config.RateLimitGroup{
+				RateLimitGroupCatalog:     {Limit: 2, WindowSec: 60},
+				RateLimitGroupReservation: {Limit: 1, WindowSec: 60},
+			},
+		},
+	})
+
+	do := func(url, apiKey string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("GET", url, strings.NewReader(""))
+		if apiKey != "" {
+			r.Header.Set(HeaderAPIKey, apiKey)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	for i := 0; i < 2; i++ {
+		if w := do("/get-books?subject=love", "a"); w.Code != http.StatusOK {
+			t.Fatalf("request %d status = %v", i, w.Code)
+		}
+	}
+
+	w := do("/get-books?subject=love", "a")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %v, want %v", w.Code, http.StatusTooManyRequests)
+	}
+	if w.Header().Get("Retry-After") == "" || w.Header().Get("X-RateLimit-Limit") != "2" || w.Header().Get("X-RateLimit-Remaining") != "0" || w.Header().Get("X-RateLimit-Reset") == "" {
+		t.Errorf("headers = %v", w.Header())
+	}
+	body := baseResp{}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || !body.IsError {
+		t.Errorf("body = %v, %v", w.Body.String(), err)
+	}
+
+	// Other clients and other route groups have their own budget.
+	if w := do("/get-books?subject=love", "b"); w.Code != http.StatusOK {
+		t.Errorf("other client status = %v", w.Code)
+	}
+	if w := do("/get-book-reservation", "a"); w.Code != http.StatusOK {
+		t.Errorf("reservation group status = %v", w.Code)
+	}
+	if w := do("/get-book-reservation", "a"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("reservation group second status = %v", w.Code)
+	}
+}
+
+func Test_RateLimitMiddlewareStoreError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	storeMock := NewMockRateLimitStore(ctrl)
+	storeMock.EXPECT().Take(gomock.Any(), "catalog:ip:192.0.2.1", 1, time.Minute).Return(RateLimitResult{}, errors.New("error"))
+	bookMock := NewMockBookService(ctrl)
+	bookMock.EXPECT().GetListOfBooks(gomock.Any(), gomock.Any()).Return(services.GetListOfBooksResp{}, nil)
+
+	router := NewRoutes(RouterDependencies{
+		BS: bookMock,
+		RateLimit: config.RateLimitConfig{
+			Enabled: true,
+			Groups: map[string]config.RateLimitGroup{
+				RateLimitGroupCatalog: {Limit: 1, WindowSec: 60},
+			},
+		},
+		RateLimitStore: storeMock,
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/get-books?subject=love", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %v, want fail open", w.Code)
+	}
+}
+
+func Test_RateLimitDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	bookMock := NewMockBookService(ctrl)
+	bookMock.EXPECT().GetListOfBooks(gomock.Any(), gomock.Any()).Return(services.GetListOfBooksResp{}, nil).Times(3)
+
+	router := NewRoutes(RouterDependencies{
+		BS: bookMock,
+		RateLimit: config.RateLimitConfig{
+			Enabled: false,
+			Groups: map[string]config.RateLimitGroup{
+				RateLimitGroupCatalog: {Limit: 1, WindowSec: 60},
+			},
+		},
+	})
+
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/get-books?subject=love", nil))
+		if w.Code != http.StatusOK || w.Header().Get("X-RateLimit-Limit") != "" {
+			t.Errorf("request %d status = %v headers = %v", i, w.Code, w.Header())
+		}
+	}
+}
@@ -1,20 +1,101 @@
 package resthttp
 
 import (
+	"expvar"
+
+	"gihub.com/gadhittana01/book-project/config"
 	"github.com/go-chi/chi"
 )
 
 type RouterDependencies struct {
-	BS BookService
+	BS             BookService
+	US             UserService
+	WS             WebhookService
+	RS             ReservationStreamService
+	IS             IdempotencyService
+	SW             SweeperService
+	EventStream    config.EventStream
+	RateLimit      config.RateLimitConfig
+	RateLimitStore RateLimitStore
+	Idempotency    config.Idempotency
+	AdminToken     string
 }
 
 func NewRoutes(rd RouterDependencies) *chi.Mux {
 	router := chi.NewRouter()
+	rl := newRateLimiter(rd.RateLimit, rd.RateLimitStore, rd.US)
+	// Creation routes replay their first response to retries sent with the same Idempotency-Key.
+	idem := newIdempotency(rd.IS, rd.Idempotency, rl.clientKey)
+	admin := newAdminAuth(rd.AdminToken)
 
 	bh := newBookHandler(rd.BS)
-	router.Get("/get-books", bh.GetListOfBooks)
-	router.Post("/borrow-book", bh.BorrowBook)
-	router.Get("/get-book-reservation", bh.GetBookReservation)
+	router.With(rl.middleware(RateLimitGroupCatalog)).Get("/get-books", bh.GetListOfBooks)
+	router.Get("/get-branches", bh.GetBranches)
+	router.Get("/get-book-availability", bh.GetBookAvailability)
+	router.Get("/get-pickup-slots", bh.GetPickupSlots)
+	router.Group(func(r chi.Router) {
+		r.Use(rl.middleware(RateLimitGroupReservation))
+		r.With(idem.middleware).Post("/borrow-book", bh.BorrowBook)
+		r.With(idem.middleware).Post("/reservations:batch", bh.BorrowBooks)
+		r.Get("/get-book-reservation", bh.GetBookReservation)
+		r.With(idem.middleware).Post("/join-waitlist", bh.JoinWaitlist)
+		r.Post("/cancel-reservation", bh.CancelReservation)
+		r.Post("/confirm-reservation", bh.ConfirmReservation)
+	})
+	// Calendar apps subscribe to this feed and poll it, the path ends in .ics so they recognise it.
+	router.Get("/users/{id}/reservations.ics", bh.GetReservationCalendar)
+	// Loan and fine routes are used by librarians at the desk.
+	router.Group(func(r chi.Router) {
+		r.Use(admin.middleware)
+		r.Post("/checkout-book", bh.CheckoutBook)
+		r.Post("/return-book", bh.ReturnBook)
+		r.Post("/renew-loan", bh.RenewLoan)
+		r.Get("/get-loans", bh.GetLoans)
+		r.Post("/settle-fines", bh.SettleFines)
+		r.Post("/waive-fine", bh.WaiveFine)
+	})
+	router.Get("/get-fines", bh.GetFines)
+	// Outbox routes let operators inspect and retry dead-lettered reservation events.
+	router.Group(func(r chi.Router) {
+		r.Use(admin.middleware)
+		r.Get("/get-outbox-events", bh.GetOutboxEvents)
+		r.Post("/requeue-outbox-event", bh.RequeueOutboxEvent)
+	})
+	// Operators expire uncollected reservations without waiting for the next sweeper run.
+	swh := newSweeperHandler(rd.SW)
+	router.With(admin.middleware).Post("/expire-reservations", swh.ExpireReservations)
+
+	uh := newUserHandler(rd.US)
+	router.With(idem.middleware).Post("/register-user", uh.RegisterUser)
+	router.Get("/get-user", uh.GetUser)
+	router.Post("/update-user-profile", uh.UpdateUserProfile)
+	router.Post("/deactivate-user", uh.DeactivateUser)
+	router.Post("/set-notify-opt-out", uh.SetNotifyOptOut)
+
+	// Webhook routes are used by admins to manage partner subscriptions.
+	wh := newWebhookHandler(rd.WS)
+	router.Group(func(r chi.Router) {
+		r.Use(admin.middleware)
+		r.With(idem.middleware).Post("/create-webhook", wh.CreateWebhook)
+		r.Get("/get-webhooks", wh.GetWebhooks)
+		r.Post("/update-webhook", wh.UpdateWebhook)
+		r.Post("/delete-webhook", wh.DeleteWebhook)
+		r.Get("/get-webhook-deliveries", wh.GetWebhookDeliveries)
+		r.Post("/replay-webhook-delivery", wh.ReplayWebhookDelivery)
+	})
+
+	// The event stream stays outside the rate limiter, clients hold one long-lived connection.
+	sh := newStreamHandler(rd.RS, rd.EventStream.HeartbeatSec)
+	router.Get("/events/reservations", sh.StreamReservationEvents)
+
+	router.Get("/readiness", bh.GetReadiness)
 
 	return router
 }
+
+// NewInternalRoutes serves the operational endpoints that must not be public, such as the expvar counters.
+func NewInternalRoutes() *chi.Mux {
+	router := chi.NewRouter()
+	router.Handle("/debug/vars", expvar.Handler())
+	return router
+}
@@ -0,0 +1,21 @@
+package resthttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_debugVarsInternalOnly(t *testing.T) {
+	w := httptest.NewRecorder()
+	NewRoutes(RouterDependencies{}).ServeHTTP(w, httptest.NewRequest("GET", "/debug/vars", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("public status = %v, want %v", w.Code, http.StatusNotFound)
+	}
+
+	w = httptest.NewRecorder()
+	NewInternalRoutes().ServeHTTP(w, httptest.NewRequest("GET", "/debug/vars", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("internal status = %v, want %v", w.Code, http.StatusOK)
+	}
+}
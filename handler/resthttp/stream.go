@@ -0,0 +1,122 @@
+package resthttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/services"
+)
+
+const (
+	defaultHeartbeatSec = 15
+	// streamRetryMS tells EventSource clients how long to wait before reconnecting.
+	streamRetryMS = 3000
+)
+
+type streamHandler struct {
+	service   ReservationStreamService
+	heartbeat time.Duration
+}
+
+func newStreamHandler(service ReservationStreamService, heartbeatSec int) *streamHandler {
+	if heartbeatSec <= 0 {
+		heartbeatSec = defaultHeartbeatSec
+	}
+	return &streamHandler{
+		service:   service,
+		heartbeat: time.Duration(heartbeatSec) * time.Second,
+	}
+}
+
+// StreamReservationEvents streams reservation events as server-sent events until the client disconnects. The
+// subscription follows the request context rather than context.Background() so it ends with the connection.
+func (p streamHandler) StreamReservationEvents(w http.ResponseWriter, r *http.Request) {
+	var (
+		uid, lastEventID int
+		err              error
+	)
+	resp := newResponse(time.Now())
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		resp.setInternalServerError("Streaming is not supported", w)
+		return
+	}
+
+	query := r.URL.Query()
+	userIDString := strings.TrimSpace(query.Get("user_id"))
+	if userIDString != "" {
+		uid, err = strconv.Atoi(userIDString)
+		if err != nil {
+			resp.setBadRequest(err.Error(), w)
+			return
+		}
+	}
+	lastEventIDString := strings.TrimSpace(r.Header.Get("Last-Event-ID"))
+	if lastEventIDString != "" {
+		lastEventID, err = strconv.Atoi(lastEventIDString)
+		if err != nil {
+			resp.setBadRequest(err.Error(), w)
+			return
+		}
+	}
+
+	sub, err := p.service.SubscribeReservationEvents(r.Context(), services.SubscribeReservationEventsReq{
+		UserID:      uid,
+		Branch:      strings.TrimSpace(query.Get("branch")),
+		LastEventID: lastEventID,
+	})
+	if err != nil {
+		resp.setError(err, w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "retry: %d\n\n", streamRetryMS)
+	if sub.Gap {
+		// Some events after Last-Event-ID are no longer buffered, the client should reload its state.
+		fmt.Fprintf(w, "event: resync\ndata: {}\n\n")
+	}
+	for _, event := range sub.Replay {
+		writeStreamEvent(w, event)
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(p.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-sub.Events:
+			if !ok {
+				// The stream dropped this client for falling behind, it reconnects with Last-Event-ID.
+				return
+			}
+			writeStreamEvent(w, event)
+			flusher.Flush()
+		case <-ticker.C:
+			fmt.Fprintf(w, ": heartbeat\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+func writeStreamEvent(w http.ResponseWriter, event services.ReservationEventRes) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("stream event %d: %v", event.ID, err)
+		return
+	}
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+}
@@ -0,0 +1,100 @@
+package resthttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/services"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_StreamReservationEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name        string
+		query       string
+		lastEventID string
+		mock        func(events chan services.ReservationEventRes) ReservationStreamService
+		wantCode    int
+		wantBody    []string
+	}{
+		{
+			name:        "test normal flow",
+			query:       "?user_id=1&branch=central",
+			lastEventID: "3",
+			mock: func(events chan services.ReservationEventRes) ReservationStreamService {
+				streamMock := NewMockReservationStreamService(ctrl)
+				streamMock.EXPECT().SubscribeReservationEvents(gomock.Any(), services.SubscribeReservationEventsReq{
+					UserID:      1,
+					Branch:      "central",
+					LastEventID: 3,
+				}).Return(services.ReservationEventSubscription{
+					Replay: []services.ReservationEventRes{{ID: 4, Type: "reservation.cancelled"}},
+					Gap:    true,
+					Events: events,
+				}, nil)
+				return streamMock
+			},
+			wantCode: http.StatusOK,
+			wantBody: []string{
+				"retry: 3000\n\n",
+				"event: resync\n",
+				"id: 4\nevent: reservation.cancelled\ndata: {\"id\":4,",
+				"id: 5\nevent: reservation.created\ndata: {\"id\":5,",
+			},
+		},
+		{
+			name:  "test invalid last event id",
+			query: "",
+			mock: func(events chan services.ReservationEventRes) ReservationStreamService {
+				return NewMockReservationStreamService(ctrl)
+			},
+			lastEventID: "abc",
+			wantCode:    http.StatusBadRequest,
+		},
+		{
+			name:  "test service error",
+			query: "?user_id=-1",
+			mock: func(events chan services.ReservationEventRes) ReservationStreamService {
+				streamMock := NewMockReservationStreamService(ctrl)
+				streamMock.EXPECT().SubscribeReservationEvents(gomock.Any(), gomock.Any()).Return(services.ReservationEventSubscription{}, &services.ServiceError{Code: services.ErrCodeInvalidRequest})
+				return streamMock
+			},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := make(chan services.ReservationEventRes, 1)
+			events <- services.ReservationEventRes{ID: 5, Type: "reservation.created"}
+			// Closing the channel ends the stream the same way a dropped subscription does.
+			close(events)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			req := httptest.NewRequest(http.MethodGet, "/events/reservations"+tt.query, nil).WithContext(ctx)
+			if tt.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", tt.lastEventID)
+			}
+			w := httptest.NewRecorder()
+			newStreamHandler(tt.mock(events), 0).StreamReservationEvents(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("StreamReservationEvents() code = %v, want %v", w.Code, tt.wantCode)
+			}
+			body := w.Body.String()
+			for _, want := range tt.wantBody {
+				if !strings.Contains(body, want) {
+					t.Errorf("StreamReservationEvents() body = %q, want it to contain %q", body, want)
+				}
+			}
+			if tt.wantCode == http.StatusOK && w.Header().Get("Content-Type") != "text/event-stream" {
+				t.Errorf("StreamReservationEvents() content type = %v", w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
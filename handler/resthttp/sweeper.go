@@ -0,0 +1,33 @@
+package resthttp
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type sweeperHandler struct {
+	service SweeperService
+}
+
+func newSweeperHandler(service SweeperService) *sweeperHandler {
+	return &sweeperHandler{
+		service: service,
+	}
+}
+
+// ExpireReservations runs the sweeper once. The answer is skipped when another replica holds the sweeper lease.
+func (p sweeperHandler) ExpireReservations(w http.ResponseWriter, r *http.Request) {
+	resp := newResponse(time.Now())
+
+	res, err := p.service.Sweep(context.Background())
+	if err != nil {
+		resp.setError(err, w)
+		return
+	}
+
+	resp.setOK(map[string]interface{}{
+		"data": res,
+	}, w)
+	return
+}
@@ -0,0 +1,71 @@
+package resthttp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/services"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_ExpireReservations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		mock     func() SweeperService
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "test normal flow",
+			mock: func() SweeperService {
+				sweeperMock := NewMockSweeperService(ctrl)
+				sweeperMock.EXPECT().Sweep(gomock.Any()).Return(services.SweepRes{
+					Expired:      1,
+					Reservations: []services.GetBookReservationRes{{ID: 4, Status: "expired"}},
+				}, nil)
+				return sweeperMock
+			},
+			wantCode: http.StatusOK,
+			wantBody: `"expired":1`,
+		},
+		{
+			name: "test lease held elsewhere",
+			mock: func() SweeperService {
+				sweeperMock := NewMockSweeperService(ctrl)
+				sweeperMock.EXPECT().Sweep(gomock.Any()).Return(services.SweepRes{Skipped: true}, nil)
+				return sweeperMock
+			},
+			wantCode: http.StatusOK,
+			wantBody: `"skipped":true`,
+		},
+		{
+			name: "test store error",
+			mock: func() SweeperService {
+				sweeperMock := NewMockSweeperService(ctrl)
+				sweeperMock.EXPECT().Sweep(gomock.Any()).Return(services.SweepRes{}, errors.New("store unavailable"))
+				return sweeperMock
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			i := sweeperHandler{
+				service: tt.mock(),
+			}
+			i.ExpireReservations(w, httptest.NewRequest("POST", "http://localhost:8000/expire-reservations", nil))
+			if w.Code != tt.wantCode {
+				t.Errorf("ExpireReservations() status = %v, want %v", w.Code, tt.wantCode)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("ExpireReservations() body = %v, want it to contain %v", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
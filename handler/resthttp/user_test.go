@@ -0,0 +1,204 @@
+package resthttp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/services"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_newUserHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	userMock := NewMockUserService(ctrl)
+	if got, want := newUserHandler(userMock), (&userHandler{service: userMock}); !reflect.DeepEqual(got, want) {
+		t.Errorf("newUserHandler() = %v, want %v", got, want)
+	}
+}
+
+func Test_RegisterUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		body     string
+		mock     func() UserService
+		wantCode int
+	}{
+		{
+			name: "test normal flow",
+			body: `{"name": "Jack", "email": "jack@example.com", "phone": "+62811", "preferred_branch": "central"}`,
+			mock: func() UserService {
+				userMock := NewMockUserService(ctrl)
+				userMock.EXPECT().RegisterUser(gomock.Any(), services.RegisterUserReq{
+					Name:            "Jack",
+					Email:           "jack@example.com",
+					Phone:           "+62811",
+					PreferredBranch: "central",
+				}).Return(services.UserRes{ID: 1, Name: "Jack", Active: true}, nil)
+				return userMock
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "test bad request",
+			body: "",
+			mock: func() UserService {
+				return NewMockUserService(ctrl)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "test email taken",
+			body: `{"name": "Jack", "email": "jack@example.com"}`,
+			mock: func() UserService {
+				userMock := NewMockUserService(ctrl)
+				userMock.EXPECT().RegisterUser(gomock.Any(), gomock.Any()).Return(services.UserRes{}, &services.ServiceError{Code: services.ErrCodeConflict, Message: "Email is already registered"})
+				return userMock
+			},
+			wantCode: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			i := userHandler{
+				service: tt.mock(),
+			}
+			i.RegisterUser(w, httptest.NewRequest("POST", "http://localhost:8000/register-user", strings.NewReader(tt.body)))
+			if w.Code != tt.wantCode {
+				t.Errorf("RegisterUser() status = %v, want %v", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_GetUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		url      string
+		mock     func() UserService
+		wantCode int
+	}{
+		{
+			name: "test normal flow",
+			url:  "http://localhost:8000/get-user?id=1",
+			mock: func() UserService {
+				userMock := NewMockUserService(ctrl)
+				userMock.EXPECT().GetUser(gomock.Any(), services.GetUserReq{ID: 1}).Return(services.UserRes{ID: 1}, nil)
+				return userMock
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "test missing id",
+			url:  "http://localhost:8000/get-user",
+			mock: func() UserService {
+				return NewMockUserService(ctrl)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "test not found",
+			url:  "http://localhost:8000/get-user?id=9",
+			mock: func() UserService {
+				userMock := NewMockUserService(ctrl)
+				userMock.EXPECT().GetUser(gomock.Any(), services.GetUserReq{ID: 9}).Return(services.UserRes{}, &services.ServiceError{Code: services.ErrCodeNotFound, Message: "User not found"})
+				return userMock
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			i := userHandler{
+				service: tt.mock(),
+			}
+			i.GetUser(w, httptest.NewRequest("GET", tt.url, nil))
+			if w.Code != tt.wantCode {
+				t.Errorf("GetUser() status = %v, want %v", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_UpdateUserProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	userMock := NewMockUserService(ctrl)
+	userMock.EXPECT().UpdateUserProfile(gomock.Any(), services.UpdateUserProfileReq{ID: 1, Name: "Jack", Email: "jack@example.com"}).Return(services.UserRes{ID: 1}, nil)
+
+	w := httptest.NewRecorder()
+	i := userHandler{
+		service: userMock,
+	}
+	i.UpdateUserProfile(w, httptest.NewRequest("POST", "http://localhost:8000/update-user-profile", strings.NewReader(`{"id": 1, "name": "Jack", "email": "jack@example.com"}`)))
+	if w.Code != http.StatusOK {
+		t.Errorf("UpdateUserProfile() status = %v, want %v", w.Code, http.StatusOK)
+	}
+}
+
+func Test_DeactivateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		mock     func() UserService
+		wantCode int
+	}{
+		{
+			name: "test normal flow",
+			mock: func() UserService {
+				userMock := NewMockUserService(ctrl)
+				userMock.EXPECT().DeactivateUser(gomock.Any(), services.DeactivateUserReq{ID: 1}).Return(services.UserRes{ID: 1}, nil)
+				return userMock
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "test internal server error",
+			mock: func() UserService {
+				userMock := NewMockUserService(ctrl)
+				userMock.EXPECT().DeactivateUser(gomock.Any(), gomock.Any()).Return(services.UserRes{}, errors.New("error"))
+				return userMock
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			i := userHandler{
+				service: tt.mock(),
+			}
+			i.DeactivateUser(w, httptest.NewRequest("POST", "http://localhost:8000/deactivate-user", strings.NewReader(`{"id": 1}`)))
+			if w.Code != tt.wantCode {
+				t.Errorf("DeactivateUser() status = %v, want %v", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_SetNotifyOptOut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	userMock := NewMockUserService(ctrl)
+	userMock.EXPECT().SetNotifyOptOut(gomock.Any(), services.SetNotifyOptOutReq{ID: 1, OptOut: true}).Return(services.UserRes{ID: 1, NotifyOptOut: true}, nil)
+
+	w := httptest.NewRecorder()
+	i := userHandler{
+		service: userMock,
+	}
+	i.SetNotifyOptOut(w, httptest.NewRequest("POST", "http://localhost:8000/set-notify-opt-out", strings.NewReader(`{"id": 1, "opt_out": true}`)))
+	if w.Code != http.StatusOK {
+		t.Errorf("SetNotifyOptOut() status = %v, want %v", w.Code, http.StatusOK)
+	}
+}
@@ -0,0 +1,176 @@
+package resthttp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/services"
+)
+
+type webhookHandler struct {
+	service WebhookService
+}
+
+func newWebhookHandler(service WebhookService) *webhookHandler {
+	return &webhookHandler{
+		service: service,
+	}
+}
+
+func (p webhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	resp := newResponse(time.Now())
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+
+	reqBody := services.CreateWebhookReq{}
+	err = json.Unmarshal(body, &reqBody)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+	res, err := p.service.CreateWebhook(context.Background(), reqBody)
+	if err != nil {
+		resp.setError(err, w)
+		return
+	}
+
+	resp.setOK(map[string]interface{}{
+		"data": res,
+	}, w)
+	return
+}
+
+func (p webhookHandler) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	resp := newResponse(time.Now())
+
+	res, err := p.service.GetWebhooks(context.Background())
+	if err != nil {
+		resp.setError(err, w)
+		return
+	}
+
+	resp.setOK(map[string]interface{}{
+		"data": res,
+	}, w)
+	return
+}
+
+func (p webhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	resp := newResponse(time.Now())
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+
+	reqBody := services.UpdateWebhookReq{}
+	err = json.Unmarshal(body, &reqBody)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+	res, err := p.service.UpdateWebhook(context.Background(), reqBody)
+	if err != nil {
+		resp.setError(err, w)
+		return
+	}
+
+	resp.setOK(map[string]interface{}{
+		"data": res,
+	}, w)
+	return
+}
+
+func (p webhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	resp := newResponse(time.Now())
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+
+	reqBody := services.DeleteWebhookReq{}
+	err = json.Unmarshal(body, &reqBody)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+	err = p.service.DeleteWebhook(context.Background(), reqBody)
+	if err != nil {
+		resp.setError(err, w)
+		return
+	}
+
+	resp.setOK(map[string]interface{}{
+		"data": fmt.Sprintf("Webhook %d successfully deleted", reqBody.ID),
+	}, w)
+	return
+}
+
+func (p webhookHandler) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	resp := newResponse(time.Now())
+
+	query := r.URL.Query()
+	var subscriptionID int
+	if raw := strings.TrimSpace(query.Get("subscription_id")); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil {
+			resp.setBadRequest(InvalidRequestParam, w)
+			return
+		}
+		subscriptionID = id
+	}
+
+	res, err := p.service.GetWebhookDeliveries(context.Background(), services.GetWebhookDeliveriesReq{
+		SubscriptionID: subscriptionID,
+		Status:         strings.TrimSpace(query.Get("status")),
+	})
+	if err != nil {
+		resp.setError(err, w)
+		return
+	}
+
+	resp.setOK(map[string]interface{}{
+		"data": res,
+	}, w)
+	return
+}
+
+func (p webhookHandler) ReplayWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	resp := newResponse(time.Now())
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+
+	reqBody := services.ReplayWebhookDeliveryReq{}
+	err = json.Unmarshal(body, &reqBody)
+	if err != nil {
+		resp.setBadRequest(err.Error(), w)
+		return
+	}
+	res, err := p.service.ReplayWebhookDelivery(context.Background(), reqBody)
+	if err != nil {
+		resp.setError(err, w)
+		return
+	}
+
+	resp.setOK(map[string]interface{}{
+		"data": res,
+	}, w)
+	return
+}
@@ -0,0 +1,134 @@
+package resthttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/services"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_newWebhookHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	webhookMock := NewMockWebhookService(ctrl)
+	if got, want := newWebhookHandler(webhookMock), (&webhookHandler{service: webhookMock}); !reflect.DeepEqual(got, want) {
+		t.Errorf("newWebhookHandler() = %v, want %v", got, want)
+	}
+}
+
+func Test_CreateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		body     string
+		mock     func() WebhookService
+		wantCode int
+	}{
+		{
+			name: "test normal flow",
+			body: `{"url": "https://kiosk.example.com/hook", "events": ["reservation.created"]}`,
+			mock: func() WebhookService {
+				webhookMock := NewMockWebhookService(ctrl)
+				webhookMock.EXPECT().CreateWebhook(gomock.Any(), services.CreateWebhookReq{
+					URL:    "https://kiosk.example.com/hook",
+					Events: []string{"reservation.created"},
+				}).Return(services.WebhookRes{ID: 1, Secret: "whsec_1"}, nil)
+				return webhookMock
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "test bad request",
+			body: "",
+			mock: func() WebhookService {
+				return NewMockWebhookService(ctrl)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "test invalid url",
+			body: `{"url": "/hook"}`,
+			mock: func() WebhookService {
+				webhookMock := NewMockWebhookService(ctrl)
+				webhookMock.EXPECT().CreateWebhook(gomock.Any(), gomock.Any()).Return(services.WebhookRes{}, &services.ServiceError{Code: services.ErrCodeInvalidRequest, Message: "URL must be an absolute http or https URL"})
+				return webhookMock
+			},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			i := webhookHandler{
+				service: tt.mock(),
+			}
+			i.CreateWebhook(w, httptest.NewRequest("POST", "http://localhost:8000/create-webhook", strings.NewReader(tt.body)))
+			if w.Code != tt.wantCode {
+				t.Errorf("CreateWebhook() status = %v, want %v", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_GetWebhookDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		url      string
+		mock     func() WebhookService
+		wantCode int
+	}{
+		{
+			name: "test normal flow",
+			url:  "http://localhost:8000/get-webhook-deliveries?subscription_id=2&status=failed",
+			mock: func() WebhookService {
+				webhookMock := NewMockWebhookService(ctrl)
+				webhookMock.EXPECT().GetWebhookDeliveries(gomock.Any(), services.GetWebhookDeliveriesReq{SubscriptionID: 2, Status: "failed"}).Return([]services.WebhookDeliveryRes{}, nil)
+				return webhookMock
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "test invalid subscription id",
+			url:  "http://localhost:8000/get-webhook-deliveries?subscription_id=kiosk",
+			mock: func() WebhookService {
+				return NewMockWebhookService(ctrl)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			i := webhookHandler{
+				service: tt.mock(),
+			}
+			i.GetWebhookDeliveries(w, httptest.NewRequest("GET", tt.url, nil))
+			if w.Code != tt.wantCode {
+				t.Errorf("GetWebhookDeliveries() status = %v, want %v", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_ReplayWebhookDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	webhookMock := NewMockWebhookService(ctrl)
+	webhookMock.EXPECT().ReplayWebhookDelivery(gomock.Any(), services.ReplayWebhookDeliveryReq{ID: 7}).Return(services.WebhookDeliveryRes{}, &services.ServiceError{Code: services.ErrCodeConflict, Message: "Webhook subscription is disabled"})
+
+	w := httptest.NewRecorder()
+	i := webhookHandler{
+		service: webhookMock,
+	}
+	i.ReplayWebhookDelivery(w, httptest.NewRequest("POST", "http://localhost:8000/replay-webhook-delivery", strings.NewReader(`{"id": 7}`)))
+	if w.Code != http.StatusConflict {
+		t.Errorf("ReplayWebhookDelivery() status = %v, want %v", w.Code, http.StatusConflict)
+	}
+}
@@ -2,6 +2,7 @@ package book
 
 import (
 	"context"
+	"fmt"
 
 	"gihub.com/gadhittana01/book-project/config"
 	"gihub.com/gadhittana01/book-project/pkg/domain"
@@ -9,9 +10,33 @@ import (
 
 type IResource interface {
 	GetListOfBooks(ctx context.Context, req domain.GetListOfBooksReq) (domain.GetListOfBooksResp, error)
-	BorrowBook(ctx context.Context, req domain.BorrowBookReq) error
+	BorrowBook(ctx context.Context, req domain.BorrowBookReq) (domain.BorrowBookReq, error)
 	GetBookByKey(ctx context.Context, req domain.GeBookByKeyReq) (domain.Book, error)
 	GetBookReservation(ctx context.Context, req domain.GetBookReservationReq) (map[int][]domain.BorrowBookReq, error)
+	GetReservationPage(ctx context.Context, req domain.GetReservationPageReq) ([]domain.BorrowBookReq, error)
+	BorrowBooks(ctx context.Context, req []domain.BorrowBookReq) ([]domain.BorrowBookReq, error)
+	GetCatalogHealth(ctx context.Context) ([]domain.ProviderHealth, error)
+	JoinWaitlist(ctx context.Context, req domain.JoinWaitlistReq) (int, error)
+	CancelReservation(ctx context.Context, req domain.CancelReservationReq) (domain.BorrowBookReq, error)
+	ConfirmReservation(ctx context.Context, req domain.ConfirmReservationReq) error
+	CheckoutBook(ctx context.Context, req domain.CheckoutBookReq) (domain.Loan, error)
+	ReturnBook(ctx context.Context, req domain.ReturnBookReq) (domain.Loan, error)
+	RenewLoan(ctx context.Context, req domain.RenewLoanReq) (domain.Loan, error)
+	GetLoans(ctx context.Context, req domain.GetLoansReq) (map[int][]domain.Loan, error)
+	GetFines(ctx context.Context, req domain.GetFinesReq) (domain.FineLedger, error)
+	SettleFines(ctx context.Context, req domain.SettleFinesReq) (domain.FineLedger, error)
+	WaiveFine(ctx context.Context, req domain.WaiveFineReq) (domain.FineLedger, error)
+	GetBranches(ctx context.Context) ([]domain.Branch, error)
+	GetAvailability(ctx context.Context, req domain.GetAvailabilityReq) ([]domain.BranchAvailability, error)
+	GetPickupSlots(ctx context.Context, req domain.GetPickupSlotsReq) ([]domain.PickupSlot, error)
+	AcquireLease(ctx context.Context, req domain.AcquireLeaseReq) (bool, error)
+	ExpireUncollected(ctx context.Context, req domain.ExpireUncollectedReq) ([]domain.BorrowBookReq, error)
+	ClaimReminders(ctx context.Context, req domain.ClaimRemindersReq) ([]domain.BorrowBookReq, error)
+	ReleaseReminder(ctx context.Context, req domain.ReleaseReminderReq) error
+	ClaimOutbox(ctx context.Context, req domain.ClaimOutboxReq) ([]domain.OutboxEvent, error)
+	AckOutbox(ctx context.Context, req domain.AckOutboxReq) error
+	GetOutbox(ctx context.Context, req domain.GetOutboxReq) ([]domain.OutboxEvent, error)
+	RequeueOutbox(ctx context.Context, req domain.RequeueOutboxReq) (domain.OutboxEvent, error)
 }
 
 type module struct {
@@ -19,18 +44,35 @@ type module struct {
 	persistent persistent
 }
 
-func New(cfg *config.GlobalConfig, httpclient HttpResource) IResource {
-	return &module{
-		external:   newExternal(cfg, httpclient),
-		persistent: newPersistent(),
+func New(cfg *config.GlobalConfig, httpclient HttpResource) (IResource, error) {
+	var (
+		ext external
+		err error
+	)
+
+	switch cfg.BookService.Mode {
+	case "", ModeRemote:
+		ext, err = newExternal(cfg, httpclient)
+	case ModeLocal:
+		ext, err = newLocalExternal(cfg)
+	default:
+		err = fmt.Errorf("unknown bookservice mode %q", cfg.BookService.Mode)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &module{
+		external:   ext,
+		persistent: newPersistent(cfg),
+	}, nil
 }
 
 func (m module) GetListOfBooks(ctx context.Context, req domain.GetListOfBooksReq) (domain.GetListOfBooksResp, error) {
 	return m.external.getListOfBooks(ctx, req)
 }
 
-func (m module) BorrowBook(ctx context.Context, req domain.BorrowBookReq) error {
+func (m module) BorrowBook(ctx context.Context, req domain.BorrowBookReq) (domain.BorrowBookReq, error) {
 	return m.persistent.borrowBook(ctx, req)
 }
 
@@ -41,3 +83,99 @@ func (m module) GetBookByKey(ctx context.Context, req domain.GeBookByKeyReq) (do
 func (m module) GetBookReservation(ctx context.Context, req domain.GetBookReservationReq) (map[int][]domain.BorrowBookReq, error) {
 	return m.persistent.getBookReservation(ctx, req)
 }
+
+func (m module) GetReservationPage(ctx context.Context, req domain.GetReservationPageReq) ([]domain.BorrowBookReq, error) {
+	return m.persistent.getReservationPage(ctx, req)
+}
+
+func (m module) BorrowBooks(ctx context.Context, req []domain.BorrowBookReq) ([]domain.BorrowBookReq, error) {
+	return m.persistent.borrowBooks(ctx, req)
+}
+
+func (m module) GetCatalogHealth(ctx context.Context) ([]domain.ProviderHealth, error) {
+	return m.external.getCatalogHealth(ctx)
+}
+
+func (m module) JoinWaitlist(ctx context.Context, req domain.JoinWaitlistReq) (int, error) {
+	return m.persistent.joinWaitlist(ctx, req)
+}
+
+func (m module) CancelReservation(ctx context.Context, req domain.CancelReservationReq) (domain.BorrowBookReq, error) {
+	return m.persistent.cancelReservation(ctx, req)
+}
+
+func (m module) ConfirmReservation(ctx context.Context, req domain.ConfirmReservationReq) error {
+	return m.persistent.confirmReservation(ctx, req)
+}
+
+func (m module) CheckoutBook(ctx context.Context, req domain.CheckoutBookReq) (domain.Loan, error) {
+	return m.persistent.checkoutBook(ctx, req)
+}
+
+func (m module) ReturnBook(ctx context.Context, req domain.ReturnBookReq) (domain.Loan, error) {
+	return m.persistent.returnBook(ctx, req)
+}
+
+func (m module) RenewLoan(ctx context.Context, req domain.RenewLoanReq) (domain.Loan, error) {
+	return m.persistent.renewLoan(ctx, req)
+}
+
+func (m module) GetLoans(ctx context.Context, req domain.GetLoansReq) (map[int][]domain.Loan, error) {
+	return m.persistent.getLoans(ctx, req)
+}
+
+func (m module) GetFines(ctx context.Context, req domain.GetFinesReq) (domain.FineLedger, error) {
+	return m.persistent.getFines(ctx, req)
+}
+
+func (m module) SettleFines(ctx context.Context, req domain.SettleFinesReq) (domain.FineLedger, error) {
+	return m.persistent.settleFines(ctx, req)
+}
+
+func (m module) WaiveFine(ctx context.Context, req domain.WaiveFineReq) (domain.FineLedger, error) {
+	return m.persistent.waiveFine(ctx, req)
+}
+
+func (m module) GetBranches(ctx context.Context) ([]domain.Branch, error) {
+	return m.persistent.getBranches(ctx)
+}
+
+func (m module) GetAvailability(ctx context.Context, req domain.GetAvailabilityReq) ([]domain.BranchAvailability, error) {
+	return m.persistent.getAvailability(ctx, req)
+}
+
+func (m module) GetPickupSlots(ctx context.Context, req domain.GetPickupSlotsReq) ([]domain.PickupSlot, error) {
+	return m.persistent.getPickupSlots(ctx, req)
+}
+
+func (m module) AcquireLease(ctx context.Context, req domain.AcquireLeaseReq) (bool, error) {
+	return m.persistent.acquireLease(ctx, req)
+}
+
+func (m module) ExpireUncollected(ctx context.Context, req domain.ExpireUncollectedReq) ([]domain.BorrowBookReq, error) {
+	return m.persistent.expireUncollected(ctx, req)
+}
+
+func (m module) ClaimReminders(ctx context.Context, req domain.ClaimRemindersReq) ([]domain.BorrowBookReq, error) {
+	return m.persistent.claimReminders(ctx, req)
+}
+
+func (m module) ReleaseReminder(ctx context.Context, req domain.ReleaseReminderReq) error {
+	return m.persistent.releaseReminder(ctx, req)
+}
+
+func (m module) ClaimOutbox(ctx context.Context, req domain.ClaimOutboxReq) ([]domain.OutboxEvent, error) {
+	return m.persistent.claimOutbox(ctx, req)
+}
+
+func (m module) AckOutbox(ctx context.Context, req domain.AckOutboxReq) error {
+	return m.persistent.ackOutbox(ctx, req)
+}
+
+func (m module) GetOutbox(ctx context.Context, req domain.GetOutboxReq) ([]domain.OutboxEvent, error) {
+	return m.persistent.getOutbox(ctx, req)
+}
+
+func (m module) RequeueOutbox(ctx context.Context, req domain.RequeueOutboxReq) (domain.OutboxEvent, error) {
+	return m.persistent.requeueOutbox(ctx, req)
+}
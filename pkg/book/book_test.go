@@ -23,7 +23,7 @@ func TestNew(t *testing.T) {
 	tests := []struct {
 		name    string
 		args    args
-		want    *module
+		want    IResource
 		wantErr bool
 	}{
 		{
@@ -33,18 +33,68 @@ func TestNew(t *testing.T) {
 				httpclient: httpMock,
 			},
 			want: &module{
-				external: newExternal(
-					&cfg,
-					httpMock,
-				),
-				persistent: newPersistent(),
+				external: &externalModule{
+					cfg: &cfg,
+					catalog: &catalogChain{
+						links: []*catalogLink{
+							{
+								provider: newOpenLibraryProvider("", httpMock),
+							},
+						},
+					},
+				},
+				persistent: newPersistent(&cfg),
 			},
 			wantErr: false,
 		},
+		{
+			name: "unknown provider",
+			args: args{
+				cfg: &config.GlobalConfig{
+					BookService: config.BookService{
+						Provider: "unknown",
+					},
+				},
+				httpclient: httpMock,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "unknown mode",
+			args: args{
+				cfg: &config.GlobalConfig{
+					BookService: config.BookService{
+						Mode: "unknown",
+					},
+				},
+				httpclient: httpMock,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "local mode without catalog",
+			args: args{
+				cfg: &config.GlobalConfig{
+					BookService: config.BookService{
+						Mode: ModeLocal,
+					},
+				},
+				httpclient: httpMock,
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := New(tt.args.cfg, tt.args.httpclient); !reflect.DeepEqual(got, tt.want) {
+			got, err := New(tt.args.cfg, tt.args.httpclient)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("New() = %v, want %v", got, tt.want)
 			}
 		})
@@ -183,7 +233,7 @@ func Test_BorrowBook(t *testing.T) {
 					},
 					PickUpDate: "2022-01-01",
 					UserID:     1,
-				}).Return(nil)
+				}).Return(domain.BorrowBookReq{ID: 1}, nil)
 
 				return &module{
 					external:   extMock,
@@ -196,7 +246,7 @@ func Test_BorrowBook(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			m := tt.mock()
-			err := m.BorrowBook(tt.args.ctx, tt.args.req)
+			_, err := m.BorrowBook(tt.args.ctx, tt.args.req)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("BorrowBook() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -452,3 +502,157 @@ func Test_GetBookReservation(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetCatalogHealth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		mock    func() *module
+		args    args
+		want    []domain.ProviderHealth
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx: context.Background(),
+			},
+			mock: func() *module {
+				extMock := NewMockexternal(ctrl)
+				pstMock := NewMockpersistent(ctrl)
+
+				extMock.EXPECT().getCatalogHealth(gomock.Any()).Return([]domain.ProviderHealth{
+					{
+						Name:    "openlibrary",
+						Healthy: true,
+					},
+				}, nil)
+
+				return &module{
+					external:   extMock,
+					persistent: pstMock,
+				}
+			},
+			want: []domain.ProviderHealth{
+				{
+					Name:    "openlibrary",
+					Healthy: true,
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.mock()
+			got, err := m.GetCatalogHealth(tt.args.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetCatalogHealth() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetCatalogHealth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_JoinWaitlist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	type args struct {
+		ctx context.Context
+		req domain.JoinWaitlistReq
+	}
+	tests := []struct {
+		name    string
+		mock    func() *module
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx: context.Background(),
+				req: domain.JoinWaitlistReq{
+					Book:       domain.Book{Key: "123"},
+					PickUpDate: "2022-01-01",
+					UserID:     1,
+				},
+			},
+			mock: func() *module {
+				pstMock := NewMockpersistent(ctrl)
+				pstMock.EXPECT().joinWaitlist(gomock.Any(), domain.JoinWaitlistReq{
+					Book:       domain.Book{Key: "123"},
+					PickUpDate: "2022-01-01",
+					UserID:     1,
+				}).Return(2, nil)
+
+				return &module{
+					external:   NewMockexternal(ctrl),
+					persistent: pstMock,
+				}
+			},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name: "error",
+			args: args{
+				ctx: context.Background(),
+				req: domain.JoinWaitlistReq{
+					Book:   domain.Book{Key: "123"},
+					UserID: 1,
+				},
+			},
+			mock: func() *module {
+				pstMock := NewMockpersistent(ctrl)
+				pstMock.EXPECT().joinWaitlist(gomock.Any(), gomock.Any()).Return(0, domain.ErrBookAvailable)
+
+				return &module{
+					external:   NewMockexternal(ctrl),
+					persistent: pstMock,
+				}
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.mock()
+			got, err := m.JoinWaitlist(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("JoinWaitlist() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("JoinWaitlist() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_CancelReservation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	pstMock := NewMockpersistent(ctrl)
+	pstMock.EXPECT().cancelReservation(gomock.Any(), domain.CancelReservationReq{ID: 1, UserID: 2}).Return(domain.BorrowBookReq{ID: 1}, nil)
+	pstMock.EXPECT().confirmReservation(gomock.Any(), domain.ConfirmReservationReq{ID: 3, UserID: 2}).Return(domain.ErrConfirmWindowPassed)
+
+	m := &module{
+		external:   NewMockexternal(ctrl),
+		persistent: pstMock,
+	}
+	if _, err := m.CancelReservation(context.Background(), domain.CancelReservationReq{ID: 1, UserID: 2}); err != nil {
+		t.Errorf("CancelReservation() error = %v", err)
+	}
+	if err := m.ConfirmReservation(context.Background(), domain.ConfirmReservationReq{ID: 3, UserID: 2}); err != domain.ErrConfirmWindowPassed {
+		t.Errorf("ConfirmReservation() error = %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+package book
+
+import (
+	"context"
+	"fmt"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+// CatalogProvider looks up works in an upstream catalog and maps them into domain.Book.
+type CatalogProvider interface {
+	Name() string
+	GetListOfBooks(ctx context.Context, req domain.GetListOfBooksReq) (domain.GetListOfBooksResp, error)
+}
+
+const (
+	ProviderOpenLibrary = "openlibrary"
+	ProviderGoogleBooks = "googlebooks"
+)
+
+// NewCatalogProvider returns the provider registered under name, defaulting to Open Library.
+func NewCatalogProvider(name, address string, httpclient HttpResource) (CatalogProvider, error) {
+	switch name {
+	case "", ProviderOpenLibrary:
+		return newOpenLibraryProvider(address, httpclient), nil
+	case ProviderGoogleBooks:
+		return newGoogleBooksProvider(address, httpclient), nil
+	}
+	return nil, fmt.Errorf("unknown catalog provider %q", name)
+}
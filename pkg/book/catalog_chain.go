@@ -0,0 +1,130 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+// catalogChain asks each provider in order and returns the first successful answer.
+type catalogChain struct {
+	links []*catalogLink
+}
+
+type catalogLink struct {
+	provider CatalogProvider
+	timeout  time.Duration
+	health   providerHealth
+}
+
+type providerHealth struct {
+	mu                  sync.Mutex
+	consecutiveFailures int
+	lastError           string
+	lastCheckedAt       time.Time
+}
+
+func newCatalogChain(cfg *config.GlobalConfig, httpclient HttpResource) (*catalogChain, error) {
+	providers := cfg.BookService.Providers
+	if len(providers) > 0 && (cfg.BookService.Address != "" || cfg.BookService.Provider != "") {
+		return nil, errors.New("bookservice.providers cannot be combined with bookservice.address or bookservice.provider")
+	}
+	if len(providers) == 0 {
+		providers = []config.CatalogProviderConfig{
+			{
+				Name:    cfg.BookService.Provider,
+				Address: cfg.BookService.Address,
+			},
+		}
+	}
+
+	chain := &catalogChain{}
+	for _, item := range providers {
+		provider, err := NewCatalogProvider(item.Name, item.Address, httpclient)
+		if err != nil {
+			return nil, err
+		}
+		chain.links = append(chain.links, &catalogLink{
+			provider: provider,
+			timeout:  time.Duration(item.TimeoutMS) * time.Millisecond,
+		})
+	}
+
+	return chain, nil
+}
+
+func (c *catalogChain) GetListOfBooks(ctx context.Context, req domain.GetListOfBooksReq) (domain.GetListOfBooksResp, error) {
+	if len(c.links) == 0 {
+		return domain.GetListOfBooksResp{}, errors.New("no catalog provider configured")
+	}
+
+	var lastErr error
+	for _, link := range c.links {
+		if ctx.Err() != nil {
+			return domain.GetListOfBooksResp{}, ctx.Err()
+		}
+
+		res, err := link.getListOfBooks(ctx, req)
+		if err != nil {
+			lastErr = fmt.Errorf("%s: %w", link.provider.Name(), err)
+			continue
+		}
+
+		res.Provider = link.provider.Name()
+		return res, nil
+	}
+
+	return domain.GetListOfBooksResp{}, fmt.Errorf("all catalog providers failed, last error %w", lastErr)
+}
+
+func (c *catalogChain) Health() []domain.ProviderHealth {
+	res := []domain.ProviderHealth{}
+	for _, link := range c.links {
+		res = append(res, link.health.snapshot(link.provider.Name()))
+	}
+	return res
+}
+
+func (l *catalogLink) getListOfBooks(ctx context.Context, req domain.GetListOfBooksReq) (domain.GetListOfBooksResp, error) {
+	if l.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.timeout)
+		defer cancel()
+	}
+
+	res, err := l.provider.GetListOfBooks(ctx, req)
+	l.health.record(err)
+	return res, err
+}
+
+func (h *providerHealth) record(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.lastCheckedAt = time.Now()
+	if err != nil {
+		h.consecutiveFailures++
+		h.lastError = err.Error()
+		return
+	}
+	h.consecutiveFailures = 0
+	h.lastError = ""
+}
+
+func (h *providerHealth) snapshot(name string) domain.ProviderHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return domain.ProviderHealth{
+		Name:                name,
+		Healthy:             h.consecutiveFailures == 0,
+		ConsecutiveFailures: h.consecutiveFailures,
+		LastError:           h.lastError,
+		LastCheckedAt:       h.lastCheckedAt,
+	}
+}
@@ -0,0 +1,202 @@
+package book
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+func Test_newCatalogChain(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *config.GlobalConfig
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "single provider from address",
+			cfg: &config.GlobalConfig{
+				BookService: config.BookService{
+					Address: "https://openlibrary.org",
+				},
+			},
+			want: []string{ProviderOpenLibrary},
+		},
+		{
+			name: "ordered providers",
+			cfg: &config.GlobalConfig{
+				BookService: config.BookService{
+					Providers: []config.CatalogProviderConfig{
+						{Name: ProviderGoogleBooks, TimeoutMS: 100},
+						{Name: ProviderOpenLibrary, TimeoutMS: 200},
+					},
+				},
+			},
+			want: []string{ProviderGoogleBooks, ProviderOpenLibrary},
+		},
+		{
+			name: "providers combined with address",
+			cfg: &config.GlobalConfig{
+				BookService: config.BookService{
+					Address: "https://openlibrary.org",
+					Providers: []config.CatalogProviderConfig{
+						{Name: ProviderOpenLibrary},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown provider",
+			cfg: &config.GlobalConfig{
+				BookService: config.BookService{
+					Providers: []config.CatalogProviderConfig{
+						{Name: "unknown"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newCatalogChain(tt.cfg, http.DefaultClient)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newCatalogChain() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if len(got.links) != len(tt.want) {
+				t.Fatalf("newCatalogChain() links = %d, want %d", len(got.links), len(tt.want))
+			}
+			for i, name := range tt.want {
+				if got.links[i].provider.Name() != name {
+					t.Errorf("newCatalogChain() link %d = %v, want %v", i, got.links[i].provider.Name(), name)
+				}
+			}
+		})
+	}
+}
+
+func Test_catalogChainGetListOfBooks(t *testing.T) {
+	fixtures := newCatalogFixtureServer(t)
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer slow.Close()
+
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	tests := []struct {
+		name         string
+		links        []*catalogLink
+		wantProvider string
+		wantHealthy  []bool
+		wantErr      bool
+	}{
+		{
+			name: "first provider answers",
+			links: []*catalogLink{
+				{provider: newOpenLibraryProvider(fixtures.URL, fixtures.Client())},
+				{provider: newGoogleBooksProvider(fixtures.URL, fixtures.Client())},
+			},
+			wantProvider: ProviderOpenLibrary,
+			wantHealthy:  []bool{true, true},
+		},
+		{
+			name: "fall through on error",
+			links: []*catalogLink{
+				{provider: newOpenLibraryProvider(down.URL, down.Client())},
+				{provider: newGoogleBooksProvider(fixtures.URL, fixtures.Client())},
+			},
+			wantProvider: ProviderGoogleBooks,
+			wantHealthy:  []bool{false, true},
+		},
+		{
+			name: "fall through on timeout",
+			links: []*catalogLink{
+				{provider: newOpenLibraryProvider(slow.URL, slow.Client()), timeout: 20 * time.Millisecond},
+				{provider: newGoogleBooksProvider(fixtures.URL, fixtures.Client())},
+			},
+			wantProvider: ProviderGoogleBooks,
+			wantHealthy:  []bool{false, true},
+		},
+		{
+			name: "all providers failed",
+			links: []*catalogLink{
+				{provider: newOpenLibraryProvider(down.URL, down.Client())},
+				{provider: newGoogleBooksProvider(down.URL, down.Client())},
+			},
+			wantHealthy: []bool{false, false},
+			wantErr:     true,
+		},
+		{
+			name:        "no provider",
+			links:       nil,
+			wantHealthy: []bool{},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &catalogChain{links: tt.links}
+			got, err := c.GetListOfBooks(context.Background(), domain.GetListOfBooksReq{
+				Subject: "love",
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetListOfBooks() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Provider != tt.wantProvider {
+				t.Errorf("GetListOfBooks() provider = %v, want %v", got.Provider, tt.wantProvider)
+			}
+			if !tt.wantErr && len(got.Books) == 0 {
+				t.Errorf("GetListOfBooks() returned no books")
+			}
+
+			health := c.Health()
+			if len(health) != len(tt.wantHealthy) {
+				t.Fatalf("Health() = %d entries, want %d", len(health), len(tt.wantHealthy))
+			}
+			for i, healthy := range tt.wantHealthy {
+				if health[i].Healthy != healthy {
+					t.Errorf("Health()[%d].Healthy = %v, want %v", i, health[i].Healthy, healthy)
+				}
+				if !healthy && health[i].LastError == "" {
+					t.Errorf("Health()[%d].LastError is empty", i)
+				}
+			}
+		})
+	}
+}
+
+func Test_providerHealthRecovers(t *testing.T) {
+	h := providerHealth{}
+	h.record(context.DeadlineExceeded)
+	h.record(context.DeadlineExceeded)
+
+	got := h.snapshot(ProviderOpenLibrary)
+	if got.Healthy || got.ConsecutiveFailures != 2 {
+		t.Errorf("snapshot() = %+v, want 2 consecutive failures", got)
+	}
+
+	h.record(nil)
+	got = h.snapshot(ProviderOpenLibrary)
+	if !got.Healthy || got.ConsecutiveFailures != 0 || got.LastError != "" {
+		t.Errorf("snapshot() = %+v, want healthy", got)
+	}
+}
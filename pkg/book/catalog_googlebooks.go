@@ -0,0 +1,97 @@
+package book
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+type googleBooksProvider struct {
+	address    string
+	httpclient HttpResource
+}
+
+func newGoogleBooksProvider(address string, httpclient HttpResource) CatalogProvider {
+	return &googleBooksProvider{
+		address:    address,
+		httpclient: httpclient,
+	}
+}
+
+const (
+	PathGoogleBooksVolumes = "/books/v1/volumes"
+	googleBooksMaxResults  = "40"
+)
+
+type googleBooksVolumesResp struct {
+	Items []googleBooksVolume `json:"items"`
+}
+
+type googleBooksVolume struct {
+	ID         string `json:"id"`
+	VolumeInfo struct {
+		Title   string   `json:"title"`
+		Authors []string `json:"authors"`
+	} `json:"volumeInfo"`
+}
+
+func (p *googleBooksProvider) Name() string {
+	return ProviderGoogleBooks
+}
+
+func (p *googleBooksProvider) GetListOfBooks(ctx context.Context, req domain.GetListOfBooksReq) (domain.GetListOfBooksResp, error) {
+	res := domain.GetListOfBooksResp{}
+
+	query := url.Values{}
+	query.Set("q", "subject:"+req.Subject)
+	query.Set("maxResults", googleBooksMaxResults)
+	URL := p.address + PathGoogleBooksVolumes + "?" + query.Encode()
+
+	reqHttp, err := http.NewRequestWithContext(ctx, http.MethodGet, URL, nil)
+	if err != nil {
+		return res, err
+	}
+
+	resHttp, err := p.httpclient.Do(reqHttp)
+	if err != nil {
+		return res, err
+	}
+
+	defer resHttp.Body.Close()
+
+	if resHttp.StatusCode != 200 {
+		return res, errors.New("error external call")
+	}
+
+	resBody, err := ioutil.ReadAll(resHttp.Body)
+	if err != nil {
+		return res, err
+	}
+
+	volumes := googleBooksVolumesResp{}
+	if err = json.Unmarshal(resBody, &volumes); err != nil {
+		return res, err
+	}
+
+	for _, item := range volumes.Items {
+		authors := []domain.Author{}
+		for _, name := range item.VolumeInfo.Authors {
+			authors = append(authors, domain.Author{
+				Name: name,
+			})
+		}
+		res.Books = append(res.Books, domain.Book{
+			Key:          item.ID,
+			Title:        item.VolumeInfo.Title,
+			EditionCount: 1,
+			Authors:      authors,
+		})
+	}
+
+	return res, nil
+}
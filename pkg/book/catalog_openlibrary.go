@@ -0,0 +1,64 @@
+package book
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+type openLibraryProvider struct {
+	address    string
+	httpclient HttpResource
+}
+
+func newOpenLibraryProvider(address string, httpclient HttpResource) CatalogProvider {
+	return &openLibraryProvider{
+		address:    address,
+		httpclient: httpclient,
+	}
+}
+
+const (
+	PathGetListOfBooks = "/subjects"
+)
+
+func (p *openLibraryProvider) Name() string {
+	return ProviderOpenLibrary
+}
+
+func (p *openLibraryProvider) GetListOfBooks(ctx context.Context, req domain.GetListOfBooksReq) (domain.GetListOfBooksResp, error) {
+	res := domain.GetListOfBooksResp{}
+
+	URL := p.address + PathGetListOfBooks + "/" + req.Subject + ".json"
+
+	reqHttp, err := http.NewRequestWithContext(ctx, http.MethodGet, URL, nil)
+	if err != nil {
+		return res, err
+	}
+
+	resHttp, err := p.httpclient.Do(reqHttp)
+	if err != nil {
+		return res, err
+	}
+
+	defer resHttp.Body.Close()
+
+	if resHttp.StatusCode != 200 {
+		return res, errors.New("error external call")
+	}
+
+	resBody, err := ioutil.ReadAll(resHttp.Body)
+	if err != nil {
+		return res, err
+	}
+
+	if err = json.Unmarshal(resBody, &res); err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
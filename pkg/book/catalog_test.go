@@ -0,0 +1,204 @@
+package book
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	reflect "reflect"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+func newCatalogFixtureServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/subjects/love.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"key": "/subjects/love",
+			"name": "love",
+			"subject_type": "subject",
+			"work_count": 1,
+			"works": [
+				{
+					"key": "/works/OL1908641W",
+					"title": "Know Nothing",
+					"edition_count": 6,
+					"lending_identifier": "knownothingnovel00sett",
+					"authors": [
+						{
+							"key": "/authors/OL228578A",
+							"name": "Mary Lee Settle"
+						}
+					]
+				}
+			]
+		}`))
+	})
+	mux.HandleFunc("/books/v1/volumes", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("q") != "subject:love" {
+			w.Write([]byte(`{"kind": "books#volumes", "totalItems": 0}`))
+			return
+		}
+		w.Write([]byte(`{
+			"kind": "books#volumes",
+			"totalItems": 1,
+			"items": [
+				{
+					"id": "zyTCAlFPjgYC",
+					"volumeInfo": {
+						"title": "The Google Story",
+						"authors": ["David A. Vise", "Mark Malseed"]
+					}
+				}
+			]
+		}`))
+	})
+	mux.HandleFunc("/subjects/broken.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	mux.HandleFunc("/subjects/garbage.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`not json`))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func Test_NewCatalogProvider(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "default to open library",
+			provider: "",
+			want:     ProviderOpenLibrary,
+		},
+		{
+			name:     "open library",
+			provider: ProviderOpenLibrary,
+			want:     ProviderOpenLibrary,
+		},
+		{
+			name:     "google books",
+			provider: ProviderGoogleBooks,
+			want:     ProviderGoogleBooks,
+		},
+		{
+			name:     "unknown provider",
+			provider: "unknown",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewCatalogProvider(tt.provider, "", http.DefaultClient)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewCatalogProvider() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.Name() != tt.want {
+				t.Errorf("NewCatalogProvider() = %v, want %v", got.Name(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_catalogProviderGetListOfBooks(t *testing.T) {
+	srv := newCatalogFixtureServer(t)
+
+	tests := []struct {
+		name     string
+		provider CatalogProvider
+		subject  string
+		want     domain.GetListOfBooksResp
+		wantErr  bool
+	}{
+		{
+			name:     "open library success",
+			provider: newOpenLibraryProvider(srv.URL, srv.Client()),
+			subject:  "love",
+			want: domain.GetListOfBooksResp{
+				Books: []domain.Book{
+					{
+						Key:          "/works/OL1908641W",
+						Title:        "Know Nothing",
+						EditionCount: 6,
+						Authors: []domain.Author{
+							{
+								Name: "Mary Lee Settle",
+							},
+						},
+						LendingIdentifier: "knownothingnovel00sett",
+					},
+				},
+			},
+		},
+		{
+			name:     "open library error status code",
+			provider: newOpenLibraryProvider(srv.URL, srv.Client()),
+			subject:  "broken",
+			want:     domain.GetListOfBooksResp{},
+			wantErr:  true,
+		},
+		{
+			name:     "open library invalid body",
+			provider: newOpenLibraryProvider(srv.URL, srv.Client()),
+			subject:  "garbage",
+			want:     domain.GetListOfBooksResp{},
+			wantErr:  true,
+		},
+		{
+			name:     "google books success",
+			provider: newGoogleBooksProvider(srv.URL, srv.Client()),
+			subject:  "love",
+			want: domain.GetListOfBooksResp{
+				Books: []domain.Book{
+					{
+						Key:          "zyTCAlFPjgYC",
+						Title:        "The Google Story",
+						EditionCount: 1,
+						Authors: []domain.Author{
+							{
+								Name: "David A. Vise",
+							},
+							{
+								Name: "Mark Malseed",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "google books no items",
+			provider: newGoogleBooksProvider(srv.URL, srv.Client()),
+			subject:  "nothing",
+			want:     domain.GetListOfBooksResp{},
+		},
+		{
+			name:     "google books unreachable",
+			provider: newGoogleBooksProvider("http://127.0.0.1:0", srv.Client()),
+			subject:  "love",
+			want:     domain.GetListOfBooksResp{},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.provider.GetListOfBooks(context.Background(), domain.GetListOfBooksReq{
+				Subject: tt.subject,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetListOfBooks() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetListOfBooks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
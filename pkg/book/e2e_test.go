@@ -0,0 +1,114 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+	"gihub.com/gadhittana01/book-project/pkg/fakeopenlibrary"
+	httpclient "gihub.com/gadhittana01/book-project/pkg/http_client"
+)
+
+func newFakeOpenLibrary(t *testing.T, opts fakeopenlibrary.Options) *httptest.Server {
+	fake, err := fakeopenlibrary.New(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func Test_e2eFakeOpenLibrary(t *testing.T) {
+	srv := newFakeOpenLibrary(t, fakeopenlibrary.Options{})
+
+	m, err := New(&config.GlobalConfig{
+		BookService: config.BookService{
+			Address: srv.URL,
+		},
+	}, srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := m.GetListOfBooks(context.Background(), domain.GetListOfBooksReq{
+		Subject: "love",
+	})
+	if err != nil || len(list.Books) != 3 || list.Provider != ProviderOpenLibrary {
+		t.Fatalf("GetListOfBooks() = %+v, %v", list, err)
+	}
+
+	book, err := m.GetBookByKey(context.Background(), domain.GeBookByKeyReq{
+		Key:     "/works/OL98501W",
+		Subject: "love",
+	})
+	if err != nil || book.Title != "Pride and Prejudice" {
+		t.Fatalf("GetBookByKey() = %+v, %v", book, err)
+	}
+}
+
+func Test_e2eFakeOpenLibraryFailover(t *testing.T) {
+	slow := newFakeOpenLibrary(t, fakeopenlibrary.Options{Latency: time.Second})
+	healthy := newFakeOpenLibrary(t, fakeopenlibrary.Options{})
+
+	m, err := New(&config.GlobalConfig{
+		BookService: config.BookService{
+			Providers: []config.CatalogProviderConfig{
+				{Name: ProviderOpenLibrary, Address: slow.URL, TimeoutMS: 20},
+				{Name: ProviderOpenLibrary, Address: healthy.URL},
+			},
+		},
+	}, healthy.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := m.GetListOfBooks(context.Background(), domain.GetListOfBooksReq{
+		Subject: "fantasy",
+	})
+	if err != nil || len(list.Books) != 2 {
+		t.Fatalf("GetListOfBooks() = %+v, %v", list, err)
+	}
+
+	health, _ := m.GetCatalogHealth(context.Background())
+	if len(health) != 2 || health[0].Healthy || !health[1].Healthy {
+		t.Errorf("GetCatalogHealth() = %+v", health)
+	}
+}
+
+func Test_e2eReplayCassette(t *testing.T) {
+	replay, err := httpclient.NewRecorder(nil, config.RecorderConfig{
+		Mode:         httpclient.RecorderModeReplay,
+		CassettePath: "testdata/cassettes/openlibrary_love.json",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := New(&config.GlobalConfig{
+		BookService: config.BookService{
+			Address: "https://openlibrary.org",
+		},
+	}, replay)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	book, err := m.GetBookByKey(context.Background(), domain.GeBookByKeyReq{
+		Key:     "/works/OL45804W",
+		Subject: "love",
+	})
+	if err != nil || book.Title != "Wuthering Heights" || book.Authors[0].Name != "Emily Brontë" {
+		t.Fatalf("GetBookByKey() = %+v, %v", book, err)
+	}
+
+	if _, err := m.GetListOfBooks(context.Background(), domain.GetListOfBooksReq{
+		Subject: "not-recorded",
+	}); !errors.Is(err, httpclient.ErrInteractionNotFound) {
+		t.Errorf("GetListOfBooks() error = %v, want %v", err, httpclient.ErrInteractionNotFound)
+	}
+}
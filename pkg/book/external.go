@@ -2,10 +2,7 @@ package book
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
-	"io/ioutil"
-	"net/http"
 
 	"gihub.com/gadhittana01/book-project/config"
 	"gihub.com/gadhittana01/book-project/pkg/domain"
@@ -14,59 +11,32 @@ import (
 type external interface {
 	getListOfBooks(ctx context.Context, req domain.GetListOfBooksReq) (domain.GetListOfBooksResp, error)
 	getBookByKey(ctx context.Context, req domain.GeBookByKeyReq) (domain.Book, error)
+	getCatalogHealth(ctx context.Context) ([]domain.ProviderHealth, error)
 }
 
 type externalModule struct {
-	cfg        *config.GlobalConfig
-	httpclient HttpResource
+	cfg     *config.GlobalConfig
+	catalog *catalogChain
 }
 
-func newExternal(cfg *config.GlobalConfig, httpclient HttpResource) external {
-	return &externalModule{
-		cfg:        cfg,
-		httpclient: httpclient,
+func newExternal(cfg *config.GlobalConfig, httpclient HttpResource) (external, error) {
+	catalog, err := newCatalogChain(cfg, httpclient)
+	if err != nil {
+		return nil, err
 	}
-}
 
-const (
-	PathGetListOfBooks = "/subjects"
-)
+	return &externalModule{
+		cfg:     cfg,
+		catalog: catalog,
+	}, nil
+}
 
 func (m *externalModule) getListOfBooks(ctx context.Context, req domain.GetListOfBooksReq) (domain.GetListOfBooksResp, error) {
-	res := domain.GetListOfBooksResp{}
-
 	if req.Subject == "" {
-		return res, errors.New("Subject cannot be empty")
-	}
-
-	URL := m.cfg.BookService.Address + PathGetListOfBooks + "/" + req.Subject + ".json"
-
-	reqHttp, err := http.NewRequestWithContext(ctx, http.MethodGet, URL, nil)
-	if err != nil {
-		return res, err
-	}
-
-	resHttp, err := m.httpclient.Do(reqHttp)
-	if err != nil {
-		return res, err
-	}
-
-	defer resHttp.Body.Close()
-
-	if resHttp.StatusCode != 200 {
-		return res, errors.New("error external call")
-	}
-
-	resBody, err := ioutil.ReadAll(resHttp.Body)
-	if err != nil {
-		return res, err
+		return domain.GetListOfBooksResp{}, errors.New("Subject cannot be empty")
 	}
 
-	if err = json.Unmarshal(resBody, &res); err != nil {
-		return res, err
-	}
-
-	return res, nil
+	return m.catalog.GetListOfBooks(ctx, req)
 }
 
 func (m *externalModule) getBookByKey(ctx context.Context, req domain.GeBookByKeyReq) (domain.Book, error) {
@@ -80,9 +50,14 @@ func (m *externalModule) getBookByKey(ctx context.Context, req domain.GeBookByKe
 
 	for _, item := range resBooks.Books {
 		if item.Key == req.Key {
+			item.Provider = resBooks.Provider
 			return item, nil
 		}
 	}
 
 	return res, errors.New("Book not found")
 }
+
+func (m *externalModule) getCatalogHealth(ctx context.Context) ([]domain.ProviderHealth, error) {
+	return m.catalog.Health(), nil
+}
@@ -0,0 +1,67 @@
+package book
+
+import (
+	"context"
+	"errors"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/catalogstore"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+const (
+	ModeRemote = "remote"
+	ModeLocal  = "local"
+
+	ProviderLocal = "local"
+)
+
+// localExternalModule serves the catalog from a store imported by cmd/catalog-import instead of Open Library.
+type localExternalModule struct {
+	store *catalogstore.Store
+}
+
+func newLocalExternal(cfg *config.GlobalConfig) (external, error) {
+	if cfg.BookService.LocalCatalogPath == "" {
+		return nil, errors.New("bookservice.localcatalogpath is required in local mode")
+	}
+
+	store, err := catalogstore.Load(cfg.BookService.LocalCatalogPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &localExternalModule{
+		store: store,
+	}, nil
+}
+
+func (m *localExternalModule) getListOfBooks(ctx context.Context, req domain.GetListOfBooksReq) (domain.GetListOfBooksResp, error) {
+	if req.Subject == "" {
+		return domain.GetListOfBooksResp{}, errors.New("Subject cannot be empty")
+	}
+
+	return domain.GetListOfBooksResp{
+		Books:    m.store.BooksBySubject(req.Subject),
+		Provider: ProviderLocal,
+	}, nil
+}
+
+func (m *localExternalModule) getBookByKey(ctx context.Context, req domain.GeBookByKeyReq) (domain.Book, error) {
+	book, ok := m.store.Book(req.Key)
+	if !ok {
+		return domain.Book{}, errors.New("Book not found")
+	}
+
+	book.Provider = ProviderLocal
+	return book, nil
+}
+
+func (m *localExternalModule) getCatalogHealth(ctx context.Context) ([]domain.ProviderHealth, error) {
+	return []domain.ProviderHealth{
+		{
+			Name:    ProviderLocal,
+			Healthy: true,
+		},
+	}, nil
+}
@@ -0,0 +1,168 @@
+package book
+
+import (
+	"context"
+	"path/filepath"
+	reflect "reflect"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/catalogstore"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+func newTestCatalogStore() *catalogstore.Store {
+	store := catalogstore.New()
+	store.Add(domain.Book{
+		Key:          "/works/OL1908641W",
+		Title:        "Know Nothing",
+		EditionCount: 6,
+		Authors: []domain.Author{
+			{
+				Name: "Mary Lee Settle",
+			},
+		},
+		LendingIdentifier: "knownothingnovel00sett",
+	}, "love")
+	return store
+}
+
+func Test_newLocalExternal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	if err := newTestCatalogStore().Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		cfg     *config.GlobalConfig
+		wantErr bool
+	}{
+		{
+			name: "success",
+			cfg: &config.GlobalConfig{
+				BookService: config.BookService{
+					Mode:             ModeLocal,
+					LocalCatalogPath: path,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing path",
+			cfg: &config.GlobalConfig{
+				BookService: config.BookService{
+					Mode: ModeLocal,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "store not found",
+			cfg: &config.GlobalConfig{
+				BookService: config.BookService{
+					Mode:             ModeLocal,
+					LocalCatalogPath: filepath.Join(t.TempDir(), "missing.json"),
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newLocalExternal(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newLocalExternal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_localGetListOfBooks(t *testing.T) {
+	m := &localExternalModule{
+		store: newTestCatalogStore(),
+	}
+
+	tests := []struct {
+		name    string
+		req     domain.GetListOfBooksReq
+		want    domain.GetListOfBooksResp
+		wantErr bool
+	}{
+		{
+			name: "success",
+			req: domain.GetListOfBooksReq{
+				Subject: "Love",
+			},
+			want: domain.GetListOfBooksResp{
+				Books: []domain.Book{
+					{
+						Key:          "/works/OL1908641W",
+						Title:        "Know Nothing",
+						EditionCount: 6,
+						Authors: []domain.Author{
+							{
+								Name: "Mary Lee Settle",
+							},
+						},
+						LendingIdentifier: "knownothingnovel00sett",
+					},
+				},
+				Provider: ProviderLocal,
+			},
+		},
+		{
+			name: "unknown subject",
+			req: domain.GetListOfBooksReq{
+				Subject: "history",
+			},
+			want: domain.GetListOfBooksResp{
+				Books:    []domain.Book{},
+				Provider: ProviderLocal,
+			},
+		},
+		{
+			name:    "subject empty",
+			req:     domain.GetListOfBooksReq{},
+			want:    domain.GetListOfBooksResp{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.getListOfBooks(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getListOfBooks() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getListOfBooks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_localGetBookByKey(t *testing.T) {
+	m := &localExternalModule{
+		store: newTestCatalogStore(),
+	}
+
+	got, err := m.getBookByKey(context.Background(), domain.GeBookByKeyReq{
+		Key:     "/works/OL1908641W",
+		Subject: "love",
+	})
+	if err != nil || got.Title != "Know Nothing" || got.Provider != ProviderLocal {
+		t.Errorf("getBookByKey() = %v, %v", got, err)
+	}
+
+	if _, err := m.getBookByKey(context.Background(), domain.GeBookByKeyReq{
+		Key: "not found",
+	}); err == nil {
+		t.Errorf("getBookByKey() expected error")
+	}
+
+	health, _ := m.getCatalogHealth(context.Background())
+	if len(health) != 1 || !health[0].Healthy {
+		t.Errorf("getCatalogHealth() = %v", health)
+	}
+}
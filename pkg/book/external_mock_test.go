@@ -50,6 +50,21 @@ func (mr *MockexternalMockRecorder) getBookByKey(ctx, req interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getBookByKey", reflect.TypeOf((*Mockexternal)(nil).getBookByKey), ctx, req)
 }
 
+// getCatalogHealth mocks base method.
+func (m *Mockexternal) getCatalogHealth(ctx context.Context) ([]domain.ProviderHealth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getCatalogHealth", ctx)
+	ret0, _ := ret[0].([]domain.ProviderHealth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getCatalogHealth indicates an expected call of getCatalogHealth.
+func (mr *MockexternalMockRecorder) getCatalogHealth(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getCatalogHealth", reflect.TypeOf((*Mockexternal)(nil).getCatalogHealth), ctx)
+}
+
 // getListOfBooks mocks base method.
 func (m *Mockexternal) getListOfBooks(ctx context.Context, req domain.GetListOfBooksReq) (domain.GetListOfBooksResp, error) {
 	m.ctrl.T.Helper()
@@ -17,16 +17,22 @@ func Test_newExternal(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
 	httpClientMock := NewMockHttpResource(ctrl)
-	cfg := config.GlobalConfig{}
+	cfg := config.GlobalConfig{
+		BookService: config.BookService{
+			Address:  "https://dummyaccountsservice.com",
+			Provider: ProviderGoogleBooks,
+		},
+	}
 
 	type args struct {
 		cfg        *config.GlobalConfig
 		httpclient HttpResource
 	}
 	tests := []struct {
-		name string
-		args args
-		want external
+		name    string
+		args    args
+		want    external
+		wantErr bool
 	}{
 		{
 			name: "success",
@@ -35,14 +41,39 @@ func Test_newExternal(t *testing.T) {
 				httpclient: httpClientMock,
 			},
 			want: &externalModule{
-				cfg:        &cfg,
+				cfg: &cfg,
+				catalog: &catalogChain{
+					links: []*catalogLink{
+						{
+							provider: newGoogleBooksProvider("https://dummyaccountsservice.com", httpClientMock),
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown provider",
+			args: args{
+				cfg: &config.GlobalConfig{
+					BookService: config.BookService{
+						Provider: "unknown",
+					},
+				},
 				httpclient: httpClientMock,
 			},
+			want:    nil,
+			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := newExternal(tt.args.cfg, tt.args.httpclient); !reflect.DeepEqual(got, tt.want) {
+			got, err := newExternal(tt.args.cfg, tt.args.httpclient)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newExternal() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("newExternal() = %v, want %v", got, tt.want)
 			}
 		})
@@ -130,6 +161,7 @@ func Test_getListOfBooks(t *testing.T) {
 						LendingIdentifier: "knownothingnovel00sett",
 					},
 				},
+				Provider: ProviderOpenLibrary,
 			},
 			wantErr: false,
 		},
@@ -247,8 +279,14 @@ func Test_getListOfBooks(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			field := tt.fields()
 			m := &externalModule{
-				cfg:        field.cfg,
-				httpclient: field.httpclient,
+				cfg: field.cfg,
+				catalog: &catalogChain{
+					links: []*catalogLink{
+						{
+							provider: newOpenLibraryProvider(field.cfg.BookService.Address, field.httpclient),
+						},
+					},
+				},
 			}
 			got, err := m.getListOfBooks(tt.args.ctx, tt.args.req)
 			if (err != nil) != tt.wantErr {
@@ -340,6 +378,7 @@ func Test_getBookByKey(t *testing.T) {
 					},
 				},
 				LendingIdentifier: "knownothingnovel00sett",
+				Provider:          ProviderOpenLibrary,
 			},
 			wantErr: false,
 		},
@@ -429,8 +468,14 @@ func Test_getBookByKey(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			field := tt.fields()
 			m := &externalModule{
-				cfg:        field.cfg,
-				httpclient: field.httpclient,
+				cfg: field.cfg,
+				catalog: &catalogChain{
+					links: []*catalogLink{
+						{
+							provider: newOpenLibraryProvider(field.cfg.BookService.Address, field.httpclient),
+						},
+					},
+				},
 			}
 			got, err := m.getBookByKey(tt.args.ctx, tt.args.req)
 			if (err != nil) != tt.wantErr {
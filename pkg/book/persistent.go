@@ -3,37 +3,436 @@ package book
 import (
 	"context"
 	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
+	"gihub.com/gadhittana01/book-project/config"
 	"gihub.com/gadhittana01/book-project/pkg/domain"
 )
 
+const (
+	pickUpDateLayout = "2006-01-02"
+
+	defaultHoldConfirmHours     = 24
+	defaultReservationPageLimit = 500
+)
+
 type persistent interface {
-	borrowBook(ctx context.Context, req domain.BorrowBookReq) error
+	borrowBook(ctx context.Context, req domain.BorrowBookReq) (domain.BorrowBookReq, error)
 	getBookReservation(ctx context.Context, req domain.GetBookReservationReq) (map[int][]domain.BorrowBookReq, error)
+	getReservationPage(ctx context.Context, req domain.GetReservationPageReq) ([]domain.BorrowBookReq, error)
+	borrowBooks(ctx context.Context, req []domain.BorrowBookReq) ([]domain.BorrowBookReq, error)
+	joinWaitlist(ctx context.Context, req domain.JoinWaitlistReq) (int, error)
+	cancelReservation(ctx context.Context, req domain.CancelReservationReq) (domain.BorrowBookReq, error)
+	confirmReservation(ctx context.Context, req domain.ConfirmReservationReq) error
+	checkoutBook(ctx context.Context, req domain.CheckoutBookReq) (domain.Loan, error)
+	returnBook(ctx context.Context, req domain.ReturnBookReq) (domain.Loan, error)
+	renewLoan(ctx context.Context, req domain.RenewLoanReq) (domain.Loan, error)
+	getLoans(ctx context.Context, req domain.GetLoansReq) (map[int][]domain.Loan, error)
+	getFines(ctx context.Context, req domain.GetFinesReq) (domain.FineLedger, error)
+	settleFines(ctx context.Context, req domain.SettleFinesReq) (domain.FineLedger, error)
+	waiveFine(ctx context.Context, req domain.WaiveFineReq) (domain.FineLedger, error)
+	getBranches(ctx context.Context) ([]domain.Branch, error)
+	getAvailability(ctx context.Context, req domain.GetAvailabilityReq) ([]domain.BranchAvailability, error)
+	getPickupSlots(ctx context.Context, req domain.GetPickupSlotsReq) ([]domain.PickupSlot, error)
+	acquireLease(ctx context.Context, req domain.AcquireLeaseReq) (bool, error)
+	expireUncollected(ctx context.Context, req domain.ExpireUncollectedReq) ([]domain.BorrowBookReq, error)
+	claimReminders(ctx context.Context, req domain.ClaimRemindersReq) ([]domain.BorrowBookReq, error)
+	releaseReminder(ctx context.Context, req domain.ReleaseReminderReq) error
+	claimOutbox(ctx context.Context, req domain.ClaimOutboxReq) ([]domain.OutboxEvent, error)
+	ackOutbox(ctx context.Context, req domain.AckOutboxReq) error
+	getOutbox(ctx context.Context, req domain.GetOutboxReq) ([]domain.OutboxEvent, error)
+	requeueOutbox(ctx context.Context, req domain.RequeueOutboxReq) (domain.OutboxEvent, error)
 }
 
 type persistentModule struct {
+	cfg *config.GlobalConfig
 }
 
-func newPersistent() persistent {
-	return &persistentModule{}
+func newPersistent(cfg *config.GlobalConfig) persistent {
+	return &persistentModule{
+		cfg: cfg,
+	}
 }
 
-var books map[int][]domain.BorrowBookReq = make(map[int][]domain.BorrowBookReq)
+var (
+	// mu guards every reservation, loan, fine, lease and outbox global in this package.
+	mu sync.Mutex
+
+	books             map[int][]domain.BorrowBookReq = make(map[int][]domain.BorrowBookReq)
+	holds             map[string][]domain.Hold       = make(map[string][]domain.Hold) // keyed by holdKey
+	lastReservationID int
+	// reservationIndex lists every reservation in ID order. IDs only grow and reservations are only appended, so
+	// pages are found with a binary search instead of a scan of every user.
+	reservationIndex []reservationRef
+	loans            map[int][]domain.Loan = make(map[int][]domain.Loan)
+	lastLoanID       int
+
+	timeNow = time.Now
+)
 
-func (m *persistentModule) borrowBook(ctx context.Context, req domain.BorrowBookReq) error {
+// reservationRef locates a reservation in books.
+type reservationRef struct {
+	userID int
+	pos    int
+}
+
+// borrowBook stores a reservation and books its pickup slot in one step, and returns what was stored.
+func (m *persistentModule) borrowBook(ctx context.Context, req domain.BorrowBookReq) (domain.BorrowBookReq, error) {
 	if req.UserID == 0 {
-		return errors.New("User ID is empty")
+		return domain.BorrowBookReq{}, errors.New("User ID is empty")
 	}
-	books[req.UserID] = append(books[req.UserID], req)
-	return nil
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	now := timeNow()
+	m.releaseExpiredHolds(now)
+
+	return m.storeReservation(req, now)
+}
+
+// storeReservation checks the borrowing policies, the pickup and a free copy and adds the reservation. A free copy
+// goes to the hold queue first, unless nobody in it can take the copy. Callers must hold mu.
+func (m *persistentModule) storeReservation(req domain.BorrowBookReq, now time.Time) (domain.BorrowBookReq, error) {
+	if err := m.checkPolicies(req.UserID, req.Book, req.Subject); err != nil {
+		return domain.BorrowBookReq{}, err
+	}
+	slot, err := m.checkPickup(req.Branch, req.PickUpDate, req.PickUpSlot)
+	if err != nil {
+		return domain.BorrowBookReq{}, err
+	}
+	if !m.hasFreeCopy(req.Book.Key, req.Branch) {
+		return domain.BorrowBookReq{}, domain.ErrFullyReserved
+	}
+	if i, _, _ := m.nextPromotableHold(holds[holdKey(req.Book.Key, req.Branch)], now); i >= 0 {
+		return domain.BorrowBookReq{}, domain.ErrFullyReserved
+	}
+
+	req.PickUpSlot = slot
+	if req.Status == "" {
+		req.Status = domain.ReservationStatusActive
+	}
+	return m.addReservation(req, now), nil
 }
 
 func (m *persistentModule) getBookReservation(ctx context.Context, req domain.GetBookReservationReq) (map[int][]domain.BorrowBookReq, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	m.releaseExpiredHolds(timeNow())
+
+	result := make(map[int][]domain.BorrowBookReq)
+	if req.UserID != 0 {
+		result[req.UserID] = filterBranch(books[req.UserID], req.Branch)
+	} else {
+		for uid, items := range books {
+			result[uid] = filterBranch(items, req.Branch)
+		}
+	}
+
+	for _, queue := range holds {
+		for i, hold := range queue {
+			if req.UserID != 0 && hold.UserID != req.UserID {
+				continue
+			}
+			if req.Branch != "" && hold.Branch != req.Branch {
+				continue
+			}
+			result[hold.UserID] = append(result[hold.UserID], domain.BorrowBookReq{
+				Book:          hold.Book,
+				Subject:       hold.Subject,
+				Branch:        hold.Branch,
+				PickUpDate:    hold.PickUpDate,
+				UserID:        hold.UserID,
+				Status:        domain.ReservationStatusWaiting,
+				QueuePosition: i + 1,
+				CreatedAt:     hold.CreatedAt,
+			})
+		}
+	}
+	return result, nil
+}
+
+// getReservationPage returns up to Limit reservations with an ID above AfterID in ID order. Only the page is
+// copied, so callers can walk every reservation without holding them all at once. Waitlist holds have no ID and
+// are not included.
+func (m *persistentModule) getReservationPage(ctx context.Context, req domain.GetReservationPageReq) ([]domain.BorrowBookReq, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultReservationPageLimit
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	m.releaseExpiredHolds(timeNow())
+
+	page := make([]domain.BorrowBookReq, 0, limit)
+	add := func(item domain.BorrowBookReq) bool {
+		if pageMatch(req, item) {
+			page = append(page, item)
+		}
+		return len(page) < limit
+	}
+
+	// The reservations of a user are in ID order as well.
+	if req.UserID != 0 {
+		items := books[req.UserID]
+		start := sort.Search(len(items), func(i int) bool { return items[i].ID > req.AfterID })
+		for _, item := range items[start:] {
+			if !add(item) {
+				break
+			}
+		}
+		return page, nil
+	}
+
+	start := sort.Search(len(reservationIndex), func(i int) bool {
+		ref := reservationIndex[i]
+		return books[ref.userID][ref.pos].ID > req.AfterID
+	})
+	for _, ref := range reservationIndex[start:] {
+		if !add(books[ref.userID][ref.pos]) {
+			break
+		}
+	}
+	return page, nil
+}
+
+// pageMatch reports whether item passes the filters of req.
+func pageMatch(req domain.GetReservationPageReq, item domain.BorrowBookReq) bool {
+	if req.Branch != "" && item.Branch != req.Branch {
+		return false
+	}
+	if req.Status != "" && item.Status != req.Status {
+		return false
+	}
+	if req.Key != "" && item.Book.Key != req.Key {
+		return false
+	}
+	// Pickup dates are YYYY-MM-DD, so they compare as strings.
+	if req.From != "" && item.PickUpDate < req.From {
+		return false
+	}
+	if req.To != "" && item.PickUpDate > req.To {
+		return false
+	}
+	if req.Query == "" {
+		return true
+	}
+	query := strings.ToLower(req.Query)
+	if strings.Contains(strings.ToLower(item.Book.Title), query) || strings.Contains(strings.ToLower(item.Book.Key), query) {
+		return true
+	}
+	for _, author := range item.Book.Authors {
+		if strings.Contains(strings.ToLower(author.Name), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// joinWaitlist puts the user at the back of the hold queue of a fully reserved work and returns the queue position.
+func (m *persistentModule) joinWaitlist(ctx context.Context, req domain.JoinWaitlistReq) (int, error) {
 	if req.UserID == 0 {
-		return books, nil
+		return 0, errors.New("User ID is empty")
 	}
-	return map[int][]domain.BorrowBookReq{
-		req.UserID: books[req.UserID],
-	}, nil
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	now := timeNow()
+	m.releaseExpiredHolds(now)
+
+	if len(m.cfg.Branches) > 0 {
+		if _, ok := m.findBranch(req.Branch); !ok {
+			return 0, domain.ErrBranchNotFound
+		}
+	}
+	// The policies are checked again when the hold is offered a copy.
+	if err := m.checkPolicies(req.UserID, req.Book, req.Subject); err != nil {
+		return 0, err
+	}
+
+	hk := holdKey(req.Book.Key, req.Branch)
+	queue := holds[hk]
+	for _, hold := range queue {
+		if hold.UserID == req.UserID {
+			return 0, domain.ErrAlreadyInWaitlist
+		}
+	}
+	// A free copy nobody in the queue can take is there to borrow, like in storeReservation.
+	if m.hasFreeCopy(req.Book.Key, req.Branch) {
+		if i, _, _ := m.nextPromotableHold(queue, now); i < 0 {
+			return 0, domain.ErrBookAvailable
+		}
+	}
+
+	holds[hk] = append(queue, domain.Hold{
+		Book:       req.Book,
+		Subject:    req.Subject,
+		Branch:     req.Branch,
+		PickUpDate: req.PickUpDate,
+		UserID:     req.UserID,
+		CreatedAt:  now,
+	})
+	return len(holds[hk]), nil
+}
+
+// cancelReservation cancels an active or provisional reservation, hands the copy to the next user in the queue and
+// returns the cancelled reservation.
+func (m *persistentModule) cancelReservation(ctx context.Context, req domain.CancelReservationReq) (domain.BorrowBookReq, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	now := timeNow()
+	m.releaseExpiredHolds(now)
+
+	res := findReservation(req.ID, req.UserID)
+	if res == nil {
+		return domain.BorrowBookReq{}, domain.ErrReservationNotFound
+	}
+	if res.Status != domain.ReservationStatusActive && res.Status != domain.ReservationStatusProvisional {
+		return domain.BorrowBookReq{}, domain.ErrInvalidReservationState
+	}
+
+	res.Status = domain.ReservationStatusCancelled
+	cancelled := *res
+	recordEvent(domain.EventReservationCancelled, cancelled, now)
+	m.promoteHolds(res.Book.Key, res.Branch, now)
+	return cancelled, nil
+}
+
+// confirmReservation turns a provisional reservation into an active one while the confirmation window is open.
+func (m *persistentModule) confirmReservation(ctx context.Context, req domain.ConfirmReservationReq) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	now := timeNow()
+	res := findReservation(req.ID, req.UserID)
+	if res == nil {
+		return domain.ErrReservationNotFound
+	}
+	if res.Status == domain.ReservationStatusProvisional && now.After(res.ConfirmBy) {
+		m.releaseExpiredHolds(now)
+		return domain.ErrConfirmWindowPassed
+	}
+	if res.Status != domain.ReservationStatusProvisional {
+		return domain.ErrInvalidReservationState
+	}
+
+	res.Status = domain.ReservationStatusActive
+	res.ConfirmBy = time.Time{}
+	recordEvent(domain.EventReservationConfirmed, *res, now)
+	return nil
+}
+
+// releaseExpiredHolds expires provisional reservations nobody confirmed in time and promotes the next holds.
+// Callers must hold mu.
+func (m *persistentModule) releaseExpiredHolds(now time.Time) {
+	type copyKey struct{ key, branch string }
+	released := map[copyKey]bool{}
+	for uid := range books {
+		for i := range books[uid] {
+			res := &books[uid][i]
+			if res.Status == domain.ReservationStatusProvisional && now.After(res.ConfirmBy) {
+				res.Status = domain.ReservationStatusExpired
+				released[copyKey{res.Book.Key, res.Branch}] = true
+				recordEvent(domain.EventReservationExpired, *res, now)
+			}
+		}
+	}
+	for c := range released {
+		m.promoteHolds(c.key, c.branch, now)
+	}
+}
+
+// promoteHolds gives free copies of a work at a branch to the first users of its hold queue the borrowing policies
+// allow, as provisional reservations.
+// Callers must hold mu.
+func (m *persistentModule) promoteHolds(key, branch string, now time.Time) {
+	confirmHours := m.cfg.Reservation.HoldConfirmHours
+	if confirmHours <= 0 {
+		confirmHours = defaultHoldConfirmHours
+	}
+
+	hk := holdKey(key, branch)
+	for len(holds[hk]) > 0 && m.hasFreeCopy(key, branch) {
+		// Users the borrowing policies no longer allow keep their place and are passed over for this copy, so are
+		// holds without a pickup day at the branch soon. When nobody is left the copy is free to borrow.
+		i, pickUpDate, slot := m.nextPromotableHold(holds[hk], now)
+		if i < 0 {
+			return
+		}
+		queue := holds[hk]
+		hold := queue[i]
+		holds[hk] = append(append([]domain.Hold(nil), queue[:i]...), queue[i+1:]...)
+		if len(holds[hk]) == 0 {
+			delete(holds, hk)
+		}
+
+		m.addReservation(domain.BorrowBookReq{
+			Book:       hold.Book,
+			Subject:    hold.Subject,
+			Branch:     hold.Branch,
+			PickUpDate: pickUpDate,
+			PickUpSlot: slot,
+			UserID:     hold.UserID,
+			Status:     domain.ReservationStatusProvisional,
+			ConfirmBy:  now.Add(time.Duration(confirmHours) * time.Hour),
+		}, now)
+	}
+}
+
+// hasFreeCopy reports whether a branch has a copy of the work left that is not reserved or out on loan.
+// Callers must hold mu.
+func (m *persistentModule) hasFreeCopy(key, branch string) bool {
+	copies := m.copiesPerWork(branch)
+	if copies <= 0 {
+		return true
+	}
+	return reservedCopies(key, branch) < copies
+}
+
+// addReservation stores req under a new ID, records its created event and returns it. Callers must hold mu.
+func (m *persistentModule) addReservation(req domain.BorrowBookReq, now time.Time) domain.BorrowBookReq {
+	lastReservationID++
+	req.ID = lastReservationID
+	req.CreatedAt = now
+	books[req.UserID] = append(books[req.UserID], req)
+	reservationIndex = append(reservationIndex, reservationRef{userID: req.UserID, pos: len(books[req.UserID]) - 1})
+	recordEvent(domain.EventReservationCreated, req, now)
+	return req
+}
+
+// filterBranch copies items, keeping only those at branch when it is set.
+func filterBranch(items []domain.BorrowBookReq, branch string) []domain.BorrowBookReq {
+	if branch == "" {
+		return append([]domain.BorrowBookReq(nil), items...)
+	}
+	result := []domain.BorrowBookReq{}
+	for _, item := range items {
+		if item.Branch == branch {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// findReservation returns the stored reservation with id, limited to userID when it is set. Callers must hold mu.
+func findReservation(id, userID int) *domain.BorrowBookReq {
+	for uid := range books {
+		if userID != 0 && uid != userID {
+			continue
+		}
+		for i := range books[uid] {
+			if books[uid][i].ID == id {
+				return &books[uid][i]
+			}
+		}
+	}
+	return nil
 }
@@ -0,0 +1,50 @@
+package book
+
+import (
+	"context"
+	"errors"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+// borrowBooks stores every reservation or none of them. Items are stored in order, so later ones see the copies
+// and pickup slots taken by earlier ones. When one fails the stored ones and their events are rolled back and a
+// *domain.BatchItemError names the failing item.
+func (m *persistentModule) borrowBooks(ctx context.Context, req []domain.BorrowBookReq) ([]domain.BorrowBookReq, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	now := timeNow()
+	m.releaseExpiredHolds(now)
+
+	// New reservations and events are only ever appended, remembering the lengths is enough to undo them.
+	savedLens := map[int]int{}
+	savedReservationID, savedEventID, savedOutboxLen := lastReservationID, lastEventID, len(outbox)
+	savedIndexLen := len(reservationIndex)
+	rollback := func() {
+		for uid, n := range savedLens {
+			books[uid] = books[uid][:n]
+		}
+		lastReservationID, lastEventID, outbox = savedReservationID, savedEventID, outbox[:savedOutboxLen]
+		reservationIndex = reservationIndex[:savedIndexLen]
+	}
+
+	result := make([]domain.BorrowBookReq, 0, len(req))
+	for i, item := range req {
+		if item.UserID == 0 {
+			rollback()
+			return nil, &domain.BatchItemError{Index: i, Err: errors.New("User ID is empty")}
+		}
+		if _, ok := savedLens[item.UserID]; !ok {
+			savedLens[item.UserID] = len(books[item.UserID])
+		}
+
+		res, err := m.storeReservation(item, now)
+		if err != nil {
+			rollback()
+			return nil, &domain.BatchItemError{Index: i, Err: err}
+		}
+		result = append(result, res)
+	}
+	return result, nil
+}
@@ -0,0 +1,54 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+func Test_borrowBooks(t *testing.T) {
+	ctx := context.Background()
+	m := &persistentModule{cfg: &config.GlobalConfig{Reservation: config.Reservation{CopiesPerWork: 1}}}
+	matilda := domain.Book{Key: "/works/batch-matilda", Title: "Matilda"}
+	dune := domain.Book{Key: "/works/batch-dune", Title: "Dune"}
+
+	mu.Lock()
+	beforeID, beforeEvent, beforeLen := lastReservationID, lastEventID, len(books[811])
+	beforeIndex := len(reservationIndex)
+	mu.Unlock()
+
+	// The second copy of Matilda is not there, so nothing of the batch is kept.
+	_, err := m.borrowBooks(ctx, []domain.BorrowBookReq{
+		{Book: dune, UserID: 811, PickUpDate: "2022-06-01"},
+		{Book: matilda, UserID: 812, PickUpDate: "2022-06-01"},
+		{Book: matilda, UserID: 811, PickUpDate: "2022-06-01"},
+	})
+	var itemErr *domain.BatchItemError
+	if !errors.As(err, &itemErr) || itemErr.Index != 2 || !errors.Is(err, domain.ErrFullyReserved) {
+		t.Fatalf("borrowBooks() error = %v, want item 2 fully reserved", err)
+	}
+	mu.Lock()
+	if lastReservationID != beforeID || lastEventID != beforeEvent || len(books[811]) != beforeLen || len(books[812]) != 0 {
+		t.Errorf("borrowBooks() left reservations %d, events %d, user 811 %d, user 812 %d", lastReservationID-beforeID, lastEventID-beforeEvent, len(books[811])-beforeLen, len(books[812]))
+	}
+	if len(reservationIndex) != beforeIndex {
+		t.Errorf("borrowBooks() left %d entries in the reservation index", len(reservationIndex)-beforeIndex)
+	}
+	mu.Unlock()
+
+	got, err := m.borrowBooks(ctx, []domain.BorrowBookReq{
+		{Book: dune, UserID: 811, PickUpDate: "2022-06-01"},
+		{Book: matilda, UserID: 812, PickUpDate: "2022-06-01"},
+	})
+	if err != nil || len(got) != 2 || got[0].ID == 0 || got[1].ID != got[0].ID+1 || got[1].Status != domain.ReservationStatusActive {
+		t.Fatalf("borrowBooks() = %+v, %v", got, err)
+	}
+	for _, res := range got {
+		if _, err := m.cancelReservation(ctx, domain.CancelReservationReq{ID: res.ID, UserID: res.UserID}); err != nil {
+			t.Errorf("cancelReservation() error = %v", err)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package book
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+const (
+	slotLayout = "15:04"
+	// maxPickupSearchDays is how far ahead a promoted hold looks for a day its branch is open with pickups left.
+	maxPickupSearchDays = 31
+)
+
+func (m *persistentModule) getBranches(ctx context.Context) ([]domain.Branch, error) {
+	result := make([]domain.Branch, 0, len(m.cfg.Branches))
+	for _, b := range m.cfg.Branches {
+		result = append(result, newBranch(b))
+	}
+	return result, nil
+}
+
+// getAvailability counts the copies of a work per configured branch, plus any branch that has reservations of it.
+func (m *persistentModule) getAvailability(ctx context.Context, req domain.GetAvailabilityReq) ([]domain.BranchAvailability, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	m.releaseExpiredHolds(timeNow())
+
+	codes := []string{}
+	seen := map[string]bool{}
+	add := func(code string) {
+		if !seen[code] {
+			seen[code] = true
+			codes = append(codes, code)
+		}
+	}
+	for _, b := range m.cfg.Branches {
+		add(b.Code)
+	}
+	for _, items := range books {
+		for _, item := range items {
+			if item.Book.Key == req.Key {
+				add(item.Branch)
+			}
+		}
+	}
+
+	result := make([]domain.BranchAvailability, 0, len(codes))
+	for _, code := range codes {
+		copies := m.copiesPerWork(code)
+		reserved := reservedCopies(req.Key, code)
+		available := 0
+		if copies > reserved {
+			available = copies - reserved
+		}
+		result = append(result, domain.BranchAvailability{
+			Branch:    code,
+			Copies:    copies,
+			Reserved:  reserved,
+			Available: available,
+			Waiting:   len(holds[holdKey(req.Key, code)]),
+		})
+	}
+	return result, nil
+}
+
+// getPickupSlots lists the pickup slots of a branch on a date with the pickups already booked in each.
+func (m *persistentModule) getPickupSlots(ctx context.Context, req domain.GetPickupSlotsReq) ([]domain.PickupSlot, error) {
+	branch, ok := m.findBranch(req.Branch)
+	if !ok {
+		return nil, domain.ErrBranchNotFound
+	}
+	date, err := time.Parse(pickUpDateLayout, req.Date)
+	if err != nil {
+		return nil, domain.ErrInvalidPickUpDate
+	}
+	if !branchOpen(branch, date) {
+		return nil, domain.ErrBranchClosed
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	m.releaseExpiredHolds(timeNow())
+
+	result := []domain.PickupSlot{}
+	for _, slot := range branchSlots(branch, date) {
+		slot.Booked = pickupsOn(branch.Code, req.Date, slot.Start)
+		slot.Available = slotRoom(slot.Capacity, slot.Booked)
+		result = append(result, slot)
+	}
+	return result, nil
+}
+
+// checkPickup validates the branch, pickup date and slot of a new reservation when branches are configured and
+// returns the slot to book, the first one with room when none was asked for. A pickup date before today is
+// refused. Callers must hold mu.
+func (m *persistentModule) checkPickup(code, pickUpDate, slot string) (string, error) {
+	if len(m.cfg.Branches) == 0 {
+		return slot, nil
+	}
+	branch, ok := m.findBranch(code)
+	if !ok {
+		return "", domain.ErrBranchNotFound
+	}
+	date, err := time.Parse(pickUpDateLayout, pickUpDate)
+	if err != nil || pickUpDate < timeNow().Format(pickUpDateLayout) {
+		return "", domain.ErrInvalidPickUpDate
+	}
+	if !branchOpen(branch, date) {
+		return "", domain.ErrBranchClosed
+	}
+	if branch.PickupCapacity > 0 && pickupsOn(code, pickUpDate, "") >= branch.PickupCapacity {
+		return "", domain.ErrPickupCapacityReached
+	}
+
+	slots := branchSlots(branch, date)
+	if len(slots) == 0 {
+		if slot != "" {
+			return "", domain.ErrInvalidPickUpSlot
+		}
+		return "", nil
+	}
+	for _, s := range slots {
+		if slot != "" && s.Start != slot {
+			continue
+		}
+		if slotRoom(s.Capacity, pickupsOn(code, pickUpDate, s.Start)) > 0 {
+			return s.Start, nil
+		}
+		if slot != "" {
+			return "", domain.ErrPickupSlotFull
+		}
+	}
+	if slot != "" {
+		return "", domain.ErrInvalidPickUpSlot
+	}
+	return "", domain.ErrPickupSlotFull
+}
+
+// holdPickup picks the pickup date and slot of a promoted hold: the first day from its requested date, or today when
+// that has passed, on which the branch is open and has pickup capacity left. The copy is offered even when no slot
+// is left that day, the user settles the pickup time at the desk. Callers must hold mu.
+func (m *persistentModule) holdPickup(hold domain.Hold, now time.Time) (string, string, bool) {
+	today := now.Format(pickUpDateLayout)
+	from, err := time.Parse(pickUpDateLayout, hold.PickUpDate)
+	if err != nil || hold.PickUpDate < today {
+		from, _ = time.Parse(pickUpDateLayout, today)
+	}
+
+	for i := 0; i < maxPickupSearchDays; i++ {
+		day := from.AddDate(0, 0, i).Format(pickUpDateLayout)
+		slot, err := m.checkPickup(hold.Branch, day, "")
+		switch err {
+		case nil:
+			return day, slot, true
+		case domain.ErrBranchClosed, domain.ErrPickupCapacityReached:
+			continue
+		default:
+			return day, "", true
+		}
+	}
+	return "", "", false
+}
+
+func (m *persistentModule) findBranch(code string) (config.Branch, bool) {
+	for _, b := range m.cfg.Branches {
+		if b.Code == code {
+			return b, true
+		}
+	}
+	return config.Branch{}, false
+}
+
+// copiesPerWork returns the number of copies of each work a branch holds, 0 means unlimited.
+func (m *persistentModule) copiesPerWork(code string) int {
+	if b, ok := m.findBranch(code); ok && b.CopiesPerWork > 0 {
+		return b.CopiesPerWork
+	}
+	return m.cfg.Reservation.CopiesPerWork
+}
+
+// branchOpen reports whether the branch has opening hours on date and date is not one of its closed dates.
+func branchOpen(b config.Branch, date time.Time) bool {
+	day := date.Format(pickUpDateLayout)
+	for _, closed := range b.ClosedDates {
+		if closed == day {
+			return false
+		}
+	}
+	return b.OpeningHours[strings.ToLower(date.Weekday().String())] != ""
+}
+
+// branchSlots splits the opening hours of a branch on date into slots of SlotMinutes, leaving out a last slot
+// that would run past closing time.
+func branchSlots(b config.Branch, date time.Time) []domain.PickupSlot {
+	if b.SlotMinutes <= 0 {
+		return nil
+	}
+	hours := strings.SplitN(b.OpeningHours[strings.ToLower(date.Weekday().String())], "-", 2)
+	if len(hours) != 2 {
+		return nil
+	}
+	open, err := time.Parse(slotLayout, strings.TrimSpace(hours[0]))
+	if err != nil {
+		return nil
+	}
+	closing, err := time.Parse(slotLayout, strings.TrimSpace(hours[1]))
+	if err != nil {
+		return nil
+	}
+
+	step := time.Duration(b.SlotMinutes) * time.Minute
+	slots := []domain.PickupSlot{}
+	for start := open; !start.Add(step).After(closing); start = start.Add(step) {
+		slots = append(slots, domain.PickupSlot{
+			Start:    start.Format(slotLayout),
+			End:      start.Add(step).Format(slotLayout),
+			Capacity: b.SlotCapacity,
+		})
+	}
+	return slots
+}
+
+// slotRoom returns how many more pickups fit in a slot, -1 when it is unlimited.
+func slotRoom(capacity, booked int) int {
+	if capacity <= 0 {
+		return -1
+	}
+	if booked >= capacity {
+		return 0
+	}
+	return capacity - booked
+}
+
+// pickupsOn counts the reservations still expected to be picked up at a branch on a date, limited to one slot
+// when it is set. Callers must hold mu.
+func pickupsOn(code, pickUpDate, slot string) int {
+	count := 0
+	for _, items := range books {
+		for _, item := range items {
+			if item.Branch != code || item.PickUpDate != pickUpDate {
+				continue
+			}
+			if slot != "" && item.PickUpSlot != slot {
+				continue
+			}
+			switch item.Status {
+			case domain.ReservationStatusActive, domain.ReservationStatusProvisional, domain.ReservationStatusPickedUp:
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// reservedCopies counts the copies of a work at a branch that are reserved or out on loan. Callers must hold mu.
+func reservedCopies(key, code string) int {
+	reserved := 0
+	for _, items := range books {
+		for _, item := range items {
+			if item.Book.Key != key || item.Branch != code {
+				continue
+			}
+			switch item.Status {
+			case domain.ReservationStatusActive, domain.ReservationStatusProvisional, domain.ReservationStatusPickedUp:
+				reserved++
+			}
+		}
+	}
+	return reserved
+}
+
+// holdKey identifies the hold queue of a work at a branch.
+func holdKey(key, branch string) string {
+	if branch == "" {
+		return key
+	}
+	return branch + ":" + key
+}
+
+func newBranch(b config.Branch) domain.Branch {
+	hours := make(map[string]string, len(b.OpeningHours))
+	for day, h := range b.OpeningHours {
+		hours[strings.ToLower(day)] = h
+	}
+	return domain.Branch{
+		Code:           b.Code,
+		Name:           b.Name,
+		Address:        b.Address,
+		OpeningHours:   hours,
+		ClosedDates:    append([]string(nil), b.ClosedDates...),
+		PickupCapacity: b.PickupCapacity,
+		CopiesPerWork:  b.CopiesPerWork,
+		SlotMinutes:    b.SlotMinutes,
+		SlotCapacity:   b.SlotCapacity,
+	}
+}
@@ -0,0 +1,206 @@
+package book
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+func Test_branches(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 1, 20, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	weekdays := map[string]string{
+		"monday":    "09:00-17:00",
+		"tuesday":   "09:00-17:00",
+		"wednesday": "09:00-17:00",
+		"thursday":  "09:00-17:00",
+		"friday":    "09:00-17:00",
+	}
+	m := &persistentModule{
+		cfg: &config.GlobalConfig{
+			Reservation: config.Reservation{CopiesPerWork: 1},
+			Branches: []config.Branch{
+				{Code: "central", OpeningHours: weekdays, ClosedDates: []string{"2022-01-26"}, PickupCapacity: 2, CopiesPerWork: 2},
+				{Code: "north", OpeningHours: weekdays},
+			},
+		},
+	}
+	book := domain.Book{Key: "/works/OL82563W", Title: "Matilda"}
+	other := domain.Book{Key: "/works/OL82537W", Title: "The BFG"}
+
+	tests := []struct {
+		name string
+		req  domain.BorrowBookReq
+		want error
+	}{
+		{name: "unknown branch", req: domain.BorrowBookReq{Book: book, UserID: 401, Branch: "south", PickUpDate: "2022-01-24"}, want: domain.ErrBranchNotFound},
+		{name: "bad date", req: domain.BorrowBookReq{Book: book, UserID: 401, Branch: "central", PickUpDate: "24-01-2022"}, want: domain.ErrInvalidPickUpDate},
+		{name: "past date", req: domain.BorrowBookReq{Book: book, UserID: 401, Branch: "central", PickUpDate: "2022-01-19"}, want: domain.ErrInvalidPickUpDate},
+		{name: "weekend", req: domain.BorrowBookReq{Book: book, UserID: 401, Branch: "central", PickUpDate: "2022-01-22"}, want: domain.ErrBranchClosed},
+		{name: "closed date", req: domain.BorrowBookReq{Book: book, UserID: 401, Branch: "central", PickUpDate: "2022-01-26"}, want: domain.ErrBranchClosed},
+		{name: "central first copy", req: domain.BorrowBookReq{Book: book, UserID: 401, Branch: "central", PickUpDate: "2022-01-24"}},
+		{name: "central second copy", req: domain.BorrowBookReq{Book: book, UserID: 402, Branch: "central", PickUpDate: "2022-01-24"}},
+		{name: "central pickup capacity", req: domain.BorrowBookReq{Book: other, UserID: 403, Branch: "central", PickUpDate: "2022-01-24"}, want: domain.ErrPickupCapacityReached},
+		{name: "central fully reserved", req: domain.BorrowBookReq{Book: book, UserID: 403, Branch: "central", PickUpDate: "2022-01-25"}, want: domain.ErrFullyReserved},
+		{name: "north copy", req: domain.BorrowBookReq{Book: book, UserID: 403, Branch: "north", PickUpDate: "2022-01-24"}},
+		{name: "north fully reserved", req: domain.BorrowBookReq{Book: book, UserID: 404, Branch: "north", PickUpDate: "2022-01-25"}, want: domain.ErrFullyReserved},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := m.borrowBook(ctx, tt.req); err != tt.want {
+				t.Errorf("borrowBook() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := m.joinWaitlist(ctx, domain.JoinWaitlistReq{Book: book, UserID: 404, Branch: "north"}); err != nil {
+		t.Fatalf("joinWaitlist() error = %v", err)
+	}
+	defer delete(holds, holdKey(book.Key, "north"))
+
+	got, _ := m.getAvailability(ctx, domain.GetAvailabilityReq{Key: book.Key})
+	want := []domain.BranchAvailability{
+		{Branch: "central", Copies: 2, Reserved: 2, Available: 0},
+		{Branch: "north", Copies: 1, Reserved: 1, Available: 0, Waiting: 1},
+	}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("getAvailability() = %+v, want %+v", got, want)
+	}
+
+	res, _ := m.getBookReservation(ctx, domain.GetBookReservationReq{UserID: 404, Branch: "north"})
+	if len(res[404]) != 1 || res[404][0].Branch != "north" || res[404][0].Status != domain.ReservationStatusWaiting {
+		t.Errorf("getBookReservation() north = %+v", res[404])
+	}
+	res, _ = m.getBookReservation(ctx, domain.GetBookReservationReq{UserID: 403, Branch: "central"})
+	if len(res[403]) != 0 {
+		t.Errorf("getBookReservation() central = %+v, want none", res[403])
+	}
+
+	// Cancelling at central leaves the north queue alone.
+	first := books[401][len(books[401])-1]
+	if _, err := m.cancelReservation(ctx, domain.CancelReservationReq{ID: first.ID, UserID: 401}); err != nil {
+		t.Fatalf("cancelReservation() error = %v", err)
+	}
+	if len(holds[holdKey(book.Key, "north")]) != 1 {
+		t.Errorf("north holds = %+v", holds[holdKey(book.Key, "north")])
+	}
+
+	branches, _ := m.getBranches(ctx)
+	if len(branches) != 2 || branches[0].Code != "central" || branches[0].OpeningHours["monday"] != "09:00-17:00" {
+		t.Errorf("getBranches() = %+v", branches)
+	}
+}
+
+func Test_pickupSlots(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 1, 20, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	m := &persistentModule{
+		cfg: &config.GlobalConfig{
+			Branches: []config.Branch{
+				{Code: "east", OpeningHours: map[string]string{"monday": "09:00-11:30"}, SlotMinutes: 60, SlotCapacity: 1},
+			},
+		},
+	}
+	book := domain.Book{Key: "/works/OL166894W", Title: "Charlie and the Chocolate Factory"}
+
+	tests := []struct {
+		name     string
+		req      domain.BorrowBookReq
+		wantSlot string
+		want     error
+	}{
+		{name: "chosen slot", req: domain.BorrowBookReq{Book: book, UserID: 501, Branch: "east", PickUpDate: "2022-01-24", PickUpSlot: "09:00"}, wantSlot: "09:00"},
+		{name: "chosen slot full", req: domain.BorrowBookReq{Book: book, UserID: 502, Branch: "east", PickUpDate: "2022-01-24", PickUpSlot: "09:00"}, want: domain.ErrPickupSlotFull},
+		{name: "unknown slot", req: domain.BorrowBookReq{Book: book, UserID: 502, Branch: "east", PickUpDate: "2022-01-24", PickUpSlot: "09:30"}, want: domain.ErrInvalidPickUpSlot},
+		{name: "first free slot", req: domain.BorrowBookReq{Book: book, UserID: 502, Branch: "east", PickUpDate: "2022-01-24"}, wantSlot: "10:00"},
+		{name: "all slots full", req: domain.BorrowBookReq{Book: book, UserID: 503, Branch: "east", PickUpDate: "2022-01-24"}, want: domain.ErrPickupSlotFull},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.borrowBook(ctx, tt.req)
+			if err != tt.want {
+				t.Fatalf("borrowBook() error = %v, want %v", err, tt.want)
+			}
+			if got.PickUpSlot != tt.wantSlot {
+				t.Errorf("borrowBook() slot = %v, want %v", got.PickUpSlot, tt.wantSlot)
+			}
+		})
+	}
+
+	got, err := m.getPickupSlots(ctx, domain.GetPickupSlotsReq{Branch: "east", Date: "2022-01-24"})
+	want := []domain.PickupSlot{
+		{Start: "09:00", End: "10:00", Capacity: 1, Booked: 1},
+		{Start: "10:00", End: "11:00", Capacity: 1, Booked: 1},
+	}
+	if err != nil || len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("getPickupSlots() = %+v, %v, want %+v", got, err, want)
+	}
+	if _, err := m.getPickupSlots(ctx, domain.GetPickupSlotsReq{Branch: "east", Date: "2022-01-25"}); err != domain.ErrBranchClosed {
+		t.Errorf("getPickupSlots() closed day error = %v", err)
+	}
+
+	// Cancelling gives the slot back.
+	if _, err := m.cancelReservation(ctx, domain.CancelReservationReq{ID: books[501][len(books[501])-1].ID, UserID: 501}); err != nil {
+		t.Fatalf("cancelReservation() error = %v", err)
+	}
+	if res, err := m.borrowBook(ctx, domain.BorrowBookReq{Book: book, UserID: 503, Branch: "east", PickUpDate: "2022-01-24"}); err != nil || res.PickUpSlot != "09:00" {
+		t.Errorf("borrowBook() after cancel = %+v, %v", res, err)
+	}
+}
+
+func Test_promoteHoldsPickup(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 1, 21, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	m := &persistentModule{
+		cfg: &config.GlobalConfig{
+			Reservation: config.Reservation{CopiesPerWork: 1},
+			Branches: []config.Branch{
+				{
+					Code: "east",
+					OpeningHours: map[string]string{
+						"monday":    "09:00-17:00",
+						"tuesday":   "09:00-17:00",
+						"wednesday": "09:00-17:00",
+						"thursday":  "09:00-17:00",
+						"friday":    "09:00-17:00",
+					},
+					ClosedDates:    []string{"2022-01-24"},
+					PickupCapacity: 1,
+				},
+			},
+		},
+	}
+	book := domain.Book{Key: "/works/OL82548W", Title: "Danny the Champion of the World"}
+	other := domain.Book{Key: "/works/OL82549W", Title: "Boy"}
+
+	if _, err := m.borrowBook(ctx, domain.BorrowBookReq{Book: book, UserID: 411, Branch: "east", PickUpDate: "2022-01-25"}); err != nil {
+		t.Fatalf("borrowBook() error = %v", err)
+	}
+	if _, err := m.borrowBook(ctx, domain.BorrowBookReq{Book: other, UserID: 414, Branch: "east", PickUpDate: "2022-01-21"}); err != nil {
+		t.Fatalf("borrowBook() other error = %v", err)
+	}
+	if _, err := m.joinWaitlist(ctx, domain.JoinWaitlistReq{Book: book, UserID: 413, Branch: "east"}); err != nil {
+		t.Fatalf("joinWaitlist() error = %v", err)
+	}
+
+	// Today is full, the weekend and Monday are closed, so the copy is offered for Tuesday.
+	if _, err := m.cancelReservation(ctx, domain.CancelReservationReq{ID: books[411][len(books[411])-1].ID, UserID: 411}); err != nil {
+		t.Fatalf("cancelReservation() error = %v", err)
+	}
+	got := books[413][len(books[413])-1]
+	if got.Status != domain.ReservationStatusProvisional || got.PickUpDate != "2022-01-25" {
+		t.Errorf("promoted reservation = %+v, want pickup on 2022-01-25", got)
+	}
+}
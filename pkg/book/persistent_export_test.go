@@ -0,0 +1,66 @@
+package book
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+func Test_getReservationPage(t *testing.T) {
+	mu.Lock()
+	savedBooks, savedHolds, savedIndex := books, holds, reservationIndex
+	emma := domain.Book{Key: "/works/OL66554W", Title: "Emma", Authors: []domain.Author{{Name: "Jane Austen"}}}
+	dune := domain.Book{Key: "/works/OL893415W", Title: "Dune", Authors: []domain.Author{{Name: "Frank Herbert"}}}
+	active, cancelled := domain.ReservationStatusActive, domain.ReservationStatusCancelled
+	books = map[int][]domain.BorrowBookReq{
+		1: {{ID: 2, Book: emma, Branch: "north", PickUpDate: "2022-01-22", UserID: 1, Status: active}, {ID: 5, Book: dune, Branch: "central", PickUpDate: "2022-01-25", UserID: 1, Status: active}},
+		2: {{ID: 1, Book: emma, Branch: "central", PickUpDate: "2022-01-21", UserID: 2, Status: cancelled}, {ID: 4, Book: emma, Branch: "central", PickUpDate: "2022-01-24", UserID: 2, Status: active}},
+		3: {{ID: 3, Book: dune, Branch: "central", PickUpDate: "2022-01-23", UserID: 3, Status: cancelled}},
+	}
+	reservationIndex = []reservationRef{{userID: 2, pos: 0}, {userID: 1, pos: 0}, {userID: 3, pos: 0}, {userID: 2, pos: 1}, {userID: 1, pos: 1}}
+	holds = map[string][]domain.Hold{}
+	mu.Unlock()
+	defer func() {
+		mu.Lock()
+		books, holds, reservationIndex = savedBooks, savedHolds, savedIndex
+		mu.Unlock()
+	}()
+
+	tests := []struct {
+		name string
+		req  domain.GetReservationPageReq
+		want []int
+	}{
+		{name: "first page", req: domain.GetReservationPageReq{Limit: 2}, want: []int{1, 2}},
+		{name: "next page", req: domain.GetReservationPageReq{AfterID: 2, Limit: 2}, want: []int{3, 4}},
+		{name: "last page", req: domain.GetReservationPageReq{AfterID: 4, Limit: 2}, want: []int{5}},
+		{name: "past the end", req: domain.GetReservationPageReq{AfterID: 5, Limit: 2}, want: []int{}},
+		{name: "one user", req: domain.GetReservationPageReq{UserID: 1}, want: []int{2, 5}},
+		{name: "one user next page", req: domain.GetReservationPageReq{UserID: 1, AfterID: 2}, want: []int{5}},
+		{name: "one branch", req: domain.GetReservationPageReq{Branch: "central", AfterID: 1, Limit: 2}, want: []int{3, 4}},
+		{name: "status", req: domain.GetReservationPageReq{Status: active, Limit: 2}, want: []int{2, 4}},
+		{name: "work key", req: domain.GetReservationPageReq{Key: dune.Key}, want: []int{3, 5}},
+		{name: "search author ignoring case", req: domain.GetReservationPageReq{Query: "AUSTEN"}, want: []int{1, 2, 4}},
+		{name: "search title", req: domain.GetReservationPageReq{Query: "dun"}, want: []int{3, 5}},
+		{name: "pickup dates", req: domain.GetReservationPageReq{From: "2022-01-22", To: "2022-01-24"}, want: []int{2, 3, 4}},
+		{name: "filters combined", req: domain.GetReservationPageReq{UserID: 2, Status: active, Query: "emma"}, want: []int{4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &persistentModule{}
+			got, err := m.getReservationPage(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("getReservationPage() error = %v", err)
+			}
+			ids := []int{}
+			for _, item := range got {
+				ids = append(ids, item.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.want) {
+				t.Errorf("getReservationPage() = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package book
+
+import (
+	"context"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+var (
+	fines           map[int][]domain.FineEntry = make(map[int][]domain.FineEntry)
+	lastFineEntryID int
+)
+
+func (m *persistentModule) getFines(ctx context.Context, req domain.GetFinesReq) (domain.FineLedger, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return m.fineLedger(req.UserID, timeNow()), nil
+}
+
+// settleFines records a payment against the user's outstanding balance.
+func (m *persistentModule) settleFines(ctx context.Context, req domain.SettleFinesReq) (domain.FineLedger, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	now := timeNow()
+	if req.Currency != "" && req.Currency != m.cfg.Fine.Currency {
+		return domain.FineLedger{}, domain.ErrCurrencyMismatch
+	}
+	if req.Amount <= 0 || req.Amount > fineBalance(req.UserID) {
+		return domain.FineLedger{}, domain.ErrInvalidAmount
+	}
+
+	m.addFineEntry(domain.FineEntry{
+		UserID: req.UserID,
+		Type:   domain.FineEntryPayment,
+		Amount: -req.Amount,
+	}, now)
+	return m.fineLedger(req.UserID, now), nil
+}
+
+// waiveFine credits back part or, when no amount is given, all of what is still owed for a charge.
+func (m *persistentModule) waiveFine(ctx context.Context, req domain.WaiveFineReq) (domain.FineLedger, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	now := timeNow()
+	charge := findFineCharge(req.FineID)
+	if charge == nil {
+		return domain.FineLedger{}, domain.ErrFineNotFound
+	}
+
+	waivable := charge.Amount
+	for _, entry := range fines[charge.UserID] {
+		if entry.Type == domain.FineEntryWaiver && entry.FineID == charge.ID {
+			waivable += entry.Amount
+		}
+	}
+	if balance := fineBalance(charge.UserID); balance < waivable {
+		waivable = balance
+	}
+
+	amount := req.Amount
+	if amount == 0 {
+		amount = waivable
+	}
+	if amount <= 0 || amount > waivable {
+		return domain.FineLedger{}, domain.ErrInvalidAmount
+	}
+
+	m.addFineEntry(domain.FineEntry{
+		UserID: charge.UserID,
+		LoanID: charge.LoanID,
+		FineID: charge.ID,
+		Type:   domain.FineEntryWaiver,
+		Amount: -amount,
+		Note:   req.Note,
+	}, now)
+	return m.fineLedger(charge.UserID, now), nil
+}
+
+// chargeOverdueFine books the fine for a loan returned at returnedAt, if any. Callers must hold mu.
+func (m *persistentModule) chargeOverdueFine(loan domain.Loan, returnedAt time.Time) {
+	amount := m.overdueFine(loan, returnedAt)
+	if amount <= 0 {
+		return
+	}
+	m.addFineEntry(domain.FineEntry{
+		UserID: loan.UserID,
+		LoanID: loan.ID,
+		Type:   domain.FineEntryCharge,
+		Amount: amount,
+		Note:   "Overdue " + loan.Book.Title,
+	}, returnedAt)
+}
+
+// overdueFine charges the daily rate for every started day past the due date and grace period, up to the cap.
+func (m *persistentModule) overdueFine(loan domain.Loan, at time.Time) int64 {
+	late := at.Sub(loan.DueDate)
+	if late <= 0 {
+		return 0
+	}
+
+	days := int64((late + 24*time.Hour - 1) / (24 * time.Hour))
+	days -= int64(m.cfg.Fine.GraceDays)
+	if days <= 0 {
+		return 0
+	}
+
+	amount := days * m.cfg.Fine.DailyRate
+	if m.cfg.Fine.MaxPerItem > 0 && amount > m.cfg.Fine.MaxPerItem {
+		amount = m.cfg.Fine.MaxPerItem
+	}
+	return amount
+}
+
+// fineLedger builds the ledger of a user, accruing covers loans that are overdue but not returned yet.
+// Callers must hold mu.
+func (m *persistentModule) fineLedger(userID int, now time.Time) domain.FineLedger {
+	ledger := domain.FineLedger{
+		UserID:   userID,
+		Currency: m.cfg.Fine.Currency,
+		Balance:  fineBalance(userID),
+		Entries:  append([]domain.FineEntry{}, fines[userID]...),
+	}
+	for _, loan := range loans[userID] {
+		if loan.Status == domain.LoanStatusOpen {
+			ledger.Accruing += m.overdueFine(loan, now)
+		}
+	}
+	return ledger
+}
+
+// addFineEntry stores entry under a new ID. Callers must hold mu.
+func (m *persistentModule) addFineEntry(entry domain.FineEntry, now time.Time) {
+	lastFineEntryID++
+	entry.ID = lastFineEntryID
+	entry.Currency = m.cfg.Fine.Currency
+	entry.CreatedAt = now
+	fines[entry.UserID] = append(fines[entry.UserID], entry)
+}
+
+// fineBalance is what the user still owes. Callers must hold mu.
+func fineBalance(userID int) int64 {
+	var balance int64
+	for _, entry := range fines[userID] {
+		balance += entry.Amount
+	}
+	return balance
+}
+
+// findFineCharge returns the charge with id. Callers must hold mu.
+func findFineCharge(id int) *domain.FineEntry {
+	for uid := range fines {
+		for i := range fines[uid] {
+			if fines[uid][i].ID == id && fines[uid][i].Type == domain.FineEntryCharge {
+				return &fines[uid][i]
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package book
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+func Test_overdueFine(t *testing.T) {
+	due := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	m := &persistentModule{
+		cfg: &config.GlobalConfig{
+			Fine: config.Fine{Currency: "USD", DailyRate: 25, GraceDays: 2, MaxPerItem: 100},
+		},
+	}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want int64
+	}{
+		{
+			name: "returned on time",
+			at:   due.Add(-time.Hour),
+			want: 0,
+		},
+		{
+			name: "within grace days",
+			at:   due.AddDate(0, 0, 2),
+			want: 0,
+		},
+		{
+			name: "started day counts",
+			at:   due.AddDate(0, 0, 2).Add(time.Minute),
+			want: 25,
+		},
+		{
+			name: "capped per item",
+			at:   due.AddDate(0, 0, 30),
+			want: 100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.overdueFine(domain.Loan{DueDate: due}, tt.at); got != tt.want {
+				t.Errorf("overdueFine() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_fines(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	const userID = 301
+	book := domain.Book{Key: "/works/OL1908641W", Title: "Love Poems"}
+	m := &persistentModule{
+		cfg: &config.GlobalConfig{
+			Loan: config.Loan{PeriodDays: 7},
+			Fine: config.Fine{Currency: "USD", DailyRate: 25, GraceDays: 1, MaxPerItem: 1000},
+		},
+	}
+
+	if _, err := m.borrowBook(ctx, domain.BorrowBookReq{Book: book, UserID: userID, PickUpDate: "2022-03-01"}); err != nil {
+		t.Fatalf("borrowBook() error = %v", err)
+	}
+	loan, err := m.checkoutBook(ctx, domain.CheckoutBookReq{ReservationID: books[userID][0].ID})
+	if err != nil {
+		t.Fatalf("checkoutBook() error = %v", err)
+	}
+
+	// Five days late, one of them is grace.
+	now = loan.DueDate.AddDate(0, 0, 5)
+	if got, _ := m.getFines(ctx, domain.GetFinesReq{UserID: userID}); got.Balance != 0 || got.Accruing != 100 {
+		t.Errorf("getFines() before return = %+v", got)
+	}
+	if _, err := m.returnBook(ctx, domain.ReturnBookReq{LoanID: loan.ID}); err != nil {
+		t.Fatalf("returnBook() error = %v", err)
+	}
+	ledger, _ := m.getFines(ctx, domain.GetFinesReq{UserID: userID})
+	if ledger.Balance != 100 || ledger.Accruing != 0 || ledger.Currency != "USD" || len(ledger.Entries) != 1 {
+		t.Fatalf("getFines() after return = %+v", ledger)
+	}
+	charge := ledger.Entries[0]
+
+	if _, err := m.waiveFine(ctx, domain.WaiveFineReq{FineID: -1}); err != domain.ErrFineNotFound {
+		t.Errorf("waiveFine() unknown fine error = %v", err)
+	}
+	if _, err := m.waiveFine(ctx, domain.WaiveFineReq{FineID: charge.ID, Amount: 101}); err != domain.ErrInvalidAmount {
+		t.Errorf("waiveFine() above fine error = %v", err)
+	}
+	if ledger, err = m.waiveFine(ctx, domain.WaiveFineReq{FineID: charge.ID, Amount: 40, Note: "first offence"}); err != nil || ledger.Balance != 60 {
+		t.Fatalf("waiveFine() = %+v, %v", ledger, err)
+	}
+
+	if _, err := m.settleFines(ctx, domain.SettleFinesReq{UserID: userID, Amount: 10, Currency: "EUR"}); err != domain.ErrCurrencyMismatch {
+		t.Errorf("settleFines() other currency error = %v", err)
+	}
+	if _, err := m.settleFines(ctx, domain.SettleFinesReq{UserID: userID, Amount: 61}); err != domain.ErrInvalidAmount {
+		t.Errorf("settleFines() above balance error = %v", err)
+	}
+	if ledger, err = m.settleFines(ctx, domain.SettleFinesReq{UserID: userID, Amount: 50, Currency: "USD"}); err != nil || ledger.Balance != 10 {
+		t.Fatalf("settleFines() = %+v, %v", ledger, err)
+	}
+
+	// Only what is still owed can be waived.
+	if ledger, err = m.waiveFine(ctx, domain.WaiveFineReq{FineID: charge.ID}); err != nil || ledger.Balance != 0 || len(ledger.Entries) != 4 {
+		t.Errorf("waiveFine() rest = %+v, %v", ledger, err)
+	}
+}
@@ -0,0 +1,146 @@
+package book
+
+import (
+	"context"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+const defaultLoanPeriodDays = 14
+
+// checkoutBook records the pickup of an active reservation and opens a loan due one loan period later.
+func (m *persistentModule) checkoutBook(ctx context.Context, req domain.CheckoutBookReq) (domain.Loan, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	now := timeNow()
+	m.releaseExpiredHolds(now)
+
+	res := findReservation(req.ReservationID, 0)
+	if res == nil {
+		return domain.Loan{}, domain.ErrReservationNotFound
+	}
+	if res.Status != domain.ReservationStatusActive {
+		return domain.Loan{}, domain.ErrInvalidReservationState
+	}
+	res.Status = domain.ReservationStatusPickedUp
+	recordEvent(domain.EventReservationPickedUp, *res, now)
+
+	lastLoanID++
+	loan := domain.Loan{
+		ID:            lastLoanID,
+		ReservationID: res.ID,
+		Book:          res.Book,
+		Branch:        res.Branch,
+		UserID:        res.UserID,
+		CheckedOutAt:  now,
+		DueDate:       now.Add(m.loanPeriod()),
+		Status:        domain.LoanStatusOpen,
+	}
+	loans[loan.UserID] = append(loans[loan.UserID], loan)
+	return loan, nil
+}
+
+// returnBook closes a loan, charges any overdue fine and hands the copy to the next user in the work's hold queue.
+func (m *persistentModule) returnBook(ctx context.Context, req domain.ReturnBookReq) (domain.Loan, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	now := timeNow()
+	loan, err := findOpenLoan(req.LoanID)
+	if err != nil {
+		return domain.Loan{}, err
+	}
+
+	loan.Status = domain.LoanStatusReturned
+	loan.ReturnedAt = now
+	m.chargeOverdueFine(*loan, now)
+	if res := findReservation(loan.ReservationID, loan.UserID); res != nil {
+		res.Status = domain.ReservationStatusReturned
+		recordEvent(domain.EventReservationReturned, *res, now)
+	}
+	m.promoteHolds(loan.Book.Key, loan.Branch, now)
+	return *loan, nil
+}
+
+// renewLoan extends the due date by one loan period unless the loan is overdue, the work is held or the renewal
+// limit is reached. Overdue loans are returned instead, so the fine they accrued is charged.
+func (m *persistentModule) renewLoan(ctx context.Context, req domain.RenewLoanReq) (domain.Loan, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	now := timeNow()
+	m.releaseExpiredHolds(now)
+
+	loan, err := findOpenLoan(req.LoanID)
+	if err != nil {
+		return domain.Loan{}, err
+	}
+	if now.After(loan.DueDate) {
+		return domain.Loan{}, domain.ErrLoanOverdue
+	}
+	if loan.Renewals >= m.cfg.Loan.MaxRenewals {
+		return domain.Loan{}, domain.ErrRenewalLimitReached
+	}
+	if len(holds[holdKey(loan.Book.Key, loan.Branch)]) > 0 || hasProvisional(loan.Book.Key, loan.Branch) {
+		return domain.Loan{}, domain.ErrWorkOnHold
+	}
+
+	loan.Renewals++
+	loan.DueDate = loan.DueDate.Add(m.loanPeriod())
+	return *loan, nil
+}
+
+func (m *persistentModule) getLoans(ctx context.Context, req domain.GetLoansReq) (map[int][]domain.Loan, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	result := make(map[int][]domain.Loan)
+	if req.UserID != 0 {
+		result[req.UserID] = append([]domain.Loan(nil), loans[req.UserID]...)
+		return result, nil
+	}
+	for uid, items := range loans {
+		result[uid] = append([]domain.Loan(nil), items...)
+	}
+	return result, nil
+}
+
+func (m *persistentModule) loanPeriod() time.Duration {
+	days := m.cfg.Loan.PeriodDays
+	if days <= 0 {
+		days = defaultLoanPeriodDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// findOpenLoan returns the stored loan with id while it is still open. Callers must hold mu.
+func findOpenLoan(id int) (*domain.Loan, error) {
+	for uid := range loans {
+		for i := range loans[uid] {
+			loan := &loans[uid][i]
+			if loan.ID != id {
+				continue
+			}
+			if loan.Status != domain.LoanStatusOpen {
+				return nil, domain.ErrLoanClosed
+			}
+			return loan, nil
+		}
+	}
+	return nil, domain.ErrLoanNotFound
+}
+
+// hasProvisional reports whether a copy of the work at a branch is waiting to be confirmed by a user from the queue.
+// Callers must hold mu.
+func hasProvisional(key, branch string) bool {
+	for _, items := range books {
+		for _, item := range items {
+			if item.Book.Key == key && item.Branch == branch && item.Status == domain.ReservationStatusProvisional {
+				return true
+			}
+		}
+	}
+	return false
+}
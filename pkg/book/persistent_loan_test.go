@@ -0,0 +1,122 @@
+package book
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+func Test_loans(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 2, 1, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	book := domain.Book{Key: "/works/OL138052W", Title: "The Hobbit"}
+	m := &persistentModule{
+		cfg: &config.GlobalConfig{
+			Reservation: config.Reservation{CopiesPerWork: 1},
+			Loan:        config.Loan{PeriodDays: 7, MaxRenewals: 1},
+		},
+	}
+
+	if _, err := m.borrowBook(ctx, domain.BorrowBookReq{Book: book, UserID: 201, PickUpDate: "2022-02-01"}); err != nil {
+		t.Fatalf("borrowBook() error = %v", err)
+	}
+	reservation := books[201][len(books[201])-1]
+
+	if _, err := m.checkoutBook(ctx, domain.CheckoutBookReq{ReservationID: -1}); err != domain.ErrReservationNotFound {
+		t.Errorf("checkoutBook() unknown reservation error = %v", err)
+	}
+	loan, err := m.checkoutBook(ctx, domain.CheckoutBookReq{ReservationID: reservation.ID})
+	if err != nil {
+		t.Fatalf("checkoutBook() error = %v", err)
+	}
+	if loan.UserID != 201 || loan.Status != domain.LoanStatusOpen || !loan.DueDate.Equal(now.AddDate(0, 0, 7)) {
+		t.Errorf("checkoutBook() = %+v", loan)
+	}
+	if _, err := m.checkoutBook(ctx, domain.CheckoutBookReq{ReservationID: reservation.ID}); err != domain.ErrInvalidReservationState {
+		t.Errorf("checkoutBook() twice error = %v", err)
+	}
+
+	// The copy is out on loan, so the work stays fully reserved.
+	if _, err := m.borrowBook(ctx, domain.BorrowBookReq{Book: book, UserID: 202}); err != domain.ErrFullyReserved {
+		t.Errorf("borrowBook() while on loan error = %v", err)
+	}
+
+	renewed, err := m.renewLoan(ctx, domain.RenewLoanReq{LoanID: loan.ID})
+	if err != nil || renewed.Renewals != 1 || !renewed.DueDate.Equal(now.AddDate(0, 0, 14)) {
+		t.Fatalf("renewLoan() = %+v, %v", renewed, err)
+	}
+	if _, err := m.renewLoan(ctx, domain.RenewLoanReq{LoanID: loan.ID}); err != domain.ErrRenewalLimitReached {
+		t.Errorf("renewLoan() over limit error = %v", err)
+	}
+
+	m.cfg.Loan.MaxRenewals = 5
+	if _, err := m.joinWaitlist(ctx, domain.JoinWaitlistReq{Book: book, UserID: 202, PickUpDate: "2022-02-20"}); err != nil {
+		t.Fatalf("joinWaitlist() error = %v", err)
+	}
+	if _, err := m.renewLoan(ctx, domain.RenewLoanReq{LoanID: loan.ID}); err != domain.ErrWorkOnHold {
+		t.Errorf("renewLoan() with hold error = %v", err)
+	}
+
+	returned, err := m.returnBook(ctx, domain.ReturnBookReq{LoanID: loan.ID})
+	if err != nil || returned.Status != domain.LoanStatusReturned || !returned.ReturnedAt.Equal(now) {
+		t.Fatalf("returnBook() = %+v, %v", returned, err)
+	}
+	if _, err := m.returnBook(ctx, domain.ReturnBookReq{LoanID: loan.ID}); err != domain.ErrLoanClosed {
+		t.Errorf("returnBook() twice error = %v", err)
+	}
+	if _, err := m.returnBook(ctx, domain.ReturnBookReq{LoanID: -1}); err != domain.ErrLoanNotFound {
+		t.Errorf("returnBook() unknown loan error = %v", err)
+	}
+	if got := books[202][len(books[202])-1]; got.Status != domain.ReservationStatusProvisional {
+		t.Errorf("next hold after return = %+v", got)
+	}
+
+	got, _ := m.getLoans(ctx, domain.GetLoansReq{UserID: 201})
+	if len(got[201]) != 1 || got[201][0].Status != domain.LoanStatusReturned {
+		t.Errorf("getLoans() = %+v", got)
+	}
+}
+
+func Test_renewOverdueLoan(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	book := domain.Book{Key: "/works/OL27448W", Title: "The Lord of the Rings"}
+	m := &persistentModule{
+		cfg: &config.GlobalConfig{
+			Reservation: config.Reservation{CopiesPerWork: 1},
+			Loan:        config.Loan{PeriodDays: 7, MaxRenewals: 2},
+			Fine:        config.Fine{DailyRate: 25},
+		},
+	}
+
+	if _, err := m.borrowBook(ctx, domain.BorrowBookReq{Book: book, UserID: 211, PickUpDate: "2022-03-01"}); err != nil {
+		t.Fatalf("borrowBook() error = %v", err)
+	}
+	loan, err := m.checkoutBook(ctx, domain.CheckoutBookReq{ReservationID: books[211][len(books[211])-1].ID})
+	if err != nil {
+		t.Fatalf("checkoutBook() error = %v", err)
+	}
+
+	now = loan.DueDate.Add(time.Hour)
+	if _, err := m.renewLoan(ctx, domain.RenewLoanReq{LoanID: loan.ID}); err != domain.ErrLoanOverdue {
+		t.Errorf("renewLoan() overdue error = %v", err)
+	}
+	if ledger, _ := m.getFines(ctx, domain.GetFinesReq{UserID: 211}); ledger.Accruing != 25 {
+		t.Errorf("getFines() accruing = %v, want 25", ledger.Accruing)
+	}
+	if _, err := m.returnBook(ctx, domain.ReturnBookReq{LoanID: loan.ID}); err != nil {
+		t.Fatalf("returnBook() error = %v", err)
+	}
+	if ledger, _ := m.getFines(ctx, domain.GetFinesReq{UserID: 211}); ledger.Balance != 25 {
+		t.Errorf("getFines() balance = %v, want 25", ledger.Balance)
+	}
+}
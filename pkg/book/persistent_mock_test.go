@@ -35,20 +35,169 @@ func (m *Mockpersistent) EXPECT() *MockpersistentMockRecorder {
 	return m.recorder
 }
 
-// borrowBook mocks base method.
-func (m *Mockpersistent) borrowBook(ctx context.Context, req domain.BorrowBookReq) error {
+// ackOutbox mocks base method.
+func (m *Mockpersistent) ackOutbox(ctx context.Context, req domain.AckOutboxReq) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "borrowBook", ctx, req)
+	ret := m.ctrl.Call(m, "ackOutbox", ctx, req)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// ackOutbox indicates an expected call of ackOutbox.
+func (mr *MockpersistentMockRecorder) ackOutbox(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ackOutbox", reflect.TypeOf((*Mockpersistent)(nil).ackOutbox), ctx, req)
+}
+
+// acquireLease mocks base method.
+func (m *Mockpersistent) acquireLease(ctx context.Context, req domain.AcquireLeaseReq) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "acquireLease", ctx, req)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// acquireLease indicates an expected call of acquireLease.
+func (mr *MockpersistentMockRecorder) acquireLease(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "acquireLease", reflect.TypeOf((*Mockpersistent)(nil).acquireLease), ctx, req)
+}
+
+// borrowBook mocks base method.
+func (m *Mockpersistent) borrowBook(ctx context.Context, req domain.BorrowBookReq) (domain.BorrowBookReq, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "borrowBook", ctx, req)
+	ret0, _ := ret[0].(domain.BorrowBookReq)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // borrowBook indicates an expected call of borrowBook.
 func (mr *MockpersistentMockRecorder) borrowBook(ctx, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "borrowBook", reflect.TypeOf((*Mockpersistent)(nil).borrowBook), ctx, req)
 }
 
+// borrowBooks mocks base method.
+func (m *Mockpersistent) borrowBooks(ctx context.Context, req []domain.BorrowBookReq) ([]domain.BorrowBookReq, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "borrowBooks", ctx, req)
+	ret0, _ := ret[0].([]domain.BorrowBookReq)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// borrowBooks indicates an expected call of borrowBooks.
+func (mr *MockpersistentMockRecorder) borrowBooks(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "borrowBooks", reflect.TypeOf((*Mockpersistent)(nil).borrowBooks), ctx, req)
+}
+
+// cancelReservation mocks base method.
+func (m *Mockpersistent) cancelReservation(ctx context.Context, req domain.CancelReservationReq) (domain.BorrowBookReq, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "cancelReservation", ctx, req)
+	ret0, _ := ret[0].(domain.BorrowBookReq)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// cancelReservation indicates an expected call of cancelReservation.
+func (mr *MockpersistentMockRecorder) cancelReservation(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "cancelReservation", reflect.TypeOf((*Mockpersistent)(nil).cancelReservation), ctx, req)
+}
+
+// checkoutBook mocks base method.
+func (m *Mockpersistent) checkoutBook(ctx context.Context, req domain.CheckoutBookReq) (domain.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "checkoutBook", ctx, req)
+	ret0, _ := ret[0].(domain.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// checkoutBook indicates an expected call of checkoutBook.
+func (mr *MockpersistentMockRecorder) checkoutBook(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "checkoutBook", reflect.TypeOf((*Mockpersistent)(nil).checkoutBook), ctx, req)
+}
+
+// claimOutbox mocks base method.
+func (m *Mockpersistent) claimOutbox(ctx context.Context, req domain.ClaimOutboxReq) ([]domain.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "claimOutbox", ctx, req)
+	ret0, _ := ret[0].([]domain.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// claimOutbox indicates an expected call of claimOutbox.
+func (mr *MockpersistentMockRecorder) claimOutbox(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "claimOutbox", reflect.TypeOf((*Mockpersistent)(nil).claimOutbox), ctx, req)
+}
+
+// claimReminders mocks base method.
+func (m *Mockpersistent) claimReminders(ctx context.Context, req domain.ClaimRemindersReq) ([]domain.BorrowBookReq, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "claimReminders", ctx, req)
+	ret0, _ := ret[0].([]domain.BorrowBookReq)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// claimReminders indicates an expected call of claimReminders.
+func (mr *MockpersistentMockRecorder) claimReminders(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "claimReminders", reflect.TypeOf((*Mockpersistent)(nil).claimReminders), ctx, req)
+}
+
+// confirmReservation mocks base method.
+func (m *Mockpersistent) confirmReservation(ctx context.Context, req domain.ConfirmReservationReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "confirmReservation", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// confirmReservation indicates an expected call of confirmReservation.
+func (mr *MockpersistentMockRecorder) confirmReservation(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "confirmReservation", reflect.TypeOf((*Mockpersistent)(nil).confirmReservation), ctx, req)
+}
+
+// expireUncollected mocks base method.
+func (m *Mockpersistent) expireUncollected(ctx context.Context, req domain.ExpireUncollectedReq) ([]domain.BorrowBookReq, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "expireUncollected", ctx, req)
+	ret0, _ := ret[0].([]domain.BorrowBookReq)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// expireUncollected indicates an expected call of expireUncollected.
+func (mr *MockpersistentMockRecorder) expireUncollected(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "expireUncollected", reflect.TypeOf((*Mockpersistent)(nil).expireUncollected), ctx, req)
+}
+
+// getAvailability mocks base method.
+func (m *Mockpersistent) getAvailability(ctx context.Context, req domain.GetAvailabilityReq) ([]domain.BranchAvailability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getAvailability", ctx, req)
+	ret0, _ := ret[0].([]domain.BranchAvailability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getAvailability indicates an expected call of getAvailability.
+func (mr *MockpersistentMockRecorder) getAvailability(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getAvailability", reflect.TypeOf((*Mockpersistent)(nil).getAvailability), ctx, req)
+}
+
 // getBookReservation mocks base method.
 func (m *Mockpersistent) getBookReservation(ctx context.Context, req domain.GetBookReservationReq) (map[int][]domain.BorrowBookReq, error) {
 	m.ctrl.T.Helper()
@@ -62,4 +211,198 @@ func (m *Mockpersistent) getBookReservation(ctx context.Context, req domain.GetB
 func (mr *MockpersistentMockRecorder) getBookReservation(ctx, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getBookReservation", reflect.TypeOf((*Mockpersistent)(nil).getBookReservation), ctx, req)
+}
+
+// getBranches mocks base method.
+func (m *Mockpersistent) getBranches(ctx context.Context) ([]domain.Branch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getBranches", ctx)
+	ret0, _ := ret[0].([]domain.Branch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getBranches indicates an expected call of getBranches.
+func (mr *MockpersistentMockRecorder) getBranches(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getBranches", reflect.TypeOf((*Mockpersistent)(nil).getBranches), ctx)
+}
+
+// getFines mocks base method.
+func (m *Mockpersistent) getFines(ctx context.Context, req domain.GetFinesReq) (domain.FineLedger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getFines", ctx, req)
+	ret0, _ := ret[0].(domain.FineLedger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getFines indicates an expected call of getFines.
+func (mr *MockpersistentMockRecorder) getFines(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getFines", reflect.TypeOf((*Mockpersistent)(nil).getFines), ctx, req)
+}
+
+// getLoans mocks base method.
+func (m *Mockpersistent) getLoans(ctx context.Context, req domain.GetLoansReq) (map[int][]domain.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getLoans", ctx, req)
+	ret0, _ := ret[0].(map[int][]domain.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getLoans indicates an expected call of getLoans.
+func (mr *MockpersistentMockRecorder) getLoans(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getLoans", reflect.TypeOf((*Mockpersistent)(nil).getLoans), ctx, req)
+}
+
+// getOutbox mocks base method.
+func (m *Mockpersistent) getOutbox(ctx context.Context, req domain.GetOutboxReq) ([]domain.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getOutbox", ctx, req)
+	ret0, _ := ret[0].([]domain.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getOutbox indicates an expected call of getOutbox.
+func (mr *MockpersistentMockRecorder) getOutbox(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getOutbox", reflect.TypeOf((*Mockpersistent)(nil).getOutbox), ctx, req)
+}
+
+// getPickupSlots mocks base method.
+func (m *Mockpersistent) getPickupSlots(ctx context.Context, req domain.GetPickupSlotsReq) ([]domain.PickupSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getPickupSlots", ctx, req)
+	ret0, _ := ret[0].([]domain.PickupSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getPickupSlots indicates an expected call of getPickupSlots.
+func (mr *MockpersistentMockRecorder) getPickupSlots(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getPickupSlots", reflect.TypeOf((*Mockpersistent)(nil).getPickupSlots), ctx, req)
+}
+
+// getReservationPage mocks base method.
+func (m *Mockpersistent) getReservationPage(ctx context.Context, req domain.GetReservationPageReq) ([]domain.BorrowBookReq, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getReservationPage", ctx, req)
+	ret0, _ := ret[0].([]domain.BorrowBookReq)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getReservationPage indicates an expected call of getReservationPage.
+func (mr *MockpersistentMockRecorder) getReservationPage(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getReservationPage", reflect.TypeOf((*Mockpersistent)(nil).getReservationPage), ctx, req)
+}
+
+// joinWaitlist mocks base method.
+func (m *Mockpersistent) joinWaitlist(ctx context.Context, req domain.JoinWaitlistReq) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "joinWaitlist", ctx, req)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// joinWaitlist indicates an expected call of joinWaitlist.
+func (mr *MockpersistentMockRecorder) joinWaitlist(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "joinWaitlist", reflect.TypeOf((*Mockpersistent)(nil).joinWaitlist), ctx, req)
+}
+
+// releaseReminder mocks base method.
+func (m *Mockpersistent) releaseReminder(ctx context.Context, req domain.ReleaseReminderReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "releaseReminder", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// releaseReminder indicates an expected call of releaseReminder.
+func (mr *MockpersistentMockRecorder) releaseReminder(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "releaseReminder", reflect.TypeOf((*Mockpersistent)(nil).releaseReminder), ctx, req)
+}
+
+// renewLoan mocks base method.
+func (m *Mockpersistent) renewLoan(ctx context.Context, req domain.RenewLoanReq) (domain.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "renewLoan", ctx, req)
+	ret0, _ := ret[0].(domain.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// renewLoan indicates an expected call of renewLoan.
+func (mr *MockpersistentMockRecorder) renewLoan(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "renewLoan", reflect.TypeOf((*Mockpersistent)(nil).renewLoan), ctx, req)
+}
+
+// requeueOutbox mocks base method.
+func (m *Mockpersistent) requeueOutbox(ctx context.Context, req domain.RequeueOutboxReq) (domain.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "requeueOutbox", ctx, req)
+	ret0, _ := ret[0].(domain.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// requeueOutbox indicates an expected call of requeueOutbox.
+func (mr *MockpersistentMockRecorder) requeueOutbox(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "requeueOutbox", reflect.TypeOf((*Mockpersistent)(nil).requeueOutbox), ctx, req)
+}
+
+// returnBook mocks base method.
+func (m *Mockpersistent) returnBook(ctx context.Context, req domain.ReturnBookReq) (domain.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "returnBook", ctx, req)
+	ret0, _ := ret[0].(domain.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// returnBook indicates an expected call of returnBook.
+func (mr *MockpersistentMockRecorder) returnBook(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "returnBook", reflect.TypeOf((*Mockpersistent)(nil).returnBook), ctx, req)
+}
+
+// settleFines mocks base method.
+func (m *Mockpersistent) settleFines(ctx context.Context, req domain.SettleFinesReq) (domain.FineLedger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "settleFines", ctx, req)
+	ret0, _ := ret[0].(domain.FineLedger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// settleFines indicates an expected call of settleFines.
+func (mr *MockpersistentMockRecorder) settleFines(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "settleFines", reflect.TypeOf((*Mockpersistent)(nil).settleFines), ctx, req)
+}
+
+// waiveFine mocks base method.
+func (m *Mockpersistent) waiveFine(ctx context.Context, req domain.WaiveFineReq) (domain.FineLedger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "waiveFine", ctx, req)
+	ret0, _ := ret[0].(domain.FineLedger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// waiveFine indicates an expected call of waiveFine.
+func (mr *MockpersistentMockRecorder) waiveFine(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "waiveFine", reflect.TypeOf((*Mockpersistent)(nil).waiveFine), ctx, req)
 }
\ No newline at end of file
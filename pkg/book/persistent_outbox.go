@@ -0,0 +1,153 @@
+package book
+
+import (
+	"context"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+const (
+	defaultOutboxRetentionHours = 72
+	defaultOutboxClaimLimit     = 50
+)
+
+// outbox is kept in process memory with the reservations, so pending events do not survive a restart. A SQL store
+// keeps them in a table written in the same transaction as the change.
+var (
+	outbox      []domain.OutboxEvent
+	lastEventID int
+)
+
+// recordEvent appends a reservation event to the outbox. It runs under the same lock as the change that caused
+// it, so either both are stored or neither is. Callers must hold mu.
+func recordEvent(eventType string, res domain.BorrowBookReq, now time.Time) {
+	lastEventID++
+	outbox = append(outbox, domain.OutboxEvent{
+		ID:            lastEventID,
+		Type:          eventType,
+		Reservation:   res,
+		Status:        domain.OutboxStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	})
+}
+
+// claimOutbox returns the oldest pending events that are due and keeps them from other dispatchers for req.Lease.
+// A dispatcher that dies before acknowledging an event leaves it to be claimed again once the lease runs out.
+func (m *persistentModule) claimOutbox(ctx context.Context, req domain.ClaimOutboxReq) ([]domain.OutboxEvent, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	now := timeNow()
+	m.pruneOutbox(now)
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultOutboxClaimLimit
+	}
+	claimed := []domain.OutboxEvent{}
+	for i := range outbox {
+		if len(claimed) == limit {
+			break
+		}
+		event := &outbox[i]
+		if event.Status != domain.OutboxStatusPending || event.NextAttemptAt.After(now) {
+			continue
+		}
+		event.NextAttemptAt = now.Add(req.Lease)
+		claimed = append(claimed, *event)
+	}
+	return claimed, nil
+}
+
+// ackOutbox records the outcome of one delivery attempt.
+func (m *persistentModule) ackOutbox(ctx context.Context, req domain.AckOutboxReq) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	event := findOutboxEvent(req.ID)
+	if event == nil {
+		return domain.ErrOutboxEventNotFound
+	}
+	if event.Status != domain.OutboxStatusPending {
+		return nil
+	}
+
+	event.Attempts++
+	if req.Error == "" {
+		event.Status = domain.OutboxStatusDelivered
+		event.DeliveredAt = timeNow()
+		event.LastError = ""
+		return nil
+	}
+	event.LastError = req.Error
+	if req.Dead {
+		event.Status = domain.OutboxStatusDead
+		return nil
+	}
+	event.NextAttemptAt = req.RetryAt
+	return nil
+}
+
+// getOutbox lists outbox events, limited to req.Status when it is set.
+func (m *persistentModule) getOutbox(ctx context.Context, req domain.GetOutboxReq) ([]domain.OutboxEvent, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	result := []domain.OutboxEvent{}
+	for _, event := range outbox {
+		if req.Status != "" && event.Status != req.Status {
+			continue
+		}
+		result = append(result, event)
+	}
+	return result, nil
+}
+
+// requeueOutbox gives a dead-lettered event a fresh set of attempts.
+func (m *persistentModule) requeueOutbox(ctx context.Context, req domain.RequeueOutboxReq) (domain.OutboxEvent, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	event := findOutboxEvent(req.ID)
+	if event == nil {
+		return domain.OutboxEvent{}, domain.ErrOutboxEventNotFound
+	}
+	if event.Status != domain.OutboxStatusDead {
+		return domain.OutboxEvent{}, domain.ErrOutboxEventNotDead
+	}
+
+	event.Status = domain.OutboxStatusPending
+	event.Attempts = 0
+	event.NextAttemptAt = timeNow()
+	return *event, nil
+}
+
+// pruneOutbox drops delivered events older than the retention period. Callers must hold mu.
+func (m *persistentModule) pruneOutbox(now time.Time) {
+	hours := m.cfg.Outbox.RetentionHours
+	if hours <= 0 {
+		hours = defaultOutboxRetentionHours
+	}
+	cutoff := now.Add(-time.Duration(hours) * time.Hour)
+
+	kept := outbox[:0]
+	for _, event := range outbox {
+		if event.Status == domain.OutboxStatusDelivered && event.DeliveredAt.Before(cutoff) {
+			continue
+		}
+		kept = append(kept, event)
+	}
+	outbox = kept
+}
+
+// findOutboxEvent returns the stored event with id. Callers must hold mu.
+func findOutboxEvent(id int) *domain.OutboxEvent {
+	for i := range outbox {
+		if outbox[i].ID == id {
+			return &outbox[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package book
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+func Test_outbox(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 5, 2, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	// Reservations left by other tests would record their own events as the clock moves.
+	mu.Lock()
+	savedBooks, savedHolds, savedOutbox := books, holds, outbox
+	books, holds, outbox = map[int][]domain.BorrowBookReq{}, map[string][]domain.Hold{}, nil
+	mu.Unlock()
+	defer func() {
+		mu.Lock()
+		books, holds, outbox = savedBooks, savedHolds, savedOutbox
+		mu.Unlock()
+	}()
+
+	m := &persistentModule{cfg: &config.GlobalConfig{}}
+	book := domain.Book{Key: "/works/OL45883W", Title: "Charlie and the Chocolate Factory"}
+
+	res, err := m.borrowBook(ctx, domain.BorrowBookReq{Book: book, UserID: 801, PickUpDate: "2022-05-03"})
+	if err != nil {
+		t.Fatalf("borrowBook() error = %v", err)
+	}
+	loan, err := m.checkoutBook(ctx, domain.CheckoutBookReq{ReservationID: res.ID})
+	if err != nil {
+		t.Fatalf("checkoutBook() error = %v", err)
+	}
+	if _, err := m.returnBook(ctx, domain.ReturnBookReq{LoanID: loan.ID}); err != nil {
+		t.Fatalf("returnBook() error = %v", err)
+	}
+	res, err = m.borrowBook(ctx, domain.BorrowBookReq{Book: book, UserID: 801, PickUpDate: "2022-05-04"})
+	if err != nil {
+		t.Fatalf("borrowBook() error = %v", err)
+	}
+	if _, err := m.cancelReservation(ctx, domain.CancelReservationReq{ID: res.ID, UserID: 801}); err != nil {
+		t.Fatalf("cancelReservation() error = %v", err)
+	}
+
+	events, _ := m.getOutbox(ctx, domain.GetOutboxReq{})
+	types := []string{}
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	want := []string{
+		domain.EventReservationCreated,
+		domain.EventReservationPickedUp,
+		domain.EventReservationReturned,
+		domain.EventReservationCreated,
+		domain.EventReservationCancelled,
+	}
+	if !reflect.DeepEqual(types, want) {
+		t.Fatalf("outbox types = %v, want %v", types, want)
+	}
+	if events[4].Reservation.Status != domain.ReservationStatusCancelled {
+		t.Errorf("cancelled event reservation status = %v", events[4].Reservation.Status)
+	}
+
+	claim := domain.ClaimOutboxReq{Limit: 3, Lease: time.Minute}
+	first, _ := m.claimOutbox(ctx, claim)
+	second, _ := m.claimOutbox(ctx, claim)
+	if len(first) != 3 || len(second) != 2 {
+		t.Fatalf("claimOutbox() = %d then %d events, want 3 then 2", len(first), len(second))
+	}
+	if again, _ := m.claimOutbox(ctx, claim); len(again) != 0 {
+		t.Errorf("claimOutbox() while leased = %d events, want 0", len(again))
+	}
+
+	m.ackOutbox(ctx, domain.AckOutboxReq{ID: first[0].ID})
+	m.ackOutbox(ctx, domain.AckOutboxReq{ID: first[1].ID, Error: "timeout", RetryAt: now.Add(time.Second)})
+	m.ackOutbox(ctx, domain.AckOutboxReq{ID: first[2].ID, Error: "timeout", Dead: true})
+	if err := m.ackOutbox(ctx, domain.AckOutboxReq{ID: 9999}); err != domain.ErrOutboxEventNotFound {
+		t.Errorf("ackOutbox() unknown error = %v", err)
+	}
+
+	// Both the retried event and the unacknowledged ones come back once their time is up.
+	now = now.Add(2 * time.Minute)
+	if retry, _ := m.claimOutbox(ctx, domain.ClaimOutboxReq{Lease: time.Minute}); len(retry) != 3 || retry[0].ID != first[1].ID || retry[0].Attempts != 1 {
+		t.Errorf("claimOutbox() after lease = %+v", retry)
+	}
+
+	dead, _ := m.getOutbox(ctx, domain.GetOutboxReq{Status: domain.OutboxStatusDead})
+	if len(dead) != 1 || dead[0].ID != first[2].ID || dead[0].LastError != "timeout" {
+		t.Fatalf("getOutbox() dead = %+v", dead)
+	}
+	if requeued, err := m.requeueOutbox(ctx, domain.RequeueOutboxReq{ID: dead[0].ID}); err != nil || requeued.Status != domain.OutboxStatusPending || requeued.Attempts != 0 {
+		t.Errorf("requeueOutbox() = %+v, %v", requeued, err)
+	}
+	if _, err := m.requeueOutbox(ctx, domain.RequeueOutboxReq{ID: first[0].ID}); err != domain.ErrOutboxEventNotDead {
+		t.Errorf("requeueOutbox() delivered error = %v", err)
+	}
+
+	now = now.Add(100 * time.Hour)
+	m.claimOutbox(ctx, claim)
+	if delivered, _ := m.getOutbox(ctx, domain.GetOutboxReq{Status: domain.OutboxStatusDelivered}); len(delivered) != 0 {
+		t.Errorf("delivered events after retention = %d, want 0", len(delivered))
+	}
+}
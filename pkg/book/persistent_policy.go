@@ -0,0 +1,143 @@
+package book
+
+import (
+	"fmt"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/catalogstore"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+// policyInput is what every borrowing policy gets to look at.
+type policyInput struct {
+	userID  int
+	book    domain.Book
+	subject string
+	current []domain.BorrowBookReq
+}
+
+type policyRule func(policy config.Policy, in policyInput) *domain.PolicyViolation
+
+var policyRules = []policyRule{
+	blockedUserPolicy,
+	maxActiveReservationsPolicy,
+	maxPerSubjectPolicy,
+	onePerWorkPolicy,
+}
+
+// checkPolicies runs every borrowing policy on a reservation of book for the user and denies it with a
+// *domain.PolicyError naming all that failed. It runs under the same lock that stores the reservation, so requests
+// of one user arriving together cannot both get past a limit. Callers must hold mu.
+func (m *persistentModule) checkPolicies(userID int, book domain.Book, subject string) error {
+	policy := m.cfg.Policy
+	if policy.MaxActiveReservations <= 0 && policy.MaxPerSubject <= 0 && !policy.OnePerWork && len(policy.BlockedUsers) == 0 {
+		return nil
+	}
+
+	in := policyInput{
+		userID:  userID,
+		book:    book,
+		subject: subject,
+	}
+	for _, item := range books[userID] {
+		if holdsCopy(item.Status) {
+			in.current = append(in.current, item)
+		}
+	}
+
+	var violations []domain.PolicyViolation
+	for _, rule := range policyRules {
+		if v := rule(policy, in); v != nil {
+			violations = append(violations, *v)
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return &domain.PolicyError{Violations: violations}
+}
+
+// nextPromotableHold returns the index of the first hold in queue the policies allow and that finds a pickup day,
+// with that day and slot, or -1. The service checked the policies when the user joined the waitlist, but the user
+// may have been blocked or reached a limit while waiting. Callers must hold mu.
+func (m *persistentModule) nextPromotableHold(queue []domain.Hold, now time.Time) (int, string, string) {
+	for i, hold := range queue {
+		if m.checkPolicies(hold.UserID, hold.Book, hold.Subject) != nil {
+			continue
+		}
+		if pickUpDate, slot, ok := m.holdPickup(hold, now); ok {
+			return i, pickUpDate, slot
+		}
+	}
+	return -1, "", ""
+}
+
+func blockedUserPolicy(policy config.Policy, in policyInput) *domain.PolicyViolation {
+	for _, uid := range policy.BlockedUsers {
+		if uid == in.userID {
+			return &domain.PolicyViolation{
+				Rule:   domain.PolicyBlockedUser,
+				Reason: "User is blocked from borrowing",
+			}
+		}
+	}
+	return nil
+}
+
+func maxActiveReservationsPolicy(policy config.Policy, in policyInput) *domain.PolicyViolation {
+	limit := policy.MaxActiveReservations
+	if limit <= 0 || len(in.current) < limit {
+		return nil
+	}
+	return &domain.PolicyViolation{
+		Rule:   domain.PolicyMaxActiveReservations,
+		Reason: fmt.Sprintf("User already has %d active reservations, the limit is %d", len(in.current), limit),
+	}
+}
+
+func maxPerSubjectPolicy(policy config.Policy, in policyInput) *domain.PolicyViolation {
+	limit := policy.MaxPerSubject
+	if limit <= 0 || in.subject == "" {
+		return nil
+	}
+
+	count := 0
+	subject := catalogstore.SubjectSlug(in.subject)
+	for _, item := range in.current {
+		if catalogstore.SubjectSlug(item.Subject) == subject {
+			count++
+		}
+	}
+	if count < limit {
+		return nil
+	}
+	return &domain.PolicyViolation{
+		Rule:   domain.PolicyMaxPerSubject,
+		Reason: fmt.Sprintf("User already has %d active reservations for subject %s, the limit is %d", count, in.subject, limit),
+	}
+}
+
+func onePerWorkPolicy(policy config.Policy, in policyInput) *domain.PolicyViolation {
+	if !policy.OnePerWork {
+		return nil
+	}
+	for _, item := range in.current {
+		if item.Book.Key == in.book.Key {
+			return &domain.PolicyViolation{
+				Rule:   domain.PolicyOnePerWork,
+				Reason: fmt.Sprintf("User already has a reservation for book with key %s", in.book.Key),
+			}
+		}
+	}
+	return nil
+}
+
+// holdsCopy reports whether a reservation in status counts against the borrowing limits.
+func holdsCopy(status string) bool {
+	switch status {
+	case domain.ReservationStatusActive, domain.ReservationStatusProvisional, domain.ReservationStatusPickedUp:
+		return true
+	}
+	return false
+}
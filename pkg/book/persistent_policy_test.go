@@ -0,0 +1,131 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+func Test_checkPolicies(t *testing.T) {
+	const userID = 150
+	mu.Lock()
+	books[userID] = []domain.BorrowBookReq{
+		{Book: domain.Book{Key: "/works/OL1W"}, Subject: "love", UserID: userID, Status: domain.ReservationStatusActive},
+		{Book: domain.Book{Key: "/works/OL2W"}, Subject: "love", UserID: userID, Status: domain.ReservationStatusPickedUp},
+		{Book: domain.Book{Key: "/works/OL3W"}, Subject: "fantasy", UserID: userID, Status: domain.ReservationStatusCancelled},
+		{Book: domain.Book{Key: "/works/OL4W"}, Subject: "fantasy", UserID: userID, Status: domain.ReservationStatusExpired},
+	}
+	mu.Unlock()
+	defer func() {
+		mu.Lock()
+		delete(books, userID)
+		mu.Unlock()
+	}()
+
+	tests := []struct {
+		name    string
+		policy  config.Policy
+		subject string
+		book    domain.Book
+		want    []domain.PolicyViolation
+	}{
+		{
+			name:    "no policies configured",
+			subject: "love",
+			book:    domain.Book{Key: "/works/OL1W"},
+		},
+		{
+			name:    "within every limit",
+			policy:  config.Policy{MaxActiveReservations: 3, MaxPerSubject: 3, OnePerWork: true, BlockedUsers: []int{9}},
+			subject: "fantasy",
+			book:    domain.Book{Key: "/works/OL3W"},
+		},
+		{
+			name:    "every policy denies",
+			policy:  config.Policy{MaxActiveReservations: 2, MaxPerSubject: 2, OnePerWork: true, BlockedUsers: []int{userID}},
+			subject: "love",
+			book:    domain.Book{Key: "/works/OL1W"},
+			want: []domain.PolicyViolation{
+				{Rule: domain.PolicyBlockedUser, Reason: "User is blocked from borrowing"},
+				{Rule: domain.PolicyMaxActiveReservations, Reason: "User already has 2 active reservations, the limit is 2"},
+				{Rule: domain.PolicyMaxPerSubject, Reason: "User already has 2 active reservations for subject love, the limit is 2"},
+				{Rule: domain.PolicyOnePerWork, Reason: "User already has a reservation for book with key /works/OL1W"},
+			},
+		},
+		{
+			name:    "subjects compared case-insensitively",
+			policy:  config.Policy{MaxPerSubject: 2},
+			subject: "Love",
+			book:    domain.Book{Key: "/works/OL5W"},
+			want: []domain.PolicyViolation{
+				{Rule: domain.PolicyMaxPerSubject, Reason: "User already has 2 active reservations for subject Love, the limit is 2"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &persistentModule{cfg: &config.GlobalConfig{Policy: tt.policy}}
+
+			mu.Lock()
+			err := m.checkPolicies(userID, tt.book, tt.subject)
+			mu.Unlock()
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("checkPolicies() error = %v, want nil", err)
+				}
+				return
+			}
+			var policyErr *domain.PolicyError
+			if !errors.As(err, &policyErr) {
+				t.Fatalf("checkPolicies() error = %v, want a policy error", err)
+			}
+			if !reflect.DeepEqual(policyErr.Violations, tt.want) {
+				t.Errorf("checkPolicies() violations = %+v, want %+v", policyErr.Violations, tt.want)
+			}
+		})
+	}
+}
+
+func Test_storeChecksPolicies(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 1, 20, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+	defer func() {
+		mu.Lock()
+		delete(books, 151)
+		delete(books, 152)
+		mu.Unlock()
+	}()
+
+	m := &persistentModule{cfg: &config.GlobalConfig{Policy: config.Policy{MaxActiveReservations: 1}}}
+	matilda := domain.Book{Key: "/works/policy-matilda", Title: "Matilda"}
+	dune := domain.Book{Key: "/works/policy-dune", Title: "Dune"}
+
+	// The limit is checked where the reservation is stored, so the second request sees the first one.
+	if _, err := m.borrowBook(ctx, domain.BorrowBookReq{Book: matilda, UserID: 151, PickUpDate: "2022-01-21"}); err != nil {
+		t.Fatalf("borrowBook() error = %v", err)
+	}
+	if _, err := m.borrowBook(ctx, domain.BorrowBookReq{Book: dune, UserID: 151, PickUpDate: "2022-01-21"}); !errors.Is(err, domain.ErrPolicyDenied) {
+		t.Errorf("borrowBook() over the limit error = %v, want %v", err, domain.ErrPolicyDenied)
+	}
+
+	// Earlier items of a batch count against later ones.
+	_, err := m.borrowBooks(ctx, []domain.BorrowBookReq{
+		{Book: matilda, UserID: 152, PickUpDate: "2022-01-21"},
+		{Book: dune, UserID: 152, PickUpDate: "2022-01-21"},
+	})
+	var itemErr *domain.BatchItemError
+	if !errors.As(err, &itemErr) || itemErr.Index != 1 || !errors.Is(err, domain.ErrPolicyDenied) {
+		t.Errorf("borrowBooks() error = %v, want item 1 denied by policy", err)
+	}
+
+	if _, err := m.joinWaitlist(ctx, domain.JoinWaitlistReq{Book: matilda, UserID: 151, PickUpDate: "2022-01-21"}); !errors.Is(err, domain.ErrPolicyDenied) {
+		t.Errorf("joinWaitlist() over the limit error = %v, want %v", err, domain.ErrPolicyDenied)
+	}
+}
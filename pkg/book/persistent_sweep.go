@@ -0,0 +1,103 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+// leases are kept in process memory like the rest of the in-memory store, so they only keep the sweepers of one
+// process apart. Replicas running their own process each hold the lease and all sweep. A SQL store keeps the
+// leases in a table, so they hold across replicas.
+var leases map[string]domain.Lease = make(map[string]domain.Lease)
+
+// acquireLease gives req.Holder the named lease for req.TTL when it is free, expired or already held by it.
+func (m *persistentModule) acquireLease(ctx context.Context, req domain.AcquireLeaseReq) (bool, error) {
+	if req.Name == "" || req.Holder == "" {
+		return false, errors.New("Lease name and holder are required")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	now := timeNow()
+	if lease, ok := leases[req.Name]; ok && lease.Holder != req.Holder && now.Before(lease.ExpiresAt) {
+		return false, nil
+	}
+	leases[req.Name] = domain.Lease{
+		Name:      req.Name,
+		Holder:    req.Holder,
+		ExpiresAt: now.Add(req.TTL),
+	}
+	return true, nil
+}
+
+// expireUncollected expires active and provisional reservations whose pickup date is more than req.GraceDays
+// behind, hands their copies to the hold queues and returns the expired reservations.
+func (m *persistentModule) expireUncollected(ctx context.Context, req domain.ExpireUncollectedReq) ([]domain.BorrowBookReq, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	now := timeNow()
+	m.releaseExpiredHolds(now)
+
+	cutoff := now.AddDate(0, 0, -req.GraceDays).Format(pickUpDateLayout)
+	type copyKey struct{ key, branch string }
+	released := map[copyKey]bool{}
+	expired := []domain.BorrowBookReq{}
+	for uid := range books {
+		for i := range books[uid] {
+			res := &books[uid][i]
+			if res.Status != domain.ReservationStatusActive && res.Status != domain.ReservationStatusProvisional {
+				continue
+			}
+			if res.PickUpDate == "" || res.PickUpDate >= cutoff {
+				continue
+			}
+			res.Status = domain.ReservationStatusExpired
+			released[copyKey{res.Book.Key, res.Branch}] = true
+			recordEvent(domain.EventReservationExpired, *res, now)
+			expired = append(expired, *res)
+		}
+	}
+	for c := range released {
+		m.promoteHolds(c.key, c.branch, now)
+	}
+	return expired, nil
+}
+
+// claimReminders marks the active reservations picked up on req.PickUpDate that were not reminded yet and returns
+// them, so every reservation is reminded once even with several replicas asking.
+func (m *persistentModule) claimReminders(ctx context.Context, req domain.ClaimRemindersReq) ([]domain.BorrowBookReq, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	now := timeNow()
+	claimed := []domain.BorrowBookReq{}
+	for uid := range books {
+		for i := range books[uid] {
+			res := &books[uid][i]
+			if res.Status != domain.ReservationStatusActive || res.PickUpDate != req.PickUpDate || !res.RemindedAt.IsZero() {
+				continue
+			}
+			res.RemindedAt = now
+			claimed = append(claimed, *res)
+		}
+	}
+	return claimed, nil
+}
+
+// releaseReminder clears the reminder claim of a reservation so the next claimReminders returns it again.
+func (m *persistentModule) releaseReminder(ctx context.Context, req domain.ReleaseReminderReq) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	res := findReservation(req.ID, 0)
+	if res == nil {
+		return domain.ErrReservationNotFound
+	}
+	res.RemindedAt = time.Time{}
+	return nil
+}
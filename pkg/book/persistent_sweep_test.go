@@ -0,0 +1,124 @@
+package book
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+func Test_acquireLease(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 4, 1, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	m := &persistentModule{cfg: &config.GlobalConfig{}}
+	req := func(holder string) domain.AcquireLeaseReq {
+		return domain.AcquireLeaseReq{Name: "test-lease", Holder: holder, TTL: time.Minute}
+	}
+
+	if ok, err := m.acquireLease(ctx, req("a")); !ok || err != nil {
+		t.Fatalf("acquireLease() a = %v, %v", ok, err)
+	}
+	if ok, _ := m.acquireLease(ctx, req("b")); ok {
+		t.Errorf("acquireLease() b while held by a = %v", ok)
+	}
+	if ok, _ := m.acquireLease(ctx, req("a")); !ok {
+		t.Errorf("acquireLease() renew a = %v", ok)
+	}
+	now = now.Add(2 * time.Minute)
+	if ok, _ := m.acquireLease(ctx, req("b")); !ok {
+		t.Errorf("acquireLease() b after expiry = %v", ok)
+	}
+	if _, err := m.acquireLease(ctx, domain.AcquireLeaseReq{Name: "test-lease"}); err == nil {
+		t.Errorf("acquireLease() without holder error = nil")
+	}
+}
+
+func Test_expireUncollected(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 4, 10, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	m := &persistentModule{
+		cfg: &config.GlobalConfig{
+			Reservation: config.Reservation{CopiesPerWork: 1},
+		},
+	}
+	book := domain.Book{Key: "/works/OL1151034W", Title: "The Witches"}
+	other := domain.Book{Key: "/works/OL1151035W", Title: "The Twits"}
+
+	stale, err := m.borrowBook(ctx, domain.BorrowBookReq{Book: book, UserID: 601, PickUpDate: "2022-04-08"})
+	if err != nil {
+		t.Fatalf("borrowBook() error = %v", err)
+	}
+	recent, err := m.borrowBook(ctx, domain.BorrowBookReq{Book: other, UserID: 602, PickUpDate: "2022-04-09"})
+	if err != nil {
+		t.Fatalf("borrowBook() error = %v", err)
+	}
+	if _, err := m.joinWaitlist(ctx, domain.JoinWaitlistReq{Book: book, UserID: 603, PickUpDate: "2022-04-12"}); err != nil {
+		t.Fatalf("joinWaitlist() error = %v", err)
+	}
+
+	expired, err := m.expireUncollected(ctx, domain.ExpireUncollectedReq{GraceDays: 1})
+	if err != nil {
+		t.Fatalf("expireUncollected() error = %v", err)
+	}
+	found := false
+	for _, res := range expired {
+		if res.ID == recent.ID {
+			t.Errorf("expireUncollected() expired reservation still in grace period %+v", res)
+		}
+		if res.ID == stale.ID {
+			found = res.Status == domain.ReservationStatusExpired
+		}
+	}
+	if !found {
+		t.Errorf("expireUncollected() = %+v, want reservation %d expired", expired, stale.ID)
+	}
+
+	// The released copy goes to the waitlist.
+	next := books[603][len(books[603])-1]
+	if next.Book.Key != book.Key || next.Status != domain.ReservationStatusProvisional {
+		t.Errorf("promoted reservation = %+v", next)
+	}
+	if res := findReservation(recent.ID, 602); res.Status != domain.ReservationStatusActive {
+		t.Errorf("recent reservation = %+v", res)
+	}
+}
+
+func Test_claimReminders(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 5, 2, 18, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	m := &persistentModule{cfg: &config.GlobalConfig{}}
+	tomorrow, _ := m.borrowBook(ctx, domain.BorrowBookReq{Book: domain.Book{Key: "/works/OL45883W"}, UserID: 701, PickUpDate: "2022-05-03"})
+	later, _ := m.borrowBook(ctx, domain.BorrowBookReq{Book: domain.Book{Key: "/works/OL45884W"}, UserID: 702, PickUpDate: "2022-05-04"})
+
+	got, err := m.claimReminders(ctx, domain.ClaimRemindersReq{PickUpDate: "2022-05-03"})
+	if err != nil || len(got) != 1 || got[0].ID != tomorrow.ID || !got[0].RemindedAt.Equal(now) {
+		t.Fatalf("claimReminders() = %+v, %v", got, err)
+	}
+	if got, _ := m.claimReminders(ctx, domain.ClaimRemindersReq{PickUpDate: "2022-05-03"}); len(got) != 0 {
+		t.Errorf("claimReminders() twice = %+v, want none", got)
+	}
+	if res := findReservation(later.ID, 702); !res.RemindedAt.IsZero() {
+		t.Errorf("later reservation = %+v", res)
+	}
+
+	if err := m.releaseReminder(ctx, domain.ReleaseReminderReq{ID: tomorrow.ID}); err != nil {
+		t.Fatalf("releaseReminder() error = %v", err)
+	}
+	if got, _ := m.claimReminders(ctx, domain.ClaimRemindersReq{PickUpDate: "2022-05-03"}); len(got) != 1 || got[0].ID != tomorrow.ID {
+		t.Errorf("claimReminders() after release = %+v, want the released reservation", got)
+	}
+	if err := m.releaseReminder(ctx, domain.ReleaseReminderReq{ID: -1}); err != domain.ErrReservationNotFound {
+		t.Errorf("releaseReminder() unknown error = %v, want %v", err, domain.ErrReservationNotFound)
+	}
+}
@@ -4,12 +4,17 @@ import (
 	"context"
 	reflect "reflect"
 	"testing"
+	"time"
 
+	"gihub.com/gadhittana01/book-project/config"
 	"gihub.com/gadhittana01/book-project/pkg/domain"
 )
 
 func Test_newPersistent(t *testing.T) {
+	cfg := config.GlobalConfig{}
+
 	type args struct {
+		cfg *config.GlobalConfig
 	}
 	tests := []struct {
 		name string
@@ -18,13 +23,17 @@ func Test_newPersistent(t *testing.T) {
 	}{
 		{
 			name: "success",
-			args: args{},
-			want: &persistentModule{},
+			args: args{
+				cfg: &cfg,
+			},
+			want: &persistentModule{
+				cfg: &cfg,
+			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := newPersistent(); !reflect.DeepEqual(got, tt.want) {
+			if got := newPersistent(tt.args.cfg); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("newPersistent() = %v, want %v", got, tt.want)
 			}
 		})
@@ -100,8 +109,10 @@ func Test_borrowBook(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			m := &persistentModule{}
-			err := m.borrowBook(tt.args.ctx, tt.args.req)
+			m := &persistentModule{
+				cfg: &config.GlobalConfig{},
+			}
+			_, err := m.borrowBook(tt.args.ctx, tt.args.req)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("borrowBook() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -161,7 +172,9 @@ func Test_getBookReservation(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			m := &persistentModule{}
+			m := &persistentModule{
+				cfg: &config.GlobalConfig{},
+			}
 			got, err := m.getBookReservation(tt.args.ctx, tt.args.req)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("getBookReservation() error = %v, wantErr %v", err, tt.wantErr)
@@ -173,3 +186,161 @@ func Test_getBookReservation(t *testing.T) {
 		})
 	}
 }
+
+func Test_waitlist(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 1, 20, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	book := domain.Book{Key: "/works/OL45804W", Title: "Fantastic Mr Fox"}
+	m := &persistentModule{
+		cfg: &config.GlobalConfig{
+			Reservation: config.Reservation{CopiesPerWork: 1, HoldConfirmHours: 2},
+		},
+	}
+
+	if _, err := m.joinWaitlist(ctx, domain.JoinWaitlistReq{Book: book, UserID: 101}); err != domain.ErrBookAvailable {
+		t.Fatalf("joinWaitlist() on free book error = %v", err)
+	}
+	if _, err := m.borrowBook(ctx, domain.BorrowBookReq{Book: book, UserID: 101, PickUpDate: "2022-01-21"}); err != nil {
+		t.Fatalf("borrowBook() error = %v", err)
+	}
+	if _, err := m.borrowBook(ctx, domain.BorrowBookReq{Book: book, UserID: 102, PickUpDate: "2022-01-21"}); err != domain.ErrFullyReserved {
+		t.Fatalf("borrowBook() on reserved book error = %v", err)
+	}
+
+	for i, uid := range []int{102, 103} {
+		pos, err := m.joinWaitlist(ctx, domain.JoinWaitlistReq{Book: book, UserID: uid, PickUpDate: "2022-01-18"})
+		if err != nil || pos != i+1 {
+			t.Fatalf("joinWaitlist() user %d = %v, %v", uid, pos, err)
+		}
+	}
+	if _, err := m.joinWaitlist(ctx, domain.JoinWaitlistReq{Book: book, UserID: 103}); err != domain.ErrAlreadyInWaitlist {
+		t.Errorf("joinWaitlist() twice error = %v", err)
+	}
+
+	got, _ := m.getBookReservation(ctx, domain.GetBookReservationReq{UserID: 103})
+	if len(got[103]) != 1 || got[103][0].Status != domain.ReservationStatusWaiting || got[103][0].QueuePosition != 2 {
+		t.Errorf("getBookReservation() waiting = %+v", got[103])
+	}
+
+	first := books[101][len(books[101])-1]
+	if _, err := m.cancelReservation(ctx, domain.CancelReservationReq{ID: first.ID, UserID: 102}); err != domain.ErrReservationNotFound {
+		t.Errorf("cancelReservation() by other user error = %v", err)
+	}
+	if _, err := m.cancelReservation(ctx, domain.CancelReservationReq{ID: first.ID, UserID: 101}); err != nil {
+		t.Fatalf("cancelReservation() error = %v", err)
+	}
+	if _, err := m.cancelReservation(ctx, domain.CancelReservationReq{ID: first.ID, UserID: 101}); err != domain.ErrInvalidReservationState {
+		t.Errorf("cancelReservation() twice error = %v", err)
+	}
+
+	// The first hold becomes a provisional reservation for today since its pickup date has passed.
+	promoted := books[102][len(books[102])-1]
+	if promoted.Status != domain.ReservationStatusProvisional || promoted.PickUpDate != "2022-01-20" || !promoted.ConfirmBy.Equal(now.Add(2*time.Hour)) {
+		t.Fatalf("promoted reservation = %+v", promoted)
+	}
+
+	// Nobody confirms in time, so the copy moves on to the next hold.
+	now = now.Add(3 * time.Hour)
+	if err := m.confirmReservation(ctx, domain.ConfirmReservationReq{ID: promoted.ID, UserID: 102}); err != domain.ErrConfirmWindowPassed {
+		t.Fatalf("confirmReservation() late error = %v", err)
+	}
+	next := books[103][len(books[103])-1]
+	if next.Status != domain.ReservationStatusProvisional {
+		t.Fatalf("next reservation = %+v", next)
+	}
+	if err := m.confirmReservation(ctx, domain.ConfirmReservationReq{ID: next.ID, UserID: 103}); err != nil {
+		t.Fatalf("confirmReservation() error = %v", err)
+	}
+	if err := m.confirmReservation(ctx, domain.ConfirmReservationReq{ID: next.ID, UserID: 103}); err != domain.ErrInvalidReservationState {
+		t.Errorf("confirmReservation() twice error = %v", err)
+	}
+	if _, ok := holds[book.Key]; ok {
+		t.Errorf("holds = %+v, want empty queue", holds[book.Key])
+	}
+}
+
+func Test_promoteHoldsPolicies(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 1, 20, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	book := domain.Book{Key: "/works/OL45805W", Title: "The Witches"}
+	m := &persistentModule{
+		cfg: &config.GlobalConfig{
+			Reservation: config.Reservation{CopiesPerWork: 1, HoldConfirmHours: 2},
+		},
+	}
+
+	if _, err := m.borrowBook(ctx, domain.BorrowBookReq{Book: book, UserID: 120, PickUpDate: "2022-01-21"}); err != nil {
+		t.Fatalf("borrowBook() error = %v", err)
+	}
+	for _, uid := range []int{121, 122} {
+		if _, err := m.joinWaitlist(ctx, domain.JoinWaitlistReq{Book: book, UserID: uid, PickUpDate: "2022-01-21"}); err != nil {
+			t.Fatalf("joinWaitlist() user %d error = %v", uid, err)
+		}
+	}
+
+	// The first user in the queue is blocked while waiting, so the copy goes to the next one.
+	m.cfg.Policy.BlockedUsers = []int{121}
+	if _, err := m.cancelReservation(ctx, domain.CancelReservationReq{ID: books[120][len(books[120])-1].ID, UserID: 120}); err != nil {
+		t.Fatalf("cancelReservation() error = %v", err)
+	}
+	if len(books[121]) != 0 {
+		t.Errorf("blocked user reservations = %+v", books[121])
+	}
+	if got := books[122][len(books[122])-1]; got.Status != domain.ReservationStatusProvisional {
+		t.Errorf("promoted reservation = %+v", got)
+	}
+	if queue := holds[book.Key]; len(queue) != 1 || queue[0].UserID != 121 {
+		t.Errorf("holds = %+v, want blocked user to keep its place", queue)
+	}
+}
+
+func Test_borrowBookBlockedQueue(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 1, 20, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	book := domain.Book{Key: "/works/OL45806W", Title: "Danny, the Champion of the World"}
+	m := &persistentModule{
+		cfg: &config.GlobalConfig{
+			Reservation: config.Reservation{CopiesPerWork: 1, HoldConfirmHours: 2},
+		},
+	}
+
+	if _, err := m.borrowBook(ctx, domain.BorrowBookReq{Book: book, UserID: 130, PickUpDate: "2022-01-21"}); err != nil {
+		t.Fatalf("borrowBook() error = %v", err)
+	}
+	if _, err := m.joinWaitlist(ctx, domain.JoinWaitlistReq{Book: book, UserID: 131, PickUpDate: "2022-01-21"}); err != nil {
+		t.Fatalf("joinWaitlist() error = %v", err)
+	}
+
+	// Everybody in the queue is blocked, so the copy stays free once it is given back.
+	m.cfg.Policy.BlockedUsers = []int{131}
+	if _, err := m.cancelReservation(ctx, domain.CancelReservationReq{ID: books[130][len(books[130])-1].ID, UserID: 130}); err != nil {
+		t.Fatalf("cancelReservation() error = %v", err)
+	}
+	if _, err := m.joinWaitlist(ctx, domain.JoinWaitlistReq{Book: book, UserID: 132, PickUpDate: "2022-01-21"}); err != domain.ErrBookAvailable {
+		t.Errorf("joinWaitlist() with a blocked queue error = %v, want %v", err, domain.ErrBookAvailable)
+	}
+	if _, err := m.borrowBook(ctx, domain.BorrowBookReq{Book: book, UserID: 132, PickUpDate: "2022-01-21"}); err != nil {
+		t.Fatalf("borrowBook() with a blocked queue error = %v", err)
+	}
+	if _, err := m.borrowBook(ctx, domain.BorrowBookReq{Book: book, UserID: 133, PickUpDate: "2022-01-21"}); err != domain.ErrFullyReserved {
+		t.Errorf("borrowBook() without a free copy error = %v, want %v", err, domain.ErrFullyReserved)
+	}
+
+	// Once the queue can take the copy again, it goes there first.
+	m.cfg.Policy.BlockedUsers = nil
+	if _, err := m.cancelReservation(ctx, domain.CancelReservationReq{ID: books[132][len(books[132])-1].ID, UserID: 132}); err != nil {
+		t.Fatalf("cancelReservation() error = %v", err)
+	}
+	if got := books[131][len(books[131])-1]; got.Status != domain.ReservationStatusProvisional {
+		t.Errorf("promoted reservation = %+v", got)
+	}
+}
@@ -0,0 +1,148 @@
+package catalogstore
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+const (
+	typeWork   = "/type/work"
+	typeAuthor = "/type/author"
+
+	maxDumpLineBytes = 16 * 1024 * 1024
+)
+
+type dumpRecord struct {
+	Type struct {
+		Key string `json:"key"`
+	} `json:"type"`
+	Key      string   `json:"key"`
+	Title    string   `json:"title"`
+	Name     string   `json:"name"`
+	Subjects []string `json:"subjects"`
+	Authors  []struct {
+		Author struct {
+			Key string `json:"key"`
+		} `json:"author"`
+	} `json:"authors"`
+}
+
+type subjectFile struct {
+	Key   string        `json:"key"`
+	Name  string        `json:"name"`
+	Works []domain.Book `json:"works"`
+}
+
+// ImportDump reads an Open Library dump and returns the number of works imported.
+// Each line is either a bare JSON record or the tab separated dump layout whose last column is JSON.
+// Author names are resolved on works with the author records of this dump and of earlier ones, also those of a
+// saved store, works whose authors are not known yet get them when a later dump brings the author records.
+func (s *Store) ImportDump(r io.Reader) (int, error) {
+	var count int
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxDumpLineBytes)
+	for line := 1; scanner.Scan(); line++ {
+		raw := scanner.Bytes()
+		if i := bytes.LastIndexByte(raw, '\t'); i >= 0 {
+			raw = raw[i+1:]
+		}
+		raw = bytes.TrimSpace(raw)
+		if len(raw) == 0 {
+			continue
+		}
+
+		record := dumpRecord{}
+		if err := json.Unmarshal(raw, &record); err != nil {
+			return count, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		switch record.Type.Key {
+		case typeAuthor:
+			s.mu.Lock()
+			s.AuthorNames[record.Key] = record.Name
+			s.mu.Unlock()
+		case typeWork, "":
+			if record.Key == "" || record.Title == "" {
+				continue
+			}
+			keys := []string{}
+			for _, item := range record.Authors {
+				keys = append(keys, item.Author.Key)
+			}
+			if len(keys) > 0 {
+				s.mu.Lock()
+				s.WorkAuthors[record.Key] = keys
+				s.mu.Unlock()
+			}
+			s.Add(domain.Book{
+				Key:   record.Key,
+				Title: record.Title,
+			}, record.Subjects...)
+			count++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return count, err
+	}
+
+	s.resolveAuthors()
+	return count, nil
+}
+
+// resolveAuthors sets the author names of imported works. A work stays pending until every one of its authors is
+// known, in the meantime it gets the names found so far.
+func (s *Store) resolveAuthors() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for workKey, authorKeys := range s.WorkAuthors {
+		authors := []domain.Author{}
+		for _, key := range authorKeys {
+			if name, ok := s.AuthorNames[key]; ok {
+				authors = append(authors, domain.Author{
+					Name: name,
+				})
+			}
+		}
+		if len(authors) == len(authorKeys) {
+			delete(s.WorkAuthors, workKey)
+		}
+		if book, ok := s.Works[workKey]; ok && len(authors) > 0 {
+			book.Authors = authors
+			s.Works[workKey] = book
+		}
+	}
+}
+
+// ImportSubject reads a /subjects/{subject}.json response saved from Open Library.
+func (s *Store) ImportSubject(r io.Reader) (int, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return 0, err
+	}
+
+	file := subjectFile{}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return 0, err
+	}
+
+	subject := file.Key
+	if subject == "" {
+		subject = file.Name
+	}
+	if SubjectSlug(subject) == "" {
+		return 0, fmt.Errorf("subject file has no key or name")
+	}
+
+	for _, book := range file.Works {
+		s.Add(book, subject)
+	}
+	return len(file.Works), nil
+}
@@ -0,0 +1,186 @@
+package catalogstore
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+func Test_ImportDump(t *testing.T) {
+	tests := []struct {
+		name      string
+		dump      string
+		wantCount int
+		wantLove  []domain.Book
+		wantErr   bool
+	}{
+		{
+			name: "tab separated dump with authors",
+			dump: "/type/work\t/works/OL1W\t3\t2010-04-28T06:54:19\t" +
+				`{"type": {"key": "/type/work"}, "key": "/works/OL1W", "title": "Know Nothing", "subjects": ["Love", "Fiction"], "authors": [{"author": {"key": "/authors/OL1A"}}]}` + "\n" +
+				"/type/author\t/authors/OL1A\t1\t2010-04-28T06:54:19\t" +
+				`{"type": {"key": "/type/author"}, "key": "/authors/OL1A", "name": "Mary Lee Settle"}` + "\n",
+			wantCount: 1,
+			wantLove: []domain.Book{
+				{
+					Key:   "/works/OL1W",
+					Title: "Know Nothing",
+					Authors: []domain.Author{
+						{Name: "Mary Lee Settle"},
+					},
+				},
+			},
+		},
+		{
+			name: "json lines",
+			dump: `{"key": "/works/OL2W", "title": "Second", "subjects": ["love"]}` + "\n\n" +
+				`{"key": "/works/OL3W", "title": "Third", "subjects": ["history"]}` + "\n" +
+				`{"key": "/works/OL4W", "subjects": ["love"]}` + "\n",
+			wantCount: 2,
+			wantLove: []domain.Book{
+				{
+					Key:   "/works/OL2W",
+					Title: "Second",
+				},
+			},
+		},
+		{
+			name:      "invalid json",
+			dump:      "{not json}\n",
+			wantCount: 0,
+			wantLove:  []domain.Book{},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New()
+			got, err := s.ImportDump(strings.NewReader(tt.dump))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ImportDump() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.wantCount {
+				t.Errorf("ImportDump() = %v, want %v", got, tt.wantCount)
+			}
+			if love := s.BooksBySubject("love"); !reflect.DeepEqual(love, tt.wantLove) {
+				t.Errorf("BooksBySubject() = %v, want %v", love, tt.wantLove)
+			}
+		})
+	}
+}
+
+func Test_ImportDump_separateFiles(t *testing.T) {
+	works := `{"type": {"key": "/type/work"}, "key": "/works/OL1W", "title": "Know Nothing", "subjects": ["Love"], "authors": [{"author": {"key": "/authors/OL1A"}}, {"author": {"key": "/authors/OL2A"}}]}` + "\n"
+	authors := `{"type": {"key": "/type/author"}, "key": "/authors/OL1A", "name": "Mary Lee Settle"}` + "\n" +
+		`{"type": {"key": "/type/author"}, "key": "/authors/OL2A", "name": "Anne Tyler"}` + "\n"
+	want := []domain.Author{{Name: "Mary Lee Settle"}, {Name: "Anne Tyler"}}
+
+	for _, order := range [][]string{{works, authors}, {authors, works}} {
+		s := New()
+		for _, dump := range order {
+			if _, err := s.ImportDump(strings.NewReader(dump)); err != nil {
+				t.Fatalf("ImportDump() error = %v", err)
+			}
+		}
+		book, _ := s.Book("/works/OL1W")
+		if !reflect.DeepEqual(book.Authors, want) {
+			t.Errorf("Book().Authors = %v, want %v", book.Authors, want)
+		}
+	}
+}
+
+func Test_ImportDump_separateRuns(t *testing.T) {
+	works := `{"type": {"key": "/type/work"}, "key": "/works/OL1W", "title": "Know Nothing", "subjects": ["Love"], "authors": [{"author": {"key": "/authors/OL1A"}}]}` + "\n"
+	authors := `{"type": {"key": "/type/author"}, "key": "/authors/OL1A", "name": "Mary Lee Settle"}` + "\n"
+	want := []domain.Author{{Name: "Mary Lee Settle"}}
+
+	for _, order := range [][]string{{works, authors}, {authors, works}} {
+		path := filepath.Join(t.TempDir(), "catalog.json")
+		// Every dump goes in its own import run, which loads the store saved by the previous one.
+		for _, dump := range order {
+			s, err := LoadOrNew(path)
+			if err != nil {
+				t.Fatalf("LoadOrNew() error = %v", err)
+			}
+			if _, err := s.ImportDump(strings.NewReader(dump)); err != nil {
+				t.Fatalf("ImportDump() error = %v", err)
+			}
+			if err := s.Save(path); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+		}
+
+		s, err := Load(path)
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		book, _ := s.Book("/works/OL1W")
+		if !reflect.DeepEqual(book.Authors, want) {
+			t.Errorf("Book().Authors = %v, want %v", book.Authors, want)
+		}
+		if len(s.WorkAuthors) != 0 {
+			t.Errorf("WorkAuthors = %v, want none pending", s.WorkAuthors)
+		}
+	}
+}
+
+func Test_ImportSubject(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name: "subject export",
+			file: `{
+				"key": "/subjects/love",
+				"name": "love",
+				"works": [
+					{
+						"key": "/works/OL1908641W",
+						"title": "Know Nothing",
+						"edition_count": 6,
+						"lending_identifier": "knownothingnovel00sett",
+						"authors": [{"key": "/authors/OL228578A", "name": "Mary Lee Settle"}]
+					}
+				]
+			}`,
+			wantCount: 1,
+		},
+		{
+			name:    "missing subject",
+			file:    `{"works": []}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid json",
+			file:    `nope`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New()
+			got, err := s.ImportSubject(strings.NewReader(tt.file))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ImportSubject() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.wantCount {
+				t.Errorf("ImportSubject() = %v, want %v", got, tt.wantCount)
+			}
+			if tt.wantErr {
+				return
+			}
+			book, ok := s.Book("/works/OL1908641W")
+			if !ok || book.EditionCount != 6 || book.Authors[0].Name != "Mary Lee Settle" {
+				t.Errorf("Book() = %v, want imported work", book)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package catalogstore
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+// Store is a local copy of the catalog, keyed by work key and subject slug.
+type Store struct {
+	mu       sync.RWMutex
+	Works    map[string]domain.Book `json:"works"`
+	Subjects map[string][]string    `json:"subjects"`
+
+	// Works and authors come in separate dumps, so author names and the author keys of works whose names are
+	// not known yet are kept across ImportDump calls and saved with the store for a later import run.
+	AuthorNames map[string]string   `json:"author_names,omitempty"`
+	WorkAuthors map[string][]string `json:"pending_work_authors,omitempty"`
+}
+
+func New() *Store {
+	return &Store{
+		Works:       make(map[string]domain.Book),
+		Subjects:    make(map[string][]string),
+		AuthorNames: make(map[string]string),
+		WorkAuthors: make(map[string][]string),
+	}
+}
+
+// Load reads a store previously written with Save.
+func Load(path string) (*Store, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := New()
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// LoadOrNew behaves like Load but starts an empty store when path does not exist yet.
+func LoadOrNew(path string) (*Store, error) {
+	s, err := Load(path)
+	if os.IsNotExist(err) {
+		return New(), nil
+	}
+	return s, err
+}
+
+func (s *Store) Save(path string) error {
+	s.mu.RLock()
+	data, err := json.Marshal(s)
+	s.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Add stores book and links it to each subject, merging with what is already known about the work.
+func (s *Store) Add(book domain.Book, subjects ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if current, ok := s.Works[book.Key]; ok {
+		book = merge(current, book)
+	}
+	s.Works[book.Key] = book
+
+	for _, subject := range subjects {
+		slug := SubjectSlug(subject)
+		if slug == "" || contains(s.Subjects[slug], book.Key) {
+			continue
+		}
+		s.Subjects[slug] = append(s.Subjects[slug], book.Key)
+	}
+}
+
+func (s *Store) BooksBySubject(subject string) []domain.Book {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := []domain.Book{}
+	for _, key := range s.Subjects[SubjectSlug(subject)] {
+		res = append(res, s.Works[key])
+	}
+	return res
+}
+
+func (s *Store) Book(key string) (domain.Book, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	book, ok := s.Works[key]
+	return book, ok
+}
+
+func (s *Store) SubjectNames() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]string, 0, len(s.Subjects))
+	for subject := range s.Subjects {
+		res = append(res, subject)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// SubjectSlug normalises a subject the way Open Library builds /subjects/{slug} URLs.
+func SubjectSlug(subject string) string {
+	subject = strings.ToLower(strings.TrimSpace(subject))
+	subject = strings.TrimPrefix(subject, "/subjects/")
+	return strings.Join(strings.Fields(subject), "_")
+}
+
+func merge(current, incoming domain.Book) domain.Book {
+	if incoming.Title == "" {
+		incoming.Title = current.Title
+	}
+	if incoming.EditionCount == 0 {
+		incoming.EditionCount = current.EditionCount
+	}
+	if len(incoming.Authors) == 0 {
+		incoming.Authors = current.Authors
+	}
+	if incoming.LendingIdentifier == "" {
+		incoming.LendingIdentifier = current.LendingIdentifier
+	}
+	return incoming
+}
+
+func contains(list []string, item string) bool {
+	for _, v := range list {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package catalogstore
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+func Test_SubjectSlug(t *testing.T) {
+	tests := []struct {
+		subject string
+		want    string
+	}{
+		{subject: "love", want: "love"},
+		{subject: "Science Fiction", want: "science_fiction"},
+		{subject: "/subjects/love", want: "love"},
+		{subject: "  ", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.subject, func(t *testing.T) {
+			if got := SubjectSlug(tt.subject); got != tt.want {
+				t.Errorf("SubjectSlug() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_StoreAdd(t *testing.T) {
+	s := New()
+	s.Add(domain.Book{
+		Key:          "/works/OL1W",
+		Title:        "Know Nothing",
+		EditionCount: 6,
+	}, "Love", "love")
+	s.Add(domain.Book{
+		Key: "/works/OL1W",
+		Authors: []domain.Author{
+			{Name: "Mary Lee Settle"},
+		},
+	}, "Fiction")
+
+	want := domain.Book{
+		Key:          "/works/OL1W",
+		Title:        "Know Nothing",
+		EditionCount: 6,
+		Authors: []domain.Author{
+			{Name: "Mary Lee Settle"},
+		},
+	}
+	if got, ok := s.Book("/works/OL1W"); !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("Book() = %v, want %v", got, want)
+	}
+	if got := s.BooksBySubject("love"); !reflect.DeepEqual(got, []domain.Book{want}) {
+		t.Errorf("BooksBySubject() = %v, want %v", got, []domain.Book{want})
+	}
+	if got := s.SubjectNames(); !reflect.DeepEqual(got, []string{"fiction", "love"}) {
+		t.Errorf("SubjectNames() = %v", got)
+	}
+	if got := s.BooksBySubject("unknown"); len(got) != 0 {
+		t.Errorf("BooksBySubject() = %v, want empty", got)
+	}
+}
+
+func Test_StoreSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.json")
+
+	s := New()
+	s.Add(domain.Book{
+		Key:   "/works/OL1W",
+		Title: "Know Nothing",
+	}, "love")
+	if err := s.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Works, s.Works) || !reflect.DeepEqual(got.Subjects, s.Subjects) {
+		t.Errorf("Load() = %v, want %v", got, s)
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("Load() expected error for missing file")
+	}
+	empty, err := LoadOrNew(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil || len(empty.Works) != 0 {
+		t.Errorf("LoadOrNew() = %v, %v, want empty store", empty, err)
+	}
+}
@@ -1,15 +1,20 @@
 package domain
 
+import "time"
+
 type GetListOfBooksResp struct {
-	Books []Book `json:"works"`
+	Books    []Book `json:"works"`
+	Provider string `json:"provider,omitempty"`
 }
 
+// Book Provider is the catalog provider the book was looked up in.
 type Book struct {
 	Key               string   `json:"key"`
 	Title             string   `json:"title"`
 	EditionCount      int      `json:"edition_count"`
 	Authors           []Author `json:"authors"`
 	LendingIdentifier string   `json:"lending_identifier"`
+	Provider          string   `json:"provider,omitempty"`
 }
 
 type Author struct {
@@ -21,9 +26,18 @@ type GetListOfBooksReq struct {
 }
 
 type BorrowBookReq struct {
-	Book       Book   `json:"book"`
-	PickUpDate string `json:"pickup_date"`
-	UserID     int    `json:"user_id"`
+	ID            int       `json:"id"`
+	Book          Book      `json:"book"`
+	Subject       string    `json:"subject,omitempty"`
+	Branch        string    `json:"branch"`
+	PickUpDate    string    `json:"pickup_date"`
+	PickUpSlot    string    `json:"pickup_slot,omitempty"`
+	UserID        int       `json:"user_id"`
+	Status        string    `json:"status"`
+	QueuePosition int       `json:"queue_position,omitempty"`
+	ConfirmBy     time.Time `json:"confirm_by,omitempty"`
+	RemindedAt    time.Time `json:"reminded_at,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
 }
 
 type GeBookByKeyReq struct {
@@ -32,5 +46,81 @@ type GeBookByKeyReq struct {
 }
 
 type GetBookReservationReq struct {
+	UserID int    `json:"user_id"`
+	Branch string `json:"branch"`
+}
+
+// BatchItemError is the error of the item at Index that made a whole batch fail.
+type BatchItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchItemError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *BatchItemError) Unwrap() error {
+	return e.Err
+}
+
+// GetReservationPageReq pages through reservations in ID order, AfterID is the last ID of the previous page. The
+// other fields filter the reservations when set: Query is searched in the title, author names and work key ignoring
+// case, From and To bound the pickup date.
+type GetReservationPageReq struct {
+	UserID  int    `json:"user_id"`
+	Branch  string `json:"branch"`
+	Status  string `json:"status"`
+	Key     string `json:"key"`
+	Query   string `json:"q"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+	AfterID int    `json:"after_id"`
+	Limit   int    `json:"limit"`
+}
+
+type ProviderHealth struct {
+	Name                string    `json:"name"`
+	Healthy             bool      `json:"healthy"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	LastError           string    `json:"last_error,omitempty"`
+	LastCheckedAt       time.Time `json:"last_checked_at"`
+}
+
+const (
+	ReservationStatusActive      = "active"
+	ReservationStatusProvisional = "provisional"
+	ReservationStatusCancelled   = "cancelled"
+	ReservationStatusExpired     = "expired"
+	ReservationStatusPickedUp    = "picked_up"
+	ReservationStatusReturned    = "returned"
+	// ReservationStatusWaiting marks hold queue entries in reservation listings.
+	ReservationStatusWaiting = "waiting"
+)
+
+type Hold struct {
+	Book       Book      `json:"book"`
+	Subject    string    `json:"subject,omitempty"`
+	Branch     string    `json:"branch"`
+	PickUpDate string    `json:"pickup_date"`
+	UserID     int       `json:"user_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type JoinWaitlistReq struct {
+	Book       Book   `json:"book"`
+	Subject    string `json:"subject"`
+	Branch     string `json:"branch"`
+	PickUpDate string `json:"pickup_date"`
+	UserID     int    `json:"user_id"`
+}
+
+type CancelReservationReq struct {
+	ID     int `json:"id"`
+	UserID int `json:"user_id"`
+}
+
+type ConfirmReservationReq struct {
+	ID     int `json:"id"`
 	UserID int `json:"user_id"`
 }
@@ -0,0 +1,40 @@
+package domain
+
+type Branch struct {
+	Code           string            `json:"code"`
+	Name           string            `json:"name"`
+	Address        string            `json:"address"`
+	OpeningHours   map[string]string `json:"opening_hours"`
+	ClosedDates    []string          `json:"closed_dates"`
+	PickupCapacity int               `json:"pickup_capacity"`
+	CopiesPerWork  int               `json:"copies_per_work"`
+	SlotMinutes    int               `json:"slot_minutes"`
+	SlotCapacity   int               `json:"slot_capacity"`
+}
+
+// BranchAvailability counts the copies of one work at a branch, Copies is 0 when unlimited.
+type BranchAvailability struct {
+	Branch    string `json:"branch"`
+	Copies    int    `json:"copies"`
+	Reserved  int    `json:"reserved"`
+	Available int    `json:"available"`
+	Waiting   int    `json:"waiting"`
+}
+
+type GetAvailabilityReq struct {
+	Key string `json:"key"`
+}
+
+// PickupSlot is a pickup window at a branch, Start and End are HH:MM and Capacity is 0 when unlimited.
+type PickupSlot struct {
+	Start     string `json:"start"`
+	End       string `json:"end"`
+	Capacity  int    `json:"capacity"`
+	Booked    int    `json:"booked"`
+	Available int    `json:"available"`
+}
+
+type GetPickupSlotsReq struct {
+	Branch string `json:"branch"`
+	Date   string `json:"date"`
+}
@@ -0,0 +1,42 @@
+package domain
+
+import "errors"
+
+var (
+	ErrReservationNotFound     = errors.New("Reservation not found")
+	ErrFullyReserved           = errors.New("Book is fully reserved")
+	ErrBookAvailable           = errors.New("Book is available, borrow it instead of joining the waitlist")
+	ErrAlreadyInWaitlist       = errors.New("User is already in the waitlist for this book")
+	ErrInvalidReservationState = errors.New("Reservation cannot be changed in its current status")
+	ErrConfirmWindowPassed     = errors.New("Confirmation window has passed")
+	ErrLoanNotFound            = errors.New("Loan not found")
+	ErrLoanClosed              = errors.New("Loan is already returned")
+	ErrRenewalLimitReached     = errors.New("Loan renewal limit reached")
+	ErrWorkOnHold              = errors.New("Book is on hold for another user")
+	ErrLoanOverdue             = errors.New("Overdue loans cannot be renewed, return the book first")
+	ErrFineNotFound            = errors.New("Fine not found")
+	ErrInvalidAmount           = errors.New("Amount must be positive and not above the outstanding balance")
+	ErrCurrencyMismatch        = errors.New("Currency does not match the fines ledger")
+	ErrOutstandingFines        = errors.New("Outstanding fines are above the allowed balance")
+	ErrUserNotFound            = errors.New("User not found")
+	ErrUserInactive            = errors.New("User is deactivated")
+	ErrEmailTaken              = errors.New("Email is already registered")
+	ErrBranchNotFound          = errors.New("Branch not found")
+	ErrBranchClosed            = errors.New("Branch is closed on the pickup date")
+	ErrInvalidPickUpDate       = errors.New("Pickup date must be today or later, formatted as YYYY-MM-DD")
+	ErrPickupCapacityReached   = errors.New("Branch has no pickup capacity left on the pickup date")
+	ErrInvalidPickUpSlot       = errors.New("Pickup slot is not offered by the branch on the pickup date")
+	ErrPickupSlotFull          = errors.New("Pickup slot is full")
+	ErrOutboxEventNotFound     = errors.New("Outbox event not found")
+	ErrOutboxEventNotDead      = errors.New("Only dead-lettered outbox events can be requeued")
+	ErrWebhookNotFound         = errors.New("Webhook subscription not found")
+	ErrWebhookDeliveryNotFound = errors.New("Webhook delivery not found")
+	ErrWebhookDisabled         = errors.New("Webhook subscription is disabled")
+	ErrIdempotencyKeyReused    = errors.New("Idempotency key was already used with a different request")
+	ErrIdempotencyInProgress   = errors.New("A request with this idempotency key is still in progress")
+	ErrIdempotencyKeyNotFound  = errors.New("Idempotency key not found")
+	ErrMigrationChecksum       = errors.New("Applied migration was changed after it ran")
+	ErrMigrationUnknown        = errors.New("Applied migration is not part of this build")
+	ErrMigrationOutOfOrder     = errors.New("Migration is older than the applied schema version")
+	ErrMigrationLocked         = errors.New("Another replica is running the migrations")
+)
@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+const (
+	FineEntryCharge  = "charge"
+	FineEntryWaiver  = "waiver"
+	FineEntryPayment = "payment"
+)
+
+// FineEntry is one line of a user's fines ledger. Amounts are in minor units, charges are positive and
+// waivers and payments negative.
+type FineEntry struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id"`
+	LoanID    int       `json:"loan_id,omitempty"`
+	FineID    int       `json:"fine_id,omitempty"`
+	Type      string    `json:"type"`
+	Amount    int64     `json:"amount"`
+	Currency  string    `json:"currency"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type FineLedger struct {
+	UserID   int         `json:"user_id"`
+	Currency string      `json:"currency"`
+	Balance  int64       `json:"balance"`
+	Accruing int64       `json:"accruing"`
+	Entries  []FineEntry `json:"entries"`
+}
+
+type GetFinesReq struct {
+	UserID int `json:"user_id"`
+}
+
+type SettleFinesReq struct {
+	UserID   int    `json:"user_id"`
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+type WaiveFineReq struct {
+	FineID int    `json:"fine_id"`
+	Amount int64  `json:"amount"`
+	Note   string `json:"note"`
+}
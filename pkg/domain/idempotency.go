@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+const (
+	IdempotencyStatusInProgress = "in_progress"
+	IdempotencyStatusCompleted  = "completed"
+)
+
+// IdempotencyRecord is the first request made with an idempotency key and, once it finished, its response.
+// Fingerprint identifies the request so a key reused for another request can be told apart from a retry.
+type IdempotencyRecord struct {
+	Scope       string    `json:"scope"`
+	Key         string    `json:"key"`
+	Fingerprint string    `json:"fingerprint"`
+	Status      string    `json:"status"`
+	StatusCode  int       `json:"status_code"`
+	ContentType string    `json:"content_type"`
+	Body        []byte    `json:"body"`
+	LockedUntil time.Time `json:"locked_until"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// BeginIdempotencyReq claims Key within Scope for TTL. Lock is how long the claim keeps retries waiting before a
+// request that never completed may be run again.
+type BeginIdempotencyReq struct {
+	Scope       string        `json:"scope"`
+	Key         string        `json:"key"`
+	Fingerprint string        `json:"fingerprint"`
+	TTL         time.Duration `json:"ttl"`
+	Lock        time.Duration `json:"lock"`
+}
+
+type CompleteIdempotencyReq struct {
+	Scope       string `json:"scope"`
+	Key         string `json:"key"`
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+type ReleaseIdempotencyReq struct {
+	Scope string `json:"scope"`
+	Key   string `json:"key"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// Lease gives one holder exclusive use of a named job across replicas until ExpiresAt.
+type Lease struct {
+	Name      string    `json:"name"`
+	Holder    string    `json:"holder"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type AcquireLeaseReq struct {
+	Name   string        `json:"name"`
+	Holder string        `json:"holder"`
+	TTL    time.Duration `json:"ttl"`
+}
+
+type ExpireUncollectedReq struct {
+	GraceDays int `json:"grace_days"`
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+const (
+	LoanStatusOpen     = "on_loan"
+	LoanStatusReturned = "returned"
+)
+
+type Loan struct {
+	ID            int       `json:"id"`
+	ReservationID int       `json:"reservation_id"`
+	Book          Book      `json:"book"`
+	Branch        string    `json:"branch"`
+	UserID        int       `json:"user_id"`
+	CheckedOutAt  time.Time `json:"checked_out_at"`
+	DueDate       time.Time `json:"due_date"`
+	ReturnedAt    time.Time `json:"returned_at"`
+	Renewals      int       `json:"renewals"`
+	Status        string    `json:"status"`
+}
+
+type CheckoutBookReq struct {
+	ReservationID int `json:"reservation_id"`
+}
+
+type ReturnBookReq struct {
+	LoanID int `json:"loan_id"`
+}
+
+type RenewLoanReq struct {
+	LoanID int `json:"loan_id"`
+}
+
+type GetLoansReq struct {
+	UserID int `json:"user_id"`
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// Migration is one embedded schema migration. Checksum is the hex SHA-256 of SQL, an applied migration whose
+// file changed afterwards no longer matches the checksum recorded when it ran.
+type Migration struct {
+	Version  int    `json:"version"`
+	Name     string `json:"name"`
+	SQL      string `json:"sql"`
+	Checksum string `json:"checksum"`
+}
+
+// AppliedMigration is a row of the migrations table.
+type AppliedMigration struct {
+	Version   int       `json:"version"`
+	Name      string    `json:"name"`
+	Checksum  string    `json:"checksum"`
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+// AcquireMigrationLockReq takes the migration lock for Holder until TTL passes or it is released.
+type AcquireMigrationLockReq struct {
+	Holder string        `json:"holder"`
+	TTL    time.Duration `json:"ttl"`
+}
+
+type ReleaseMigrationLockReq struct {
+	Holder string `json:"holder"`
+}
@@ -0,0 +1,27 @@
+package domain
+
+const (
+	NotifyReservationConfirmed = "reservation_confirmed"
+	NotifyPickupReminder       = "pickup_reminder"
+	NotifyReservationCancelled = "reservation_cancelled"
+	NotifyHoldReady            = "hold_ready"
+)
+
+type Notification struct {
+	Event         string `json:"event"`
+	UserID        int    `json:"user_id"`
+	ReservationID int    `json:"reservation_id"`
+	To            string `json:"to"`
+	Subject       string `json:"subject"`
+	Body          string `json:"body"`
+}
+
+type ClaimRemindersReq struct {
+	PickUpDate string `json:"pickup_date"`
+}
+
+// ReleaseReminderReq hands back the claim on the reminder of a reservation whose delivery failed, so it is sent
+// again on the next run.
+type ReleaseReminderReq struct {
+	ID int `json:"id"`
+}
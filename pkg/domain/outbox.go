@@ -0,0 +1,56 @@
+package domain
+
+import "time"
+
+// Reservation events, recorded in the outbox with every reservation state change.
+const (
+	EventReservationCreated   = "reservation.created"
+	EventReservationConfirmed = "reservation.confirmed"
+	EventReservationCancelled = "reservation.cancelled"
+	EventReservationPickedUp  = "reservation.picked_up"
+	EventReservationReturned  = "reservation.returned"
+	EventReservationExpired   = "reservation.expired"
+)
+
+const (
+	OutboxStatusPending   = "pending"
+	OutboxStatusDelivered = "delivered"
+	OutboxStatusDead      = "dead"
+)
+
+// OutboxEvent is a reservation change waiting to be delivered. Reservation is the reservation as it was right
+// after the change. NextAttemptAt is when a dispatcher may pick the event up again.
+type OutboxEvent struct {
+	ID            int           `json:"id"`
+	Type          string        `json:"type"`
+	Reservation   BorrowBookReq `json:"reservation"`
+	Status        string        `json:"status"`
+	Attempts      int           `json:"attempts"`
+	LastError     string        `json:"last_error,omitempty"`
+	NextAttemptAt time.Time     `json:"next_attempt_at"`
+	CreatedAt     time.Time     `json:"created_at"`
+	DeliveredAt   time.Time     `json:"delivered_at,omitempty"`
+}
+
+// ClaimOutboxReq claims up to Limit due events for Lease, other dispatchers skip them until it runs out.
+type ClaimOutboxReq struct {
+	Limit int           `json:"limit"`
+	Lease time.Duration `json:"lease"`
+}
+
+// AckOutboxReq marks an event delivered when Error is empty. A failed event is retried at RetryAt, or
+// dead-lettered when Dead is set.
+type AckOutboxReq struct {
+	ID      int       `json:"id"`
+	Error   string    `json:"error"`
+	RetryAt time.Time `json:"retry_at"`
+	Dead    bool      `json:"dead"`
+}
+
+type GetOutboxReq struct {
+	Status string `json:"status"`
+}
+
+type RequeueOutboxReq struct {
+	ID int `json:"id"`
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "errors"
+
+const (
+	PolicyMaxActiveReservations = "max_active_reservations"
+	PolicyMaxPerSubject         = "max_per_subject"
+	PolicyOnePerWork            = "one_per_work"
+	PolicyBlockedUser           = "blocked_user"
+)
+
+// ErrPolicyDenied is matched by every PolicyError through errors.Is.
+var ErrPolicyDenied = errors.New("Reservation denied by borrowing policy")
+
+// PolicyViolation is one borrowing policy that denied a reservation.
+type PolicyViolation struct {
+	Rule   string `json:"rule"`
+	Reason string `json:"reason"`
+}
+
+// PolicyError denies a reservation with every borrowing policy that failed.
+type PolicyError struct {
+	Violations []PolicyViolation
+}
+
+func (e *PolicyError) Error() string {
+	return ErrPolicyDenied.Error()
+}
+
+func (e *PolicyError) Is(target error) bool {
+	return target == ErrPolicyDenied
+}
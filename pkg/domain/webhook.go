@@ -0,0 +1,106 @@
+package domain
+
+import "time"
+
+// WebhookSubscription receives the reservation events listed in Events, every event when it is empty. It stops
+// receiving them once disabled, either by an admin or after too many failed deliveries in a row.
+type WebhookSubscription struct {
+	ID                  int       `json:"id"`
+	URL                 string    `json:"url"`
+	Secret              string    `json:"secret"`
+	Events              []string  `json:"events"`
+	Active              bool      `json:"active"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	DisabledReason      string    `json:"disabled_reason,omitempty"`
+	DisabledAt          time.Time `json:"disabled_at,omitempty"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+type CreateWebhookReq struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events"`
+}
+
+// UpdateWebhookReq replaces the URL, events and state of a subscription, activating it resets its failures.
+type UpdateWebhookReq struct {
+	ID     int      `json:"id"`
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Active bool     `json:"active"`
+}
+
+type GetWebhookReq struct {
+	ID int `json:"id"`
+}
+
+type DeleteWebhookReq struct {
+	ID int `json:"id"`
+}
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// WebhookDelivery is one event sent to one subscription, Payload is the exact JSON body that is posted.
+type WebhookDelivery struct {
+	ID             int       `json:"id"`
+	SubscriptionID int       `json:"subscription_id"`
+	EventID        int       `json:"event_id"`
+	EventType      string    `json:"event_type"`
+	Payload        string    `json:"payload"`
+	Status         string    `json:"status"`
+	Attempts       int       `json:"attempts"`
+	ResponseStatus int       `json:"response_status,omitempty"`
+	LastError      string    `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time `json:"next_attempt_at"`
+	CreatedAt      time.Time `json:"created_at"`
+	CompletedAt    time.Time `json:"completed_at,omitempty"`
+	// ReplayOf is the delivery this one repeats.
+	ReplayOf int `json:"replay_of,omitempty"`
+}
+
+// EnqueueWebhookReq queues Payload for every active subscription to EventType that has not got EventID yet.
+type EnqueueWebhookReq struct {
+	EventID   int    `json:"event_id"`
+	EventType string `json:"event_type"`
+	Payload   string `json:"payload"`
+}
+
+// ClaimWebhookDeliveriesReq claims up to Limit due deliveries of active subscriptions for Lease.
+type ClaimWebhookDeliveriesReq struct {
+	Limit int           `json:"limit"`
+	Lease time.Duration `json:"lease"`
+}
+
+// RecordWebhookAttemptReq stores the outcome of one delivery attempt. A failed attempt is retried at RetryAt
+// unless GiveUp is set, and disables the subscription after DisableAfter failures in a row when it is above 0.
+type RecordWebhookAttemptReq struct {
+	ID             int       `json:"id"`
+	ResponseStatus int       `json:"response_status"`
+	Error          string    `json:"error"`
+	RetryAt        time.Time `json:"retry_at"`
+	GiveUp         bool      `json:"give_up"`
+	DisableAfter   int       `json:"disable_after"`
+}
+
+type GetWebhookDeliveriesReq struct {
+	SubscriptionID int    `json:"subscription_id"`
+	Status         string `json:"status"`
+}
+
+type ReplayWebhookDeliveryReq struct {
+	ID int `json:"id"`
+}
+
+// SendWebhookReq is one signed POST of Payload to URL.
+type SendWebhookReq struct {
+	URL        string `json:"url"`
+	Secret     string `json:"secret"`
+	EventType  string `json:"event_type"`
+	DeliveryID int    `json:"delivery_id"`
+	Payload    string `json:"payload"`
+}
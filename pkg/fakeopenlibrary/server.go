@@ -0,0 +1,380 @@
+package fakeopenlibrary
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"math/rand"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed fixtures
+var embeddedFixtures embed.FS
+
+// Options control how the fake behaves. The zero value serves the embedded fixtures without faults.
+type Options struct {
+	// FixtureDir holds subjects/{subject}.json and works/{id}.json, the embedded fixtures are used when empty.
+	FixtureDir string
+	// Latency is added to every response, plus a random amount up to Jitter.
+	Latency time.Duration
+	Jitter  time.Duration
+	// ErrorRate is the chance (0..1) that a request fails with ErrorStatus.
+	ErrorRate   float64
+	ErrorStatus int
+	// FailPaths always fail with ErrorStatus when the request path starts with one of them.
+	FailPaths []string
+	// RateLimit is the number of requests allowed per RateWindow, 0 disables it.
+	RateLimit  int
+	RateWindow time.Duration
+	// Seed makes latency jitter and error injection reproducible.
+	Seed int64
+}
+
+type Server struct {
+	opts     Options
+	fixtures fs.FS
+	mux      *http.ServeMux
+
+	mu          sync.Mutex
+	rand        *rand.Rand
+	windowStart time.Time
+	windowCount int
+}
+
+type subjectResp struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	SubjectType string `json:"subject_type"`
+	WorkCount   int    `json:"work_count"`
+	Works       []work `json:"works"`
+}
+
+type work struct {
+	Key               string   `json:"key"`
+	Title             string   `json:"title"`
+	EditionCount      int      `json:"edition_count"`
+	CoverID           int      `json:"cover_id,omitempty"`
+	LendingIdentifier string   `json:"lending_identifier,omitempty"`
+	Authors           []author `json:"authors"`
+}
+
+type author struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+type searchResp struct {
+	NumFound int         `json:"numFound"`
+	Start    int         `json:"start"`
+	Docs     []searchDoc `json:"docs"`
+}
+
+type searchDoc struct {
+	Key          string   `json:"key"`
+	Title        string   `json:"title"`
+	EditionCount int      `json:"edition_count"`
+	AuthorName   []string `json:"author_name"`
+	AuthorKey    []string `json:"author_key"`
+	Subject      []string `json:"subject"`
+}
+
+const (
+	defaultRateWindow = time.Second
+	defaultSearchRows = 100
+)
+
+func New(opts Options) (*Server, error) {
+	var (
+		fixtures fs.FS
+		err      error
+	)
+	if opts.FixtureDir != "" {
+		if _, err = os.Stat(opts.FixtureDir); err != nil {
+			return nil, err
+		}
+		fixtures = os.DirFS(opts.FixtureDir)
+	} else {
+		fixtures, err = fs.Sub(embeddedFixtures, "fixtures")
+		if err != nil {
+			return nil, err
+		}
+	}
+	if opts.ErrorStatus == 0 {
+		opts.ErrorStatus = http.StatusInternalServerError
+	}
+	if opts.RateWindow == 0 {
+		opts.RateWindow = defaultRateWindow
+	}
+	seed := opts.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	s := &Server{
+		opts:     opts,
+		fixtures: fixtures,
+		mux:      http.NewServeMux(),
+		rand:     rand.New(rand.NewSource(seed)),
+	}
+	s.mux.HandleFunc("/subjects/", s.getSubject)
+	s.mux.HandleFunc("/works/", s.getWork)
+	s.mux.HandleFunc("/search.json", s.search)
+
+	return s, nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if retryAfter, ok := s.takeRateLimit(); !ok {
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		writeJSON(w, http.StatusTooManyRequests, map[string]string{
+			"error": "rate limit exceeded",
+		})
+		return
+	}
+
+	latency, fail := s.faults(r.URL.Path)
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if fail {
+		writeJSON(w, s.opts.ErrorStatus, map[string]string{
+			"error": "injected failure",
+		})
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) takeRateLimit() (int, bool) {
+	if s.opts.RateLimit <= 0 {
+		return 0, true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.windowStart) >= s.opts.RateWindow {
+		s.windowStart = now
+		s.windowCount = 0
+	}
+	if s.windowCount >= s.opts.RateLimit {
+		retryAfter := s.windowStart.Add(s.opts.RateWindow).Sub(now)
+		return int(retryAfter.Seconds()) + 1, false
+	}
+	s.windowCount++
+	return 0, true
+}
+
+func (s *Server) faults(urlPath string) (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	latency := s.opts.Latency
+	if s.opts.Jitter > 0 {
+		latency += time.Duration(s.rand.Int63n(int64(s.opts.Jitter)))
+	}
+
+	for _, prefix := range s.opts.FailPaths {
+		if strings.HasPrefix(urlPath, prefix) {
+			return latency, true
+		}
+	}
+	return latency, s.opts.ErrorRate > 0 && s.rand.Float64() < s.opts.ErrorRate
+}
+
+func (s *Server) getSubject(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/subjects/"), ".json")
+	if name == "" || !strings.HasSuffix(r.URL.Path, ".json") {
+		writeNotFound(w)
+		return
+	}
+
+	res, err := s.loadSubject(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		// Open Library answers unknown subjects with an empty work list.
+		writeJSON(w, http.StatusOK, subjectResp{
+			Key:         "/subjects/" + name,
+			Name:        name,
+			SubjectType: "subject",
+			Works:       []work{},
+		})
+		return
+	}
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (s *Server) getWork(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/works/"), ".json")
+	if id == "" || !strings.HasSuffix(r.URL.Path, ".json") {
+		writeNotFound(w)
+		return
+	}
+
+	data, err := fs.ReadFile(s.fixtures, path.Join("works", id+".json"))
+	if err == nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+		return
+	}
+
+	// Fall back to the subject fixtures so every listed work can be fetched.
+	for _, item := range s.allWorks() {
+		if item.work.Key == "/works/"+id {
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"key":      item.work.Key,
+				"title":    item.work.Title,
+				"subjects": item.subjects,
+				"type":     map[string]string{"key": "/type/work"},
+			})
+			return
+		}
+	}
+
+	writeNotFound(w)
+}
+
+func (s *Server) search(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	q := strings.ToLower(strings.TrimSpace(query.Get("q")))
+	title := strings.ToLower(strings.TrimSpace(query.Get("title")))
+	authorName := strings.ToLower(strings.TrimSpace(query.Get("author")))
+	subject := strings.ToLower(strings.TrimSpace(query.Get("subject")))
+
+	limit := defaultSearchRows
+	if v, err := strconv.Atoi(query.Get("limit")); err == nil && v > 0 {
+		limit = v
+	}
+	offset := 0
+	if v, err := strconv.Atoi(query.Get("offset")); err == nil && v > 0 {
+		offset = v
+	}
+
+	docs := []searchDoc{}
+	for _, item := range s.allWorks() {
+		doc := searchDoc{
+			Key:          item.work.Key,
+			Title:        item.work.Title,
+			EditionCount: item.work.EditionCount,
+			AuthorName:   []string{},
+			AuthorKey:    []string{},
+			Subject:      item.subjects,
+		}
+		for _, a := range item.work.Authors {
+			doc.AuthorName = append(doc.AuthorName, a.Name)
+			doc.AuthorKey = append(doc.AuthorKey, strings.TrimPrefix(a.Key, "/authors/"))
+		}
+
+		authors := strings.ToLower(strings.Join(doc.AuthorName, " "))
+		subjects := strings.ToLower(strings.Join(doc.Subject, " "))
+		switch {
+		case q != "" && !strings.Contains(strings.ToLower(doc.Title)+" "+authors+" "+subjects, q):
+			continue
+		case title != "" && !strings.Contains(strings.ToLower(doc.Title), title):
+			continue
+		case authorName != "" && !strings.Contains(authors, authorName):
+			continue
+		case subject != "" && !strings.Contains(subjects, subject):
+			continue
+		}
+		docs = append(docs, doc)
+	}
+
+	res := searchResp{
+		NumFound: len(docs),
+		Start:    offset,
+		Docs:     []searchDoc{},
+	}
+	if offset < len(docs) {
+		end := offset + limit
+		if end > len(docs) {
+			end = len(docs)
+		}
+		res.Docs = docs[offset:end]
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+type indexedWork struct {
+	work     work
+	subjects []string
+}
+
+// allWorks merges every subject fixture into one list, keeping the subjects each work appears under.
+func (s *Server) allWorks() []indexedWork {
+	entries, err := fs.ReadDir(s.fixtures, "subjects")
+	if err != nil {
+		return nil
+	}
+
+	res := []indexedWork{}
+	index := map[string]int{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		subject, err := s.loadSubject(strings.TrimSuffix(entry.Name(), ".json"))
+		if err != nil {
+			continue
+		}
+		for _, item := range subject.Works {
+			if i, ok := index[item.Key]; ok {
+				res[i].subjects = append(res[i].subjects, subject.Name)
+				continue
+			}
+			index[item.Key] = len(res)
+			res = append(res, indexedWork{
+				work:     item,
+				subjects: []string{subject.Name},
+			})
+		}
+	}
+	return res
+}
+
+func (s *Server) loadSubject(name string) (subjectResp, error) {
+	res := subjectResp{}
+
+	data, err := fs.ReadFile(s.fixtures, path.Join("subjects", name+".json"))
+	if err != nil {
+		return res, err
+	}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return res, err
+	}
+	if res.Name == "" {
+		res.Name = name
+	}
+	return res, nil
+}
+
+func writeNotFound(w http.ResponseWriter) {
+	writeJSON(w, http.StatusNotFound, map[string]string{
+		"error": "notfound",
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	data, _ := json.Marshal(body)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
@@ -0,0 +1,155 @@
+package fakeopenlibrary
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestServer(t *testing.T, opts Options) *httptest.Server {
+	s, err := New(opts)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func get(t *testing.T, url string, out interface{}) *http.Response {
+	res, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s error = %v", url, err)
+	}
+	defer res.Body.Close()
+	if out != nil {
+		if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+			t.Fatalf("GET %s decode error = %v", url, err)
+		}
+	}
+	return res
+}
+
+func Test_Routes(t *testing.T) {
+	srv := newTestServer(t, Options{})
+
+	subject := subjectResp{}
+	if res := get(t, srv.URL+"/subjects/love.json", &subject); res.StatusCode != http.StatusOK {
+		t.Fatalf("subject status = %v", res.StatusCode)
+	}
+	if subject.Name != "love" || len(subject.Works) != 3 || subject.Works[0].Key != "/works/OL98501W" {
+		t.Errorf("subject = %+v", subject)
+	}
+
+	unknown := subjectResp{}
+	if res := get(t, srv.URL+"/subjects/nothing.json", &unknown); res.StatusCode != http.StatusOK || len(unknown.Works) != 0 {
+		t.Errorf("unknown subject = %v %+v", res.StatusCode, unknown)
+	}
+
+	fromFixture := map[string]interface{}{}
+	if res := get(t, srv.URL+"/works/OL98501W.json", &fromFixture); res.StatusCode != http.StatusOK || fromFixture["title"] != "Pride and Prejudice" {
+		t.Errorf("work fixture = %v %v", res.StatusCode, fromFixture)
+	}
+
+	fromSubject := map[string]interface{}{}
+	if res := get(t, srv.URL+"/works/OL27448W.json", &fromSubject); res.StatusCode != http.StatusOK || fromSubject["title"] != "The Lord of the Rings" {
+		t.Errorf("work from subject = %v %v", res.StatusCode, fromSubject)
+	}
+
+	if res := get(t, srv.URL+"/works/OL0W.json", nil); res.StatusCode != http.StatusNotFound {
+		t.Errorf("missing work status = %v", res.StatusCode)
+	}
+}
+
+func Test_Search(t *testing.T) {
+	srv := newTestServer(t, Options{})
+
+	tests := []struct {
+		name     string
+		query    string
+		wantKeys []string
+		numFound int
+	}{
+		{name: "by title", query: "?title=wuthering", wantKeys: []string{"/works/OL45804W"}, numFound: 1},
+		{name: "by author", query: "?author=tolkien", wantKeys: []string{"/works/OL27448W"}, numFound: 1},
+		{name: "by q", query: "?q=austen", wantKeys: []string{"/works/OL98501W"}, numFound: 1},
+		{name: "by subject with limit", query: "?subject=fantasy&limit=1", wantKeys: []string{"/works/OL27448W"}, numFound: 2},
+		{name: "offset past results", query: "?subject=fantasy&offset=5", wantKeys: []string{}, numFound: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := searchResp{}
+			get(t, srv.URL+"/search.json"+tt.query, &res)
+			if res.NumFound != tt.numFound || len(res.Docs) != len(tt.wantKeys) {
+				t.Fatalf("search = %+v", res)
+			}
+			for i, key := range tt.wantKeys {
+				if res.Docs[i].Key != key {
+					t.Errorf("search doc %d = %v, want %v", i, res.Docs[i].Key, key)
+				}
+			}
+		})
+	}
+}
+
+func Test_FixtureDir(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "subjects"), 0755)
+	os.WriteFile(filepath.Join(dir, "subjects", "history.json"), []byte(`{"key": "/subjects/history", "works": [{"key": "/works/OL1W", "title": "History"}]}`), 0644)
+
+	srv := newTestServer(t, Options{FixtureDir: dir})
+	subject := subjectResp{}
+	get(t, srv.URL+"/subjects/history.json", &subject)
+	if len(subject.Works) != 1 || subject.Works[0].Title != "History" {
+		t.Errorf("subject = %+v", subject)
+	}
+
+	if _, err := New(Options{FixtureDir: filepath.Join(dir, "missing")}); err == nil {
+		t.Errorf("New() expected error for missing fixture dir")
+	}
+}
+
+func Test_Faults(t *testing.T) {
+	t.Run("fail paths", func(t *testing.T) {
+		srv := newTestServer(t, Options{FailPaths: []string{"/subjects/love"}, ErrorStatus: http.StatusBadGateway})
+		if res := get(t, srv.URL+"/subjects/love.json", nil); res.StatusCode != http.StatusBadGateway {
+			t.Errorf("status = %v, want %v", res.StatusCode, http.StatusBadGateway)
+		}
+		if res := get(t, srv.URL+"/subjects/fantasy.json", nil); res.StatusCode != http.StatusOK {
+			t.Errorf("status = %v, want %v", res.StatusCode, http.StatusOK)
+		}
+	})
+
+	t.Run("error rate", func(t *testing.T) {
+		srv := newTestServer(t, Options{ErrorRate: 1})
+		if res := get(t, srv.URL+"/subjects/love.json", nil); res.StatusCode != http.StatusInternalServerError {
+			t.Errorf("status = %v, want %v", res.StatusCode, http.StatusInternalServerError)
+		}
+	})
+
+	t.Run("latency", func(t *testing.T) {
+		srv := newTestServer(t, Options{Latency: 50 * time.Millisecond})
+		start := time.Now()
+		get(t, srv.URL+"/subjects/love.json", nil)
+		if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+			t.Errorf("elapsed = %v, want at least 50ms", elapsed)
+		}
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		srv := newTestServer(t, Options{RateLimit: 2, RateWindow: time.Minute})
+		for i := 0; i < 2; i++ {
+			if res := get(t, srv.URL+"/subjects/love.json", nil); res.StatusCode != http.StatusOK {
+				t.Fatalf("request %d status = %v", i, res.StatusCode)
+			}
+		}
+		res := get(t, srv.URL+"/subjects/love.json", nil)
+		if res.StatusCode != http.StatusTooManyRequests || res.Header.Get("Retry-After") == "" {
+			t.Errorf("status = %v, Retry-After = %q", res.StatusCode, res.Header.Get("Retry-After"))
+		}
+	})
+}
@@ -19,7 +19,7 @@ type HttpIFace interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
-func New(dep HttpClientDep) HttpIFace {
+func New(dep HttpClientDep) (HttpIFace, error) {
 	client := http.Client{
 		Timeout: time.Duration(dep.Config.HttpClientConfig.TimeoutMS) * time.Millisecond,
 		Transport: &http.Transport{
@@ -30,9 +30,11 @@ func New(dep HttpClientDep) HttpIFace {
 		},
 	}
 
-	return &httpModule{
+	limited := NewLimiter(&httpModule{
 		client: &client,
-	}
+	}, dep.Config.HttpClientConfig)
+
+	return NewRecorder(limited, ResolveRecorderConfig(dep.Config.HttpClientConfig.Recorder))
 }
 
 func (hm *httpModule) Do(req *http.Request) (*http.Response, error) {
@@ -0,0 +1,256 @@
+package httpclient
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+)
+
+// ErrUpstreamThrottled is matched by every ThrottledError through errors.Is.
+var ErrUpstreamThrottled = errors.New("upstream throttled")
+
+// ThrottledError is returned when a request cannot get a rate limit token or a pool slot in time.
+type ThrottledError struct {
+	Host       string
+	Reason     string
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("upstream throttled: %s for %s, retry after %s", e.Reason, e.Host, e.RetryAfter)
+}
+
+func (e *ThrottledError) Is(target error) bool {
+	return target == ErrUpstreamThrottled
+}
+
+// limitedModule keeps traffic to each upstream host within a token bucket and caps concurrent requests.
+type limitedModule struct {
+	next      HttpIFace
+	userAgent string
+	rate      float64
+	burst     float64
+	hostRates map[string]config.HostRateLimit
+	maxWait   time.Duration
+	pool      chan struct{}
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+// NewLimiter wraps next with the rate limit, pool and User-Agent settings in cfg.
+func NewLimiter(next HttpIFace, cfg config.HttpClientConfig) HttpIFace {
+	rl := cfg.RateLimit
+	if cfg.UserAgent == "" && rl.RequestsPerSecond <= 0 && rl.MaxConcurrent <= 0 && len(rl.Hosts) == 0 {
+		return next
+	}
+
+	lm := &limitedModule{
+		next:      next,
+		userAgent: userAgent(cfg),
+		rate:      rl.RequestsPerSecond,
+		burst:     float64(rl.Burst),
+		hostRates: rl.Hosts,
+		maxWait:   time.Duration(rl.MaxWaitMS) * time.Millisecond,
+		buckets:   make(map[string]*tokenBucket),
+	}
+	if rl.MaxConcurrent > 0 {
+		lm.pool = make(chan struct{}, rl.MaxConcurrent)
+	}
+	return lm
+}
+
+func userAgent(cfg config.HttpClientConfig) string {
+	if cfg.UserAgent == "" || cfg.Contact == "" {
+		return cfg.UserAgent
+	}
+	return fmt.Sprintf("%s (%s)", cfg.UserAgent, cfg.Contact)
+}
+
+func (lm *limitedModule) Do(req *http.Request) (*http.Response, error) {
+	if lm.userAgent != "" && req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", lm.userAgent)
+	}
+
+	host := req.URL.Hostname()
+	deadline := time.Now().Add(lm.maxWait)
+	if d, ok := req.Context().Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+
+	bucket, err := lm.waitToken(req, host, deadline)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := lm.acquireSlot(req, host, deadline)
+	if err != nil {
+		// The request is not sent, so its token goes back for the next one.
+		if bucket != nil {
+			bucket.cancel()
+		}
+		return nil, err
+	}
+
+	res, err := lm.next.Do(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	res.Body = &releaseOnClose{ReadCloser: res.Body, release: release}
+	return res, nil
+}
+
+// waitToken takes a token of the host's bucket and waits until it may be used, returning the bucket it came from.
+func (lm *limitedModule) waitToken(req *http.Request, host string, deadline time.Time) (*tokenBucket, error) {
+	bucket := lm.bucket(host)
+	if bucket == nil {
+		return nil, nil
+	}
+
+	maxWait := time.Until(deadline)
+	if maxWait < 0 {
+		maxWait = 0
+	}
+
+	wait, ok := bucket.reserve(time.Now(), maxWait)
+	if !ok {
+		return nil, &ThrottledError{Host: host, Reason: "rate limit exhausted", RetryAfter: wait}
+	}
+	if wait <= 0 {
+		return bucket, nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return bucket, nil
+	case <-req.Context().Done():
+		bucket.cancel()
+		return nil, req.Context().Err()
+	}
+}
+
+func (lm *limitedModule) acquireSlot(req *http.Request, host string, deadline time.Time) (func(), error) {
+	if lm.pool == nil {
+		return func() {}, nil
+	}
+
+	release := func() { <-lm.pool }
+	select {
+	case lm.pool <- struct{}{}:
+		return release, nil
+	default:
+	}
+
+	wait := time.Until(deadline)
+	if wait <= 0 {
+		return nil, &ThrottledError{Host: host, Reason: "request pool full"}
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case lm.pool <- struct{}{}:
+		return release, nil
+	case <-timer.C:
+		return nil, &ThrottledError{Host: host, Reason: "request pool full"}
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+}
+
+func (lm *limitedModule) bucket(host string) *tokenBucket {
+	rate, burst := lm.rate, lm.burst
+	if override, ok := lm.hostRates[host]; ok {
+		rate, burst = override.RequestsPerSecond, float64(override.Burst)
+	}
+	if rate <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	b, ok := lm.buckets[host]
+	if !ok {
+		b = newTokenBucket(rate, burst)
+		lm.buckets[host] = b
+	}
+	return b
+}
+
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate, burst float64) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+	}
+}
+
+// reserve takes a token and returns how long the caller has to wait before using it.
+// Nothing is taken when the wait would be longer than maxWait.
+func (b *tokenBucket) reserve(now time.Time, maxWait time.Duration) (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.last.IsZero() {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+
+	tokens := b.tokens - 1
+	var wait time.Duration
+	if tokens < 0 {
+		wait = time.Duration(-tokens / b.rate * float64(time.Second))
+	}
+	if wait > maxWait {
+		return wait, false
+	}
+
+	b.tokens = tokens
+	return wait, true
+}
+
+// cancel gives back a token whose request gave up while waiting.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens++
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (r *releaseOnClose) Close() error {
+	err := r.ReadCloser.Close()
+	r.once.Do(r.release)
+	return err
+}
@@ -0,0 +1,210 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+)
+
+func Test_tokenBucket(t *testing.T) {
+	now := time.Now()
+	b := newTokenBucket(2, 2)
+
+	for i := 0; i < 2; i++ {
+		if wait, ok := b.reserve(now, 0); !ok || wait != 0 {
+			t.Fatalf("reserve() burst %d = %v, %v", i, wait, ok)
+		}
+	}
+	if wait, ok := b.reserve(now, 0); ok || wait != 500*time.Millisecond {
+		t.Errorf("reserve() without wait = %v, %v, want 500ms refused", wait, ok)
+	}
+	if wait, ok := b.reserve(now, time.Second); !ok || wait != 500*time.Millisecond {
+		t.Errorf("reserve() queued = %v, %v, want 500ms", wait, ok)
+	}
+
+	// One second refills two tokens, one of them pays back the queued reservation.
+	if wait, ok := b.reserve(now.Add(time.Second), 0); !ok || wait != 0 {
+		t.Errorf("reserve() after refill = %v, %v", wait, ok)
+	}
+
+	b.cancel()
+	if b.tokens > b.burst {
+		t.Errorf("cancel() tokens = %v above burst %v", b.tokens, b.burst)
+	}
+}
+
+func Test_NewLimiter(t *testing.T) {
+	next := &httpModule{client: http.DefaultClient}
+	if got := NewLimiter(next, config.HttpClientConfig{}); got != next {
+		t.Errorf("NewLimiter() without settings should return next")
+	}
+	got := NewLimiter(next, config.HttpClientConfig{UserAgent: "book-project/1.0", Contact: "dev@example.com"})
+	if lm, ok := got.(*limitedModule); !ok || lm.userAgent != "book-project/1.0 (dev@example.com)" {
+		t.Errorf("NewLimiter() = %+v", got)
+	}
+}
+
+func Test_LimiterUserAgentAndRate(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		agents []string
+	)
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		agents = append(agents, r.Header.Get("User-Agent"))
+		mu.Unlock()
+	}))
+	defer upstream.Close()
+
+	client := NewLimiter(&httpModule{client: upstream.Client()}, config.HttpClientConfig{
+		UserAgent: "book-project/1.0",
+		Contact:   "dev@example.com",
+		RateLimit: config.UpstreamLimit{
+			RequestsPerSecond: 1,
+			Burst:             1,
+			MaxWaitMS:         0,
+		},
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, upstream.URL, nil)
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	req, _ = http.NewRequest(http.MethodGet, upstream.URL, nil)
+	_, err = client.Do(req)
+	throttled := &ThrottledError{}
+	if !errors.Is(err, ErrUpstreamThrottled) || !errors.As(err, &throttled) || throttled.RetryAfter <= 0 {
+		t.Errorf("Do() error = %v, want throttled with retry after", err)
+	}
+
+	if len(agents) != 1 || agents[0] != "book-project/1.0 (dev@example.com)" {
+		t.Errorf("User-Agent = %v", agents)
+	}
+}
+
+func Test_LimiterQueuesWithinDeadline(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer upstream.Close()
+
+	client := NewLimiter(&httpModule{client: upstream.Client()}, config.HttpClientConfig{
+		RateLimit: config.UpstreamLimit{
+			RequestsPerSecond: 20,
+			Burst:             1,
+			MaxWaitMS:         500,
+		},
+	})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		req, _ := http.NewRequest(http.MethodGet, upstream.URL, nil)
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Do() %d error = %v", i, err)
+		}
+		res.Body.Close()
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("elapsed = %v, want requests to be spaced by the rate", elapsed)
+	}
+
+	// A context deadline shorter than the queue wait fails fast as throttled.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	client.Do(mustRequest(t, context.Background(), upstream.URL))
+	if _, err := client.Do(mustRequest(t, ctx, upstream.URL)); !errors.Is(err, ErrUpstreamThrottled) {
+		t.Errorf("Do() error = %v, want throttled", err)
+	}
+}
+
+func Test_LimiterPool(t *testing.T) {
+	release := make(chan struct{})
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer upstream.Close()
+
+	client := NewLimiter(&httpModule{client: upstream.Client()}, config.HttpClientConfig{
+		RateLimit: config.UpstreamLimit{
+			MaxConcurrent: 1,
+			MaxWaitMS:     50,
+		},
+	})
+
+	done := make(chan *http.Response)
+	go func() {
+		res, _ := client.Do(mustRequest(t, context.Background(), upstream.URL))
+		done <- res
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	_, err := client.Do(mustRequest(t, context.Background(), upstream.URL))
+	if !errors.Is(err, ErrUpstreamThrottled) {
+		t.Errorf("Do() error = %v, want throttled while the pool is full", err)
+	}
+
+	close(release)
+	res := <-done
+	res.Body.Close()
+
+	res, err = client.Do(mustRequest(t, context.Background(), upstream.URL))
+	if err != nil {
+		t.Fatalf("Do() after release error = %v", err)
+	}
+	res.Body.Close()
+}
+
+func Test_LimiterPoolTimeoutKeepsToken(t *testing.T) {
+	release := make(chan struct{})
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer upstream.Close()
+
+	// Two tokens and hardly any refill: a request turned away by the full pool must not use up the second one.
+	client := NewLimiter(&httpModule{client: upstream.Client()}, config.HttpClientConfig{
+		RateLimit: config.UpstreamLimit{
+			RequestsPerSecond: 0.01,
+			Burst:             2,
+			MaxConcurrent:     1,
+			MaxWaitMS:         50,
+		},
+	})
+
+	done := make(chan *http.Response)
+	go func() {
+		res, _ := client.Do(mustRequest(t, context.Background(), upstream.URL))
+		done <- res
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	if _, err := client.Do(mustRequest(t, context.Background(), upstream.URL)); !errors.Is(err, ErrUpstreamThrottled) {
+		t.Errorf("Do() error = %v, want throttled while the pool is full", err)
+	}
+
+	close(release)
+	res := <-done
+	res.Body.Close()
+
+	res, err := client.Do(mustRequest(t, context.Background(), upstream.URL))
+	if err != nil {
+		t.Fatalf("Do() after release error = %v, want the token given back by the throttled request", err)
+	}
+	res.Body.Close()
+}
+
+func mustRequest(t *testing.T, ctx context.Context, url string) *http.Request {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return req
+}
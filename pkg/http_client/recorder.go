@@ -0,0 +1,325 @@
+package httpclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+)
+
+const (
+	RecorderModeOff    = "off"
+	RecorderModeRecord = "record"
+	RecorderModeReplay = "replay"
+	// RecorderModeAuto replays known interactions and records the ones missing from the cassette.
+	RecorderModeAuto = "auto"
+
+	MatchMethod = "method"
+	MatchHost   = "host"
+	MatchPath   = "path"
+	MatchQuery  = "query"
+	MatchBody   = "body"
+
+	// EnvRecorderMode and EnvRecorderCassette override httpclientconfig.recorder.
+	EnvRecorderMode     = "BOOK_PROJECT_RECORDER_MODE"
+	EnvRecorderCassette = "BOOK_PROJECT_RECORDER_CASSETTE"
+
+	redactedValue = "REDACTED"
+)
+
+var (
+	ErrInteractionNotFound = errors.New("httpclient: no recorded interaction matches the request")
+
+	defaultMatchOn       = []string{MatchMethod, MatchHost, MatchPath, MatchQuery}
+	defaultRedactHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+)
+
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request    RecordedRequest  `json:"request"`
+	Response   RecordedResponse `json:"response"`
+	RecordedAt time.Time        `json:"recorded_at"`
+}
+
+type RecordedRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// recorderModule records upstream interactions into a cassette file and replays them deterministically.
+type recorderModule struct {
+	next              HttpIFace
+	mode              string
+	path              string
+	matchOn           []string
+	redactHeaders     map[string]bool
+	redactQueryParams map[string]bool
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     map[int]bool
+}
+
+// ResolveRecorderConfig applies the environment overrides to the configured recorder.
+func ResolveRecorderConfig(cfg config.RecorderConfig) config.RecorderConfig {
+	if mode := os.Getenv(EnvRecorderMode); mode != "" {
+		cfg.Mode = mode
+	}
+	if cassette := os.Getenv(EnvRecorderCassette); cassette != "" {
+		cfg.CassettePath = cassette
+	}
+	if cfg.Mode == "" {
+		cfg.Mode = RecorderModeOff
+	}
+	return cfg
+}
+
+// NewRecorder wraps next with a recorder, or returns next untouched when the mode is off.
+func NewRecorder(next HttpIFace, cfg config.RecorderConfig) (HttpIFace, error) {
+	switch cfg.Mode {
+	case "", RecorderModeOff:
+		return next, nil
+	case RecorderModeRecord, RecorderModeReplay, RecorderModeAuto:
+	default:
+		return nil, fmt.Errorf("unknown recorder mode %q", cfg.Mode)
+	}
+	if cfg.CassettePath == "" {
+		return nil, errors.New("recorder cassettepath is required")
+	}
+
+	rm := &recorderModule{
+		next:              next,
+		mode:              cfg.Mode,
+		path:              cfg.CassettePath,
+		matchOn:           cfg.MatchOn,
+		redactHeaders:     make(map[string]bool),
+		redactQueryParams: make(map[string]bool),
+		used:              make(map[int]bool),
+	}
+	if len(rm.matchOn) == 0 {
+		rm.matchOn = defaultMatchOn
+	}
+	for _, item := range append(defaultRedactHeaders, cfg.RedactHeaders...) {
+		rm.redactHeaders[http.CanonicalHeaderKey(item)] = true
+	}
+	for _, item := range cfg.RedactQueryParams {
+		rm.redactQueryParams[item] = true
+	}
+
+	data, err := ioutil.ReadFile(rm.path)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &rm.cassette); err != nil {
+			return nil, fmt.Errorf("cassette %s: %w", rm.path, err)
+		}
+	case os.IsNotExist(err) && rm.mode != RecorderModeReplay:
+	default:
+		return nil, err
+	}
+
+	return rm, nil
+}
+
+func (rm *recorderModule) Do(req *http.Request) (*http.Response, error) {
+	recorded, err := rm.recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if rm.mode != RecorderModeRecord {
+		if res, ok := rm.replay(req, recorded); ok {
+			return res, nil
+		}
+		if rm.mode == RecorderModeReplay {
+			return nil, fmt.Errorf("%w: %s %s", ErrInteractionNotFound, recorded.Method, recorded.URL)
+		}
+	}
+
+	res, err := rm.next.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	if err := rm.save(Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: res.StatusCode,
+			Headers:    rm.redactHeader(res.Header),
+			Body:       string(body),
+		},
+		RecordedAt: time.Now().UTC(),
+	}); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (rm *recorderModule) replay(req *http.Request, recorded RecordedRequest) (*http.Response, bool) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	// Identical requests are answered in recording order, the last match is reused once they run out.
+	last := -1
+	for i, item := range rm.cassette.Interactions {
+		if !rm.matches(item.Request, recorded) {
+			continue
+		}
+		last = i
+		if !rm.used[i] {
+			break
+		}
+	}
+	if last < 0 {
+		return nil, false
+	}
+	rm.used[last] = true
+
+	item := rm.cassette.Interactions[last].Response
+	header := http.Header{}
+	for k, v := range item.Headers {
+		header[k] = append([]string(nil), v...)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", item.StatusCode, http.StatusText(item.StatusCode)),
+		StatusCode:    item.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(item.Body)),
+		ContentLength: int64(len(item.Body)),
+		Request:       req,
+	}, true
+}
+
+func (rm *recorderModule) save(item Interaction) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.cassette.Interactions = append(rm.cassette.Interactions, item)
+	rm.used[len(rm.cassette.Interactions)-1] = true
+
+	data, err := json.MarshalIndent(rm.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(rm.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(rm.path, data, 0644)
+}
+
+func (rm *recorderModule) recordRequest(req *http.Request) (RecordedRequest, error) {
+	res := RecordedRequest{
+		Method:  req.Method,
+		URL:     rm.redactURL(req.URL),
+		Headers: rm.redactHeader(req.Header),
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return res, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		res.Body = string(body)
+	}
+
+	return res, nil
+}
+
+func (rm *recorderModule) matches(recorded, incoming RecordedRequest) bool {
+	a, errA := url.Parse(recorded.URL)
+	b, errB := url.Parse(incoming.URL)
+	if errA != nil || errB != nil {
+		return false
+	}
+
+	for _, rule := range rm.matchOn {
+		switch rule {
+		case MatchMethod:
+			if recorded.Method != incoming.Method {
+				return false
+			}
+		case MatchHost:
+			if a.Host != b.Host {
+				return false
+			}
+		case MatchPath:
+			if a.Path != b.Path {
+				return false
+			}
+		case MatchQuery:
+			if a.Query().Encode() != b.Query().Encode() {
+				return false
+			}
+		case MatchBody:
+			if recorded.Body != incoming.Body {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (rm *recorderModule) redactURL(u *url.URL) string {
+	if len(rm.redactQueryParams) == 0 || u.RawQuery == "" {
+		return u.String()
+	}
+
+	redacted := *u
+	query := redacted.Query()
+	for key := range query {
+		if rm.redactQueryParams[key] {
+			query.Set(key, redactedValue)
+		}
+	}
+	redacted.RawQuery = query.Encode()
+	return redacted.String()
+}
+
+func (rm *recorderModule) redactHeader(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+
+	res := http.Header{}
+	for key, values := range h {
+		if rm.redactHeaders[http.CanonicalHeaderKey(key)] {
+			res[key] = []string{redactedValue}
+			continue
+		}
+		res[key] = append([]string(nil), values...)
+	}
+	return res
+}
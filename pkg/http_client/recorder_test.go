@@ -0,0 +1,176 @@
+package httpclient
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/config"
+)
+
+func newUpstream(t *testing.T, hits *int32) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(hits, 1)
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.Header().Set("X-Hit", string(rune('0'+n)))
+		w.Write([]byte(`{"path": "` + r.URL.Path + `"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func doGet(t *testing.T, client HttpIFace, url string, header http.Header) (*http.Response, string, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer res.Body.Close()
+	body, _ := ioutil.ReadAll(res.Body)
+	return res, string(body), nil
+}
+
+func Test_ResolveRecorderConfig(t *testing.T) {
+	got := ResolveRecorderConfig(config.RecorderConfig{})
+	if got.Mode != RecorderModeOff {
+		t.Errorf("ResolveRecorderConfig() mode = %v, want %v", got.Mode, RecorderModeOff)
+	}
+
+	t.Setenv(EnvRecorderMode, RecorderModeReplay)
+	t.Setenv(EnvRecorderCassette, "env.json")
+	got = ResolveRecorderConfig(config.RecorderConfig{Mode: RecorderModeRecord, CassettePath: "cfg.json"})
+	if got.Mode != RecorderModeReplay || got.CassettePath != "env.json" {
+		t.Errorf("ResolveRecorderConfig() = %+v, want env overrides", got)
+	}
+}
+
+func Test_NewRecorder(t *testing.T) {
+	next := &httpModule{client: http.DefaultClient}
+	dir := t.TempDir()
+
+	tests := []struct {
+		name        string
+		cfg         config.RecorderConfig
+		wantWrapped bool
+		wantErr     bool
+	}{
+		{name: "off", cfg: config.RecorderConfig{Mode: RecorderModeOff}, wantWrapped: false},
+		{name: "record new cassette", cfg: config.RecorderConfig{Mode: RecorderModeRecord, CassettePath: filepath.Join(dir, "new.json")}, wantWrapped: true},
+		{name: "replay missing cassette", cfg: config.RecorderConfig{Mode: RecorderModeReplay, CassettePath: filepath.Join(dir, "missing.json")}, wantErr: true},
+		{name: "missing path", cfg: config.RecorderConfig{Mode: RecorderModeAuto}, wantErr: true},
+		{name: "unknown mode", cfg: config.RecorderConfig{Mode: "rewind"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewRecorder(next, tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewRecorder() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if _, wrapped := got.(*recorderModule); wrapped != tt.wantWrapped {
+				t.Errorf("NewRecorder() wrapped = %v, want %v", wrapped, tt.wantWrapped)
+			}
+		})
+	}
+}
+
+func Test_RecordThenReplay(t *testing.T) {
+	var hits int32
+	upstream := newUpstream(t, &hits)
+	path := filepath.Join(t.TempDir(), "cassettes", "upstream.json")
+
+	recorder, err := NewRecorder(&httpModule{client: upstream.Client()}, config.RecorderConfig{
+		Mode:              RecorderModeRecord,
+		CassettePath:      path,
+		RedactHeaders:     []string{"X-Secret"},
+		RedactQueryParams: []string{"api_key"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []string{"/subjects/love.json?api_key=abc", "/subjects/love.json?api_key=abc", "/works/OL1W.json"} {
+		if _, _, err := doGet(t, recorder, upstream.URL+p, http.Header{"X-Secret": {"token"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if hits != 3 {
+		t.Fatalf("upstream hits = %v, want 3", hits)
+	}
+
+	data, _ := os.ReadFile(path)
+	for _, secret := range []string{"api_key=abc", "token", "session=secret"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q", secret)
+		}
+	}
+
+	replayer, err := NewRecorder(&httpModule{client: upstream.Client()}, config.RecorderConfig{
+		Mode:              RecorderModeReplay,
+		CassettePath:      path,
+		RedactQueryParams: []string{"api_key"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Identical requests come back in recording order, then the last one repeats.
+	wantHits := []string{"1", "2", "2"}
+	for i, want := range wantHits {
+		res, body, err := doGet(t, replayer, upstream.URL+"/subjects/love.json?api_key=other", nil)
+		if err != nil {
+			t.Fatalf("replay %d error = %v", i, err)
+		}
+		if res.StatusCode != http.StatusOK || body != `{"path": "/subjects/love.json"}` || res.Header.Get("X-Hit") != want {
+			t.Errorf("replay %d = %v %v %v, want hit %v", i, res.StatusCode, body, res.Header.Get("X-Hit"), want)
+		}
+	}
+
+	if _, _, err := doGet(t, replayer, upstream.URL+"/subjects/unknown.json", nil); !errors.Is(err, ErrInteractionNotFound) {
+		t.Errorf("replay unknown error = %v, want %v", err, ErrInteractionNotFound)
+	}
+	if hits != 3 {
+		t.Errorf("replay reached upstream, hits = %v", hits)
+	}
+}
+
+func Test_AutoModeAndMatching(t *testing.T) {
+	var hits int32
+	upstream := newUpstream(t, &hits)
+	path := filepath.Join(t.TempDir(), "auto.json")
+
+	auto, err := NewRecorder(&httpModule{client: upstream.Client()}, config.RecorderConfig{
+		Mode:         RecorderModeAuto,
+		CassettePath: path,
+		MatchOn:      []string{MatchMethod, MatchPath},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doGet(t, auto, upstream.URL+"/subjects/love.json?limit=1", nil)
+	// The query is not part of the match rules, so this is answered from the cassette.
+	_, body, _ := doGet(t, auto, upstream.URL+"/subjects/love.json?limit=2", nil)
+	doGet(t, auto, upstream.URL+"/subjects/fantasy.json", nil)
+
+	if hits != 2 {
+		t.Errorf("upstream hits = %v, want 2", hits)
+	}
+	if body != `{"path": "/subjects/love.json"}` {
+		t.Errorf("auto replay body = %v", body)
+	}
+}
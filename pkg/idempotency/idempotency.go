@@ -0,0 +1,36 @@
+package idempotency
+
+import (
+	"context"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+type IResource interface {
+	BeginIdempotency(ctx context.Context, req domain.BeginIdempotencyReq) (domain.IdempotencyRecord, bool, error)
+	CompleteIdempotency(ctx context.Context, req domain.CompleteIdempotencyReq) error
+	ReleaseIdempotency(ctx context.Context, req domain.ReleaseIdempotencyReq) error
+}
+
+type module struct {
+	persistent persistent
+}
+
+func New(cfg *config.GlobalConfig) (IResource, error) {
+	return &module{
+		persistent: newPersistent(),
+	}, nil
+}
+
+func (m module) BeginIdempotency(ctx context.Context, req domain.BeginIdempotencyReq) (domain.IdempotencyRecord, bool, error) {
+	return m.persistent.beginIdempotency(ctx, req)
+}
+
+func (m module) CompleteIdempotency(ctx context.Context, req domain.CompleteIdempotencyReq) error {
+	return m.persistent.completeIdempotency(ctx, req)
+}
+
+func (m module) ReleaseIdempotency(ctx context.Context, req domain.ReleaseIdempotencyReq) error {
+	return m.persistent.releaseIdempotency(ctx, req)
+}
@@ -0,0 +1,35 @@
+package idempotency
+
+import (
+	"context"
+	reflect "reflect"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+func TestNew(t *testing.T) {
+	got, err := New(&config.GlobalConfig{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if want := (&module{persistent: newPersistent()}); !reflect.DeepEqual(got, want) {
+		t.Errorf("New() = %v, want %v", got, want)
+	}
+}
+
+func Test_BeginIdempotency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	req := domain.BeginIdempotencyReq{Scope: "/borrow-book", Key: "abc", Fingerprint: "f1"}
+
+	pstMock := NewMockpersistent(ctrl)
+	pstMock.EXPECT().beginIdempotency(gomock.Any(), req).Return(domain.IdempotencyRecord{Key: "abc"}, true, nil)
+	m := module{persistent: pstMock}
+
+	got, started, err := m.BeginIdempotency(context.Background(), req)
+	if err != nil || !started || got.Key != "abc" {
+		t.Errorf("BeginIdempotency() = %v, %v, %v", got, started, err)
+	}
+}
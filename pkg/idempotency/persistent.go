@@ -0,0 +1,112 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+type persistent interface {
+	beginIdempotency(ctx context.Context, req domain.BeginIdempotencyReq) (domain.IdempotencyRecord, bool, error)
+	completeIdempotency(ctx context.Context, req domain.CompleteIdempotencyReq) error
+	releaseIdempotency(ctx context.Context, req domain.ReleaseIdempotencyReq) error
+}
+
+type persistentModule struct {
+}
+
+func newPersistent() persistent {
+	return &persistentModule{}
+}
+
+var (
+	// mu guards records.
+	mu sync.Mutex
+
+	records map[string]domain.IdempotencyRecord = make(map[string]domain.IdempotencyRecord) // keyed by recordKey
+
+	timeNow = time.Now
+)
+
+// beginIdempotency returns the record of the key and whether the caller claimed it and should run the request.
+// A completed record with the same fingerprint is returned for replay. A key used for another request fails with
+// ErrIdempotencyKeyReused, one whose first request is still running with ErrIdempotencyInProgress.
+func (m *persistentModule) beginIdempotency(ctx context.Context, req domain.BeginIdempotencyReq) (domain.IdempotencyRecord, bool, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	now := timeNow()
+	pruneRecords(now)
+
+	key := recordKey(req.Scope, req.Key)
+	if record, ok := records[key]; ok {
+		switch {
+		case record.Fingerprint != req.Fingerprint:
+			return domain.IdempotencyRecord{}, false, domain.ErrIdempotencyKeyReused
+		case record.Status == domain.IdempotencyStatusCompleted:
+			return record, false, nil
+		case now.Before(record.LockedUntil):
+			return domain.IdempotencyRecord{}, false, domain.ErrIdempotencyInProgress
+		}
+		// The first request never completed, the retry takes over its claim.
+	}
+
+	record := domain.IdempotencyRecord{
+		Scope:       req.Scope,
+		Key:         req.Key,
+		Fingerprint: req.Fingerprint,
+		Status:      domain.IdempotencyStatusInProgress,
+		LockedUntil: now.Add(req.Lock),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(req.TTL),
+	}
+	records[key] = record
+	return record, true, nil
+}
+
+// completeIdempotency stores the response of a claimed key, it is replayed until the record expires.
+func (m *persistentModule) completeIdempotency(ctx context.Context, req domain.CompleteIdempotencyReq) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	key := recordKey(req.Scope, req.Key)
+	record, ok := records[key]
+	if !ok {
+		return domain.ErrIdempotencyKeyNotFound
+	}
+	record.Status = domain.IdempotencyStatusCompleted
+	record.StatusCode = req.StatusCode
+	record.ContentType = req.ContentType
+	record.Body = append([]byte(nil), req.Body...)
+	record.LockedUntil = time.Time{}
+	records[key] = record
+	return nil
+}
+
+// releaseIdempotency forgets a claimed key so the request can be retried with it.
+func (m *persistentModule) releaseIdempotency(ctx context.Context, req domain.ReleaseIdempotencyReq) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	key := recordKey(req.Scope, req.Key)
+	if _, ok := records[key]; !ok {
+		return domain.ErrIdempotencyKeyNotFound
+	}
+	delete(records, key)
+	return nil
+}
+
+// pruneRecords drops expired records. Callers must hold mu.
+func pruneRecords(now time.Time) {
+	for key, record := range records {
+		if !now.Before(record.ExpiresAt) {
+			delete(records, key)
+		}
+	}
+}
+
+func recordKey(scope, key string) string {
+	return scope + "\x00" + key
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/idempotency/persistent.go
+
+// Package mock_idempotency is a generated GoMock package.
+package idempotency
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "gihub.com/gadhittana01/book-project/pkg/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mockpersistent is a mock of persistent interface.
+type Mockpersistent struct {
+	ctrl     *gomock.Controller
+	recorder *MockpersistentMockRecorder
+}
+
+// MockpersistentMockRecorder is the mock recorder for Mockpersistent.
+type MockpersistentMockRecorder struct {
+	mock *Mockpersistent
+}
+
+// NewMockpersistent creates a new mock instance.
+func NewMockpersistent(ctrl *gomock.Controller) *Mockpersistent {
+	mock := &Mockpersistent{ctrl: ctrl}
+	mock.recorder = &MockpersistentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockpersistent) EXPECT() *MockpersistentMockRecorder {
+	return m.recorder
+}
+
+// beginIdempotency mocks base method.
+func (m *Mockpersistent) beginIdempotency(ctx context.Context, req domain.BeginIdempotencyReq) (domain.IdempotencyRecord, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "beginIdempotency", ctx, req)
+	ret0, _ := ret[0].(domain.IdempotencyRecord)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// beginIdempotency indicates an expected call of beginIdempotency.
+func (mr *MockpersistentMockRecorder) beginIdempotency(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "beginIdempotency", reflect.TypeOf((*Mockpersistent)(nil).beginIdempotency), ctx, req)
+}
+
+// completeIdempotency mocks base method.
+func (m *Mockpersistent) completeIdempotency(ctx context.Context, req domain.CompleteIdempotencyReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "completeIdempotency", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// completeIdempotency indicates an expected call of completeIdempotency.
+func (mr *MockpersistentMockRecorder) completeIdempotency(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "completeIdempotency", reflect.TypeOf((*Mockpersistent)(nil).completeIdempotency), ctx, req)
+}
+
+// releaseIdempotency mocks base method.
+func (m *Mockpersistent) releaseIdempotency(ctx context.Context, req domain.ReleaseIdempotencyReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "releaseIdempotency", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// releaseIdempotency indicates an expected call of releaseIdempotency.
+func (mr *MockpersistentMockRecorder) releaseIdempotency(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "releaseIdempotency", reflect.TypeOf((*Mockpersistent)(nil).releaseIdempotency), ctx, req)
+}
\ No newline at end of file
@@ -0,0 +1,69 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+func Test_idempotency(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 5, 2, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	m := &persistentModule{}
+	begin := domain.BeginIdempotencyReq{Scope: "/borrow-book", Key: "abc", Fingerprint: "f1", TTL: time.Hour, Lock: time.Minute}
+
+	if _, started, err := m.beginIdempotency(ctx, begin); err != nil || !started {
+		t.Fatalf("beginIdempotency() started = %v, error = %v", started, err)
+	}
+	if _, _, err := m.beginIdempotency(ctx, begin); !errors.Is(err, domain.ErrIdempotencyInProgress) {
+		t.Errorf("beginIdempotency() while running error = %v, want in progress", err)
+	}
+
+	// The same key in another scope is another record.
+	other := begin
+	other.Scope = "/join-waitlist"
+	if _, started, err := m.beginIdempotency(ctx, other); err != nil || !started {
+		t.Errorf("beginIdempotency() other scope started = %v, error = %v", started, err)
+	}
+
+	if err := m.completeIdempotency(ctx, domain.CompleteIdempotencyReq{Scope: "/borrow-book", Key: "abc", StatusCode: 200, ContentType: "application/json", Body: []byte(`{"id":1}`)}); err != nil {
+		t.Fatalf("completeIdempotency() error = %v", err)
+	}
+	record, started, err := m.beginIdempotency(ctx, begin)
+	if err != nil || started || record.Status != domain.IdempotencyStatusCompleted || record.StatusCode != 200 || string(record.Body) != `{"id":1}` {
+		t.Errorf("beginIdempotency() replay = %+v, %v, %v", record, started, err)
+	}
+
+	reused := begin
+	reused.Fingerprint = "f2"
+	if _, _, err := m.beginIdempotency(ctx, reused); !errors.Is(err, domain.ErrIdempotencyKeyReused) {
+		t.Errorf("beginIdempotency() other body error = %v, want reused", err)
+	}
+
+	// A request that never completed can be retried once its lock runs out.
+	now = now.Add(2 * time.Minute)
+	if _, started, err := m.beginIdempotency(ctx, other); err != nil || !started {
+		t.Errorf("beginIdempotency() after lock started = %v, error = %v", started, err)
+	}
+	if err := m.releaseIdempotency(ctx, domain.ReleaseIdempotencyReq{Scope: "/join-waitlist", Key: "abc"}); err != nil {
+		t.Errorf("releaseIdempotency() error = %v", err)
+	}
+	if err := m.releaseIdempotency(ctx, domain.ReleaseIdempotencyReq{Scope: "/join-waitlist", Key: "abc"}); !errors.Is(err, domain.ErrIdempotencyKeyNotFound) {
+		t.Errorf("releaseIdempotency() twice error = %v, want not found", err)
+	}
+
+	// Expired records are gone, the key starts over.
+	now = now.Add(time.Hour)
+	if _, started, err := m.beginIdempotency(ctx, reused); err != nil || !started {
+		t.Errorf("beginIdempotency() after expiry started = %v, error = %v", started, err)
+	}
+	if err := m.completeIdempotency(ctx, domain.CompleteIdempotencyReq{Scope: "/unknown", Key: "abc"}); !errors.Is(err, domain.ErrIdempotencyKeyNotFound) {
+		t.Errorf("completeIdempotency() unknown error = %v, want not found", err)
+	}
+}
@@ -0,0 +1,61 @@
+package migration
+
+import (
+	"context"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+type IResource interface {
+	GetMigrations(ctx context.Context) ([]domain.Migration, error)
+	GetAppliedMigrations(ctx context.Context) ([]domain.AppliedMigration, error)
+	AcquireMigrationLock(ctx context.Context, req domain.AcquireMigrationLockReq) (bool, error)
+	ReleaseMigrationLock(ctx context.Context, req domain.ReleaseMigrationLockReq) error
+	ApplyMigration(ctx context.Context, req domain.Migration) (domain.AppliedMigration, error)
+	// InMemory reports whether the migrations table lives in the memory of this process, so migrations applied
+	// here are only seen by this process.
+	InMemory() bool
+}
+
+type module struct {
+	migrations []domain.Migration
+	persistent persistent
+}
+
+// New reads the migrations embedded in the binary, a malformed file name fails here rather than mid-migration.
+func New(cfg *config.GlobalConfig) (IResource, error) {
+	migrations, err := loadMigrations(files, "sql")
+	if err != nil {
+		return nil, err
+	}
+
+	return &module{
+		migrations: migrations,
+		persistent: newPersistent(),
+	}, nil
+}
+
+func (m module) GetMigrations(ctx context.Context) ([]domain.Migration, error) {
+	return append([]domain.Migration(nil), m.migrations...), nil
+}
+
+func (m module) GetAppliedMigrations(ctx context.Context) ([]domain.AppliedMigration, error) {
+	return m.persistent.getAppliedMigrations(ctx)
+}
+
+func (m module) AcquireMigrationLock(ctx context.Context, req domain.AcquireMigrationLockReq) (bool, error) {
+	return m.persistent.acquireMigrationLock(ctx, req)
+}
+
+func (m module) ReleaseMigrationLock(ctx context.Context, req domain.ReleaseMigrationLockReq) error {
+	return m.persistent.releaseMigrationLock(ctx, req)
+}
+
+func (m module) ApplyMigration(ctx context.Context, req domain.Migration) (domain.AppliedMigration, error) {
+	return m.persistent.applyMigration(ctx, req)
+}
+
+func (m module) InMemory() bool {
+	return m.persistent.inMemory()
+}
@@ -0,0 +1,44 @@
+package migration
+
+import (
+	"context"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+func TestNew(t *testing.T) {
+	got, err := New(&config.GlobalConfig{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	migrations, _ := got.GetMigrations(context.Background())
+	if len(migrations) == 0 {
+		t.Fatal("New() has no embedded migrations")
+	}
+	for i, m := range migrations {
+		if m.Version != i+1 || m.SQL == "" || len(m.Checksum) != 64 {
+			t.Errorf("migration %d = %+v, want version %d with SQL and checksum", i, m, i+1)
+		}
+	}
+	if !got.InMemory() {
+		t.Error("InMemory() = false, want the in-memory store")
+	}
+}
+
+func Test_ApplyMigration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	req := domain.Migration{Version: 1, Name: "create_users", Checksum: "c1"}
+
+	pstMock := NewMockpersistent(ctrl)
+	pstMock.EXPECT().applyMigration(gomock.Any(), req).Return(domain.AppliedMigration{Version: 1, Name: "create_users", Checksum: "c1"}, nil)
+	m := module{persistent: pstMock}
+
+	got, err := m.ApplyMigration(context.Background(), req)
+	if err != nil || got.Version != 1 {
+		t.Errorf("ApplyMigration() = %v, %v", got, err)
+	}
+}
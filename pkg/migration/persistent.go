@@ -0,0 +1,95 @@
+package migration
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+type persistent interface {
+	getAppliedMigrations(ctx context.Context) ([]domain.AppliedMigration, error)
+	acquireMigrationLock(ctx context.Context, req domain.AcquireMigrationLockReq) (bool, error)
+	releaseMigrationLock(ctx context.Context, req domain.ReleaseMigrationLockReq) error
+	applyMigration(ctx context.Context, req domain.Migration) (domain.AppliedMigration, error)
+	inMemory() bool
+}
+
+// persistentModule keeps the migrations table and lock of the in-memory store. That store has no schema, so
+// applying a migration only records it. A SQL store runs req.SQL and inserts the row in one transaction, and
+// takes the lock in the database so it holds across replicas.
+type persistentModule struct {
+}
+
+func newPersistent() persistent {
+	return &persistentModule{}
+}
+
+var (
+	// mu guards applied and lock.
+	mu sync.Mutex
+
+	applied []domain.AppliedMigration // ordered by version
+	lock    domain.Lease
+
+	timeNow = time.Now
+)
+
+func (m *persistentModule) inMemory() bool {
+	return true
+}
+
+func (m *persistentModule) getAppliedMigrations(ctx context.Context) ([]domain.AppliedMigration, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return append([]domain.AppliedMigration{}, applied...), nil
+}
+
+// acquireMigrationLock takes the lock when it is free, expired or already held by req.Holder, renewing it.
+func (m *persistentModule) acquireMigrationLock(ctx context.Context, req domain.AcquireMigrationLockReq) (bool, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	now := timeNow()
+	if lock.Holder != "" && lock.Holder != req.Holder && now.Before(lock.ExpiresAt) {
+		return false, nil
+	}
+	lock = domain.Lease{
+		Name:      "schema-migrations",
+		Holder:    req.Holder,
+		ExpiresAt: now.Add(req.TTL),
+	}
+	return true, nil
+}
+
+// releaseMigrationLock frees the lock if req.Holder still holds it.
+func (m *persistentModule) releaseMigrationLock(ctx context.Context, req domain.ReleaseMigrationLockReq) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if lock.Holder == req.Holder {
+		lock = domain.Lease{}
+	}
+	return nil
+}
+
+// applyMigration records req as applied. Migrations only move forward, one not newer than the last applied
+// version fails with ErrMigrationOutOfOrder. Callers hold the migration lock.
+func (m *persistentModule) applyMigration(ctx context.Context, req domain.Migration) (domain.AppliedMigration, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(applied) > 0 && req.Version <= applied[len(applied)-1].Version {
+		return domain.AppliedMigration{}, domain.ErrMigrationOutOfOrder
+	}
+	row := domain.AppliedMigration{
+		Version:   req.Version,
+		Name:      req.Name,
+		Checksum:  req.Checksum,
+		AppliedAt: timeNow(),
+	}
+	applied = append(applied, row)
+	return row, nil
+}
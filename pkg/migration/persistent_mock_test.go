@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/migration/persistent.go
+
+// Package mock_migration is a generated GoMock package.
+package migration
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "gihub.com/gadhittana01/book-project/pkg/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mockpersistent is a mock of persistent interface.
+type Mockpersistent struct {
+	ctrl     *gomock.Controller
+	recorder *MockpersistentMockRecorder
+}
+
+// MockpersistentMockRecorder is the mock recorder for Mockpersistent.
+type MockpersistentMockRecorder struct {
+	mock *Mockpersistent
+}
+
+// NewMockpersistent creates a new mock instance.
+func NewMockpersistent(ctrl *gomock.Controller) *Mockpersistent {
+	mock := &Mockpersistent{ctrl: ctrl}
+	mock.recorder = &MockpersistentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockpersistent) EXPECT() *MockpersistentMockRecorder {
+	return m.recorder
+}
+
+// acquireMigrationLock mocks base method.
+func (m *Mockpersistent) acquireMigrationLock(ctx context.Context, req domain.AcquireMigrationLockReq) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "acquireMigrationLock", ctx, req)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// acquireMigrationLock indicates an expected call of acquireMigrationLock.
+func (mr *MockpersistentMockRecorder) acquireMigrationLock(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "acquireMigrationLock", reflect.TypeOf((*Mockpersistent)(nil).acquireMigrationLock), ctx, req)
+}
+
+// applyMigration mocks base method.
+func (m *Mockpersistent) applyMigration(ctx context.Context, req domain.Migration) (domain.AppliedMigration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "applyMigration", ctx, req)
+	ret0, _ := ret[0].(domain.AppliedMigration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// applyMigration indicates an expected call of applyMigration.
+func (mr *MockpersistentMockRecorder) applyMigration(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "applyMigration", reflect.TypeOf((*Mockpersistent)(nil).applyMigration), ctx, req)
+}
+
+// getAppliedMigrations mocks base method.
+func (m *Mockpersistent) getAppliedMigrations(ctx context.Context) ([]domain.AppliedMigration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getAppliedMigrations", ctx)
+	ret0, _ := ret[0].([]domain.AppliedMigration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getAppliedMigrations indicates an expected call of getAppliedMigrations.
+func (mr *MockpersistentMockRecorder) getAppliedMigrations(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getAppliedMigrations", reflect.TypeOf((*Mockpersistent)(nil).getAppliedMigrations), ctx)
+}
+
+// inMemory mocks base method.
+func (m *Mockpersistent) inMemory() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "inMemory")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// inMemory indicates an expected call of inMemory.
+func (mr *MockpersistentMockRecorder) inMemory() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "inMemory", reflect.TypeOf((*Mockpersistent)(nil).inMemory))
+}
+
+// releaseMigrationLock mocks base method.
+func (m *Mockpersistent) releaseMigrationLock(ctx context.Context, req domain.ReleaseMigrationLockReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "releaseMigrationLock", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// releaseMigrationLock indicates an expected call of releaseMigrationLock.
+func (mr *MockpersistentMockRecorder) releaseMigrationLock(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "releaseMigrationLock", reflect.TypeOf((*Mockpersistent)(nil).releaseMigrationLock), ctx, req)
+}
\ No newline at end of file
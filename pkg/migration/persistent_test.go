@@ -0,0 +1,77 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+func Test_migrationLock(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 5, 2, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() {
+		timeNow = time.Now
+		mu.Lock()
+		lock = domain.Lease{}
+		mu.Unlock()
+	}()
+
+	m := &persistentModule{}
+	if ok, err := m.acquireMigrationLock(ctx, domain.AcquireMigrationLockReq{Holder: "a", TTL: time.Minute}); err != nil || !ok {
+		t.Fatalf("acquireMigrationLock(a) = %v, %v", ok, err)
+	}
+	if ok, _ := m.acquireMigrationLock(ctx, domain.AcquireMigrationLockReq{Holder: "b", TTL: time.Minute}); ok {
+		t.Error("acquireMigrationLock(b) got the lock held by a")
+	}
+	if ok, _ := m.acquireMigrationLock(ctx, domain.AcquireMigrationLockReq{Holder: "a", TTL: time.Minute}); !ok {
+		t.Error("acquireMigrationLock(a) could not renew its own lock")
+	}
+
+	// Releasing someone else's lock does nothing.
+	m.releaseMigrationLock(ctx, domain.ReleaseMigrationLockReq{Holder: "b"})
+	if ok, _ := m.acquireMigrationLock(ctx, domain.AcquireMigrationLockReq{Holder: "b", TTL: time.Minute}); ok {
+		t.Error("acquireMigrationLock(b) got the lock after b released it")
+	}
+
+	// A replica that died holding the lock loses it once it expires.
+	now = now.Add(2 * time.Minute)
+	if ok, _ := m.acquireMigrationLock(ctx, domain.AcquireMigrationLockReq{Holder: "b", TTL: time.Minute}); !ok {
+		t.Error("acquireMigrationLock(b) could not take the expired lock")
+	}
+	m.releaseMigrationLock(ctx, domain.ReleaseMigrationLockReq{Holder: "b"})
+	if ok, _ := m.acquireMigrationLock(ctx, domain.AcquireMigrationLockReq{Holder: "c", TTL: time.Minute}); !ok {
+		t.Error("acquireMigrationLock(c) could not take the released lock")
+	}
+}
+
+func Test_applyMigration(t *testing.T) {
+	ctx := context.Background()
+	mu.Lock()
+	saved := applied
+	applied = nil
+	mu.Unlock()
+	defer func() {
+		mu.Lock()
+		applied = saved
+		mu.Unlock()
+	}()
+
+	m := &persistentModule{}
+	for _, version := range []int{1, 2} {
+		if _, err := m.applyMigration(ctx, domain.Migration{Version: version, Name: "step", Checksum: "c"}); err != nil {
+			t.Fatalf("applyMigration(%d) error = %v", version, err)
+		}
+	}
+	if _, err := m.applyMigration(ctx, domain.Migration{Version: 2, Name: "step", Checksum: "c"}); !errors.Is(err, domain.ErrMigrationOutOfOrder) {
+		t.Errorf("applyMigration(2) again error = %v, want out of order", err)
+	}
+
+	got, _ := m.getAppliedMigrations(ctx)
+	if len(got) != 2 || got[0].Version != 1 || got[1].Version != 2 {
+		t.Errorf("getAppliedMigrations() = %+v, want versions 1 and 2", got)
+	}
+}
@@ -0,0 +1,87 @@
+package migration
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func Test_loadMigrations(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    fstest.MapFS
+		want     []int
+		wantName string
+		wantErr  bool
+	}{
+		{
+			name: "ordered by version",
+			files: fstest.MapFS{
+				"sql/0010_add_index.sql":    {Data: []byte("CREATE INDEX i ON t (c);")},
+				"sql/0002_create_table.sql": {Data: []byte("CREATE TABLE t (c INT);")},
+			},
+			want:     []int{2, 10},
+			wantName: "create_table",
+		},
+		{
+			name: "bad name",
+			files: fstest.MapFS{
+				"sql/create_table.sql": {Data: []byte("CREATE TABLE t (c INT);")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "version used twice",
+			files: fstest.MapFS{
+				"sql/0001_create_table.sql": {Data: []byte("CREATE TABLE t (c INT);")},
+				"sql/01_create_other.sql":   {Data: []byte("CREATE TABLE o (c INT);")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "version zero",
+			files: fstest.MapFS{
+				"sql/0000_create_table.sql": {Data: []byte("CREATE TABLE t (c INT);")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := loadMigrations(tt.files, "sql")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadMigrations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("loadMigrations() = %+v, want versions %v", got, tt.want)
+			}
+			for i, version := range tt.want {
+				if got[i].Version != version {
+					t.Errorf("loadMigrations()[%d].Version = %d, want %d", i, got[i].Version, version)
+				}
+			}
+			if got[0].Name != tt.wantName {
+				t.Errorf("loadMigrations()[0].Name = %q, want %q", got[0].Name, tt.wantName)
+			}
+		})
+	}
+}
+
+func Test_loadMigrations_checksum(t *testing.T) {
+	load := func(sql string) string {
+		got, err := loadMigrations(fstest.MapFS{"sql/0001_create_table.sql": {Data: []byte(sql)}}, "sql")
+		if err != nil {
+			t.Fatalf("loadMigrations() error = %v", err)
+		}
+		return got[0].Checksum
+	}
+
+	if load("CREATE TABLE t (c INT);") != load("CREATE TABLE t (c INT);") {
+		t.Error("checksum of the same SQL differs")
+	}
+	if load("CREATE TABLE t (c INT);") == load("CREATE TABLE t (c BIGINT);") {
+		t.Error("checksum of edited SQL did not change")
+	}
+}
@@ -0,0 +1,15 @@
+-- Registered library users, deactivated users keep their row.
+CREATE TABLE users (
+    id               BIGSERIAL PRIMARY KEY,
+    name             TEXT        NOT NULL,
+    email            TEXT        NOT NULL,
+    phone            TEXT        NOT NULL DEFAULT '',
+    preferred_branch TEXT        NOT NULL DEFAULT '',
+    notify_opt_out   BOOLEAN     NOT NULL DEFAULT FALSE,
+    active           BOOLEAN     NOT NULL DEFAULT TRUE,
+    created_at       TIMESTAMPTZ NOT NULL,
+    updated_at       TIMESTAMPTZ NOT NULL,
+    deactivated_at   TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX users_email_key ON users (lower(email));
@@ -0,0 +1,26 @@
+-- Reservations and waitlist entries, the book is copied from the catalog when it is reserved.
+CREATE TABLE reservations (
+    id                 BIGSERIAL PRIMARY KEY,
+    user_id            BIGINT      NOT NULL REFERENCES users (id),
+    book_key           TEXT        NOT NULL,
+    book_title         TEXT        NOT NULL DEFAULT '',
+    book_authors       TEXT[]      NOT NULL DEFAULT '{}',
+    edition_count      INTEGER     NOT NULL DEFAULT 0,
+    lending_identifier TEXT        NOT NULL DEFAULT '',
+    subject            TEXT        NOT NULL DEFAULT '',
+    branch             TEXT        NOT NULL,
+    pickup_date        DATE        NOT NULL,
+    pickup_slot        TEXT        NOT NULL DEFAULT '',
+    status             TEXT        NOT NULL,
+    queue_position     INTEGER     NOT NULL DEFAULT 0,
+    confirm_by         TIMESTAMPTZ,
+    reminded_at        TIMESTAMPTZ,
+    created_at         TIMESTAMPTZ NOT NULL,
+    CONSTRAINT reservations_status_check CHECK (status IN
+        ('active', 'provisional', 'waiting', 'cancelled', 'expired', 'picked_up', 'returned'))
+);
+
+CREATE INDEX reservations_user_id_idx ON reservations (user_id, id);
+CREATE INDEX reservations_branch_idx ON reservations (branch, id);
+CREATE INDEX reservations_pickup_idx ON reservations (branch, pickup_date, pickup_slot) WHERE status IN ('active', 'provisional');
+CREATE INDEX reservations_copies_idx ON reservations (book_key, branch) WHERE status IN ('active', 'provisional');
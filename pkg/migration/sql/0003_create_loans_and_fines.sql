@@ -0,0 +1,33 @@
+-- Loans opened at pickup and the fines ledger, amounts are in minor units of the currency.
+CREATE TABLE loans (
+    id             BIGSERIAL PRIMARY KEY,
+    reservation_id BIGINT      NOT NULL REFERENCES reservations (id),
+    user_id        BIGINT      NOT NULL REFERENCES users (id),
+    book_key       TEXT        NOT NULL,
+    book_title     TEXT        NOT NULL DEFAULT '',
+    branch         TEXT        NOT NULL,
+    checked_out_at TIMESTAMPTZ NOT NULL,
+    due_date       TIMESTAMPTZ NOT NULL,
+    returned_at    TIMESTAMPTZ,
+    renewals       INTEGER     NOT NULL DEFAULT 0,
+    status         TEXT        NOT NULL,
+    CONSTRAINT loans_status_check CHECK (status IN ('on_loan', 'returned'))
+);
+
+CREATE UNIQUE INDEX loans_reservation_id_key ON loans (reservation_id);
+CREATE INDEX loans_user_id_idx ON loans (user_id, id);
+
+CREATE TABLE fine_entries (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT      NOT NULL REFERENCES users (id),
+    loan_id    BIGINT      REFERENCES loans (id),
+    fine_id    BIGINT      REFERENCES fine_entries (id),
+    type       TEXT        NOT NULL,
+    amount     BIGINT      NOT NULL,
+    currency   CHAR(3)     NOT NULL,
+    note       TEXT        NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fine_entries_type_check CHECK (type IN ('charge', 'waiver', 'payment'))
+);
+
+CREATE INDEX fine_entries_user_id_idx ON fine_entries (user_id, id);
@@ -0,0 +1,21 @@
+-- Reservation events waiting for the dispatcher, and the leases that keep background jobs on one replica.
+CREATE TABLE outbox_events (
+    id              BIGSERIAL PRIMARY KEY,
+    type            TEXT        NOT NULL,
+    reservation     JSONB       NOT NULL,
+    status          TEXT        NOT NULL,
+    attempts        INTEGER     NOT NULL DEFAULT 0,
+    last_error      TEXT        NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL,
+    delivered_at    TIMESTAMPTZ,
+    CONSTRAINT outbox_events_status_check CHECK (status IN ('pending', 'delivered', 'dead'))
+);
+
+CREATE INDEX outbox_events_due_idx ON outbox_events (next_attempt_at, id) WHERE status = 'pending';
+
+CREATE TABLE leases (
+    name       TEXT PRIMARY KEY,
+    holder     TEXT        NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,34 @@
+-- Partner webhook subscriptions and their delivery log.
+CREATE TABLE webhook_subscriptions (
+    id                   BIGSERIAL PRIMARY KEY,
+    url                  TEXT        NOT NULL,
+    secret               TEXT        NOT NULL,
+    events               TEXT[]      NOT NULL DEFAULT '{}',
+    active               BOOLEAN     NOT NULL DEFAULT TRUE,
+    consecutive_failures INTEGER     NOT NULL DEFAULT 0,
+    disabled_reason      TEXT        NOT NULL DEFAULT '',
+    disabled_at          TIMESTAMPTZ,
+    created_at           TIMESTAMPTZ NOT NULL,
+    updated_at           TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE webhook_deliveries (
+    id              BIGSERIAL PRIMARY KEY,
+    subscription_id BIGINT      NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    event_id        BIGINT      NOT NULL,
+    event_type      TEXT        NOT NULL,
+    payload         TEXT        NOT NULL,
+    status          TEXT        NOT NULL,
+    attempts        INTEGER     NOT NULL DEFAULT 0,
+    response_status INTEGER     NOT NULL DEFAULT 0,
+    last_error      TEXT        NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL,
+    completed_at    TIMESTAMPTZ,
+    replay_of       BIGINT      REFERENCES webhook_deliveries (id),
+    CONSTRAINT webhook_deliveries_status_check CHECK (status IN ('pending', 'succeeded', 'failed'))
+);
+
+-- An outbox event is queued once per subscription, replays are extra rows.
+CREATE UNIQUE INDEX webhook_deliveries_event_key ON webhook_deliveries (subscription_id, event_id) WHERE replay_of IS NULL;
+CREATE INDEX webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at, id) WHERE status = 'pending';
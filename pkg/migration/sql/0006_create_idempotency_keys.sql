@@ -0,0 +1,17 @@
+-- First responses to requests sent with an Idempotency-Key, kept until expires_at.
+CREATE TABLE idempotency_keys (
+    scope        TEXT        NOT NULL,
+    key          TEXT        NOT NULL,
+    fingerprint  TEXT        NOT NULL,
+    status       TEXT        NOT NULL,
+    status_code  INTEGER     NOT NULL DEFAULT 0,
+    content_type TEXT        NOT NULL DEFAULT '',
+    body         BYTEA,
+    locked_until TIMESTAMPTZ NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL,
+    expires_at   TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (scope, key),
+    CONSTRAINT idempotency_keys_status_check CHECK (status IN ('in_progress', 'completed'))
+);
+
+CREATE INDEX idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
@@ -0,0 +1,60 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"sync"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+type logNotifier struct {
+	logger *log.Logger
+}
+
+// newLogNotifier logs notifications to w, the standard logger when w is nil.
+func newLogNotifier(w io.Writer) Notifier {
+	n := &logNotifier{logger: log.Default()}
+	if w != nil {
+		n.logger = log.New(w, "", log.LstdFlags)
+	}
+	return n
+}
+
+func (n *logNotifier) Notify(ctx context.Context, msg domain.Notification) error {
+	n.logger.Printf("notification %s to user %d <%s>: %s", msg.Event, msg.UserID, msg.To, msg.Subject)
+	return nil
+}
+
+// fileNotifier appends every notification as a JSON line to a file.
+type fileNotifier struct {
+	mu   sync.Mutex
+	path string
+}
+
+func newFileNotifier(path string) Notifier {
+	return &fileNotifier{path: path}
+}
+
+func (n *fileNotifier) Notify(ctx context.Context, msg domain.Notification) error {
+	line, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,46 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+const (
+	ChannelLog     = "log"
+	ChannelFile    = "file"
+	ChannelSMTP    = "smtp"
+	ChannelWebhook = "webhook"
+)
+
+// Notifier delivers a rendered notification to a user.
+type Notifier interface {
+	Notify(ctx context.Context, msg domain.Notification) error
+}
+
+// New returns the Notifier of the configured channel.
+func New(cfg *config.GlobalConfig) (Notifier, error) {
+	c := cfg.Notification
+	switch c.Channel {
+	case ChannelLog:
+		return newLogNotifier(nil), nil
+	case ChannelFile:
+		if c.File == "" {
+			return nil, fmt.Errorf("notification.file is required for the %s channel", ChannelFile)
+		}
+		return newFileNotifier(c.File), nil
+	case ChannelSMTP:
+		if c.SMTP.Host == "" || c.SMTP.From == "" {
+			return nil, fmt.Errorf("notification.smtp host and from are required for the %s channel", ChannelSMTP)
+		}
+		return newSMTPNotifier(c.SMTP), nil
+	case ChannelWebhook:
+		if c.Webhook.URL == "" {
+			return nil, fmt.Errorf("notification.webhook.url is required for the %s channel", ChannelWebhook)
+		}
+		return newWebhookNotifier(c.Webhook), nil
+	}
+	return nil, fmt.Errorf("unknown notification channel %q", c.Channel)
+}
@@ -0,0 +1,205 @@
+package notify
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+var testMsg = domain.Notification{
+	Event:         domain.NotifyReservationConfirmed,
+	UserID:        7,
+	ReservationID: 3,
+	To:            "giri@example.com",
+	Subject:       "Reservation confirmed",
+	Body:          "See you at central on 2022-02-26.",
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.Notification
+		wantErr bool
+	}{
+		{name: "log", cfg: config.Notification{Channel: ChannelLog}},
+		{name: "file", cfg: config.Notification{Channel: ChannelFile, File: "n.log"}},
+		{name: "file without path", cfg: config.Notification{Channel: ChannelFile}, wantErr: true},
+		{name: "smtp", cfg: config.Notification{Channel: ChannelSMTP, SMTP: config.SMTPConfig{Host: "localhost", Port: 25, From: "a@example.com"}}},
+		{name: "smtp without host", cfg: config.Notification{Channel: ChannelSMTP}, wantErr: true},
+		{name: "webhook", cfg: config.Notification{Channel: ChannelWebhook, Webhook: config.NotificationWebhook{URL: "http://localhost"}}},
+		{name: "unknown", cfg: config.Notification{Channel: "pigeon"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(&config.GlobalConfig{Notification: tt.cfg})
+			if (err != nil) != tt.wantErr || (err == nil && got == nil) {
+				t.Errorf("New() = %v, %v, wantErr %v", got, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_logNotifier(t *testing.T) {
+	var buf bytes.Buffer
+	if err := newLogNotifier(&buf).Notify(context.Background(), testMsg); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "reservation_confirmed to user 7 <giri@example.com>") {
+		t.Errorf("log = %q", buf.String())
+	}
+}
+
+func Test_fileNotifier(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.log")
+	n := newFileNotifier(path)
+	for i := 0; i < 2; i++ {
+		if err := n.Notify(context.Background(), testMsg); err != nil {
+			t.Fatalf("Notify() error = %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	got := domain.Notification{}
+	if len(lines) != 2 || json.Unmarshal([]byte(lines[1]), &got) != nil || got != testMsg {
+		t.Errorf("file = %q", data)
+	}
+}
+
+func Test_webhookNotifier(t *testing.T) {
+	var got domain.Notification
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		if got.UserID == 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	n := newWebhookNotifier(config.NotificationWebhook{URL: srv.URL})
+	if err := n.Notify(context.Background(), testMsg); err != nil || got != testMsg {
+		t.Errorf("Notify() = %+v, %v", got, err)
+	}
+	if err := n.Notify(context.Background(), domain.Notification{}); err == nil {
+		t.Errorf("Notify() on 500 error = nil")
+	}
+}
+
+func Test_smtpNotifier(t *testing.T) {
+	stub := newSMTPStub(t)
+	host, port, _ := net.SplitHostPort(stub.addr)
+	p, _ := strconv.Atoi(port)
+
+	n := newSMTPNotifier(config.SMTPConfig{Host: host, Port: p, From: "library@example.com"})
+	if err := n.Notify(context.Background(), testMsg); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	mail := <-stub.mails
+	if mail.from != "library@example.com" || mail.to != "giri@example.com" {
+		t.Errorf("envelope = %q -> %q", mail.from, mail.to)
+	}
+	for _, want := range []string{"Subject: Reservation confirmed", "X-Book-Project-Event: reservation_confirmed", "See you at central on 2022-02-26."} {
+		if !strings.Contains(mail.data, want) {
+			t.Errorf("mail is missing %q:\n%s", want, mail.data)
+		}
+	}
+
+	if err := n.Notify(context.Background(), domain.Notification{Subject: "no recipient"}); err == nil {
+		t.Errorf("Notify() without recipient error = nil")
+	}
+}
+
+type stubMail struct {
+	from, to, data string
+}
+
+type smtpStub struct {
+	addr  string
+	mails chan stubMail
+}
+
+// newSMTPStub accepts mail on a local port with just enough SMTP for net/smtp.
+func newSMTPStub(t *testing.T) *smtpStub {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	s := &smtpStub{addr: l.Addr().String(), mails: make(chan stubMail, 1)}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *smtpStub) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 stub ready")
+	mail := stubMail{}
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 stub")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			mail.from = strings.Trim(line[len("MAIL FROM:"):], "<> ")
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			mail.to = strings.Trim(line[len("RCPT TO:"):], "<> ")
+			reply("250 OK")
+		case cmd == "DATA":
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil || l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			mail.data = data.String()
+			s.mails <- mail
+			reply("250 queued")
+		case cmd == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
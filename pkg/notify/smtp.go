@@ -0,0 +1,54 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+type smtpNotifier struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+func newSMTPNotifier(c config.SMTPConfig) Notifier {
+	n := &smtpNotifier{
+		addr: net.JoinHostPort(c.Host, strconv.Itoa(c.Port)),
+		from: c.From,
+	}
+	if c.Username != "" {
+		n.auth = smtp.PlainAuth("", c.Username, c.Password, c.Host)
+	}
+	return n
+}
+
+func (n *smtpNotifier) Notify(ctx context.Context, msg domain.Notification) error {
+	if msg.To == "" {
+		return errors.New("notification has no recipient address")
+	}
+	return smtp.SendMail(n.addr, n.auth, n.from, []string{msg.To}, buildMessage(n.from, msg))
+}
+
+// buildMessage renders msg as a plain text RFC 5322 email.
+func buildMessage(from string, msg domain.Notification) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	fmt.Fprintf(&b, "X-Book-Project-Event: %s\r\n", msg.Event)
+	b.WriteString("\r\n")
+	b.WriteString(msg.Body)
+	b.WriteString("\r\n")
+	return b.Bytes()
+}
@@ -0,0 +1,56 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+const defaultWebhookTimeoutMS = 5000
+
+// webhookNotifier posts every notification as JSON to a URL, any status but 2xx is an error.
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func newWebhookNotifier(c config.NotificationWebhook) Notifier {
+	timeout := c.TimeoutMS
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeoutMS
+	}
+	return &webhookNotifier{
+		url:    c.URL,
+		client: &http.Client{Timeout: time.Duration(timeout) * time.Millisecond},
+	}
+}
+
+func (n *webhookNotifier) Notify(ctx context.Context, msg domain.Notification) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("notification webhook answered %s", resp.Status)
+	}
+	return nil
+}
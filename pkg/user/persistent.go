@@ -0,0 +1,140 @@
+package user
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+type persistent interface {
+	registerUser(ctx context.Context, req domain.RegisterUserReq) (domain.User, error)
+	getUser(ctx context.Context, req domain.GetUserReq) (domain.User, error)
+	updateUserProfile(ctx context.Context, req domain.UpdateUserProfileReq) (domain.User, error)
+	deactivateUser(ctx context.Context, req domain.DeactivateUserReq) (domain.User, error)
+	setNotifyOptOut(ctx context.Context, req domain.SetNotifyOptOutReq) (domain.User, error)
+}
+
+type persistentModule struct {
+}
+
+func newPersistent() persistent {
+	return &persistentModule{}
+}
+
+var (
+	// mu guards every user global below.
+	mu sync.Mutex
+
+	users      map[int]domain.User = make(map[int]domain.User)
+	lastUserID int
+
+	timeNow = time.Now
+)
+
+func (m *persistentModule) registerUser(ctx context.Context, req domain.RegisterUserReq) (domain.User, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if emailTaken(req.Email, 0) {
+		return domain.User{}, domain.ErrEmailTaken
+	}
+
+	now := timeNow()
+	lastUserID++
+	user := domain.User{
+		ID:              lastUserID,
+		Name:            req.Name,
+		Email:           req.Email,
+		Phone:           req.Phone,
+		PreferredBranch: req.PreferredBranch,
+		Active:          true,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	users[user.ID] = user
+	return user, nil
+}
+
+func (m *persistentModule) getUser(ctx context.Context, req domain.GetUserReq) (domain.User, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	user, ok := users[req.ID]
+	if !ok {
+		return domain.User{}, domain.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (m *persistentModule) updateUserProfile(ctx context.Context, req domain.UpdateUserProfileReq) (domain.User, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	user, ok := users[req.ID]
+	if !ok {
+		return domain.User{}, domain.ErrUserNotFound
+	}
+	if !user.Active {
+		return domain.User{}, domain.ErrUserInactive
+	}
+	if emailTaken(req.Email, req.ID) {
+		return domain.User{}, domain.ErrEmailTaken
+	}
+
+	user.Name = req.Name
+	user.Email = req.Email
+	user.Phone = req.Phone
+	user.PreferredBranch = req.PreferredBranch
+	user.UpdatedAt = timeNow()
+	users[user.ID] = user
+	return user, nil
+}
+
+func (m *persistentModule) deactivateUser(ctx context.Context, req domain.DeactivateUserReq) (domain.User, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	user, ok := users[req.ID]
+	if !ok {
+		return domain.User{}, domain.ErrUserNotFound
+	}
+	if !user.Active {
+		return domain.User{}, domain.ErrUserInactive
+	}
+
+	now := timeNow()
+	user.Active = false
+	user.DeactivatedAt = now
+	user.UpdatedAt = now
+	users[user.ID] = user
+	return user, nil
+}
+
+// setNotifyOptOut turns reservation notifications off, or back on, for a user.
+func (m *persistentModule) setNotifyOptOut(ctx context.Context, req domain.SetNotifyOptOutReq) (domain.User, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	user, ok := users[req.ID]
+	if !ok {
+		return domain.User{}, domain.ErrUserNotFound
+	}
+
+	user.NotifyOptOut = req.OptOut
+	user.UpdatedAt = timeNow()
+	users[user.ID] = user
+	return user, nil
+}
+
+// emailTaken reports whether another user than exceptID registered email. Callers must hold mu.
+func emailTaken(email string, exceptID int) bool {
+	for id, user := range users {
+		if id != exceptID && strings.EqualFold(user.Email, email) {
+			return true
+		}
+	}
+	return false
+}
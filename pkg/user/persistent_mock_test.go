@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/user/persistent.go
+
+// Package mock_user is a generated GoMock package.
+package user
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "gihub.com/gadhittana01/book-project/pkg/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mockpersistent is a mock of persistent interface.
+type Mockpersistent struct {
+	ctrl     *gomock.Controller
+	recorder *MockpersistentMockRecorder
+}
+
+// MockpersistentMockRecorder is the mock recorder for Mockpersistent.
+type MockpersistentMockRecorder struct {
+	mock *Mockpersistent
+}
+
+// NewMockpersistent creates a new mock instance.
+func NewMockpersistent(ctrl *gomock.Controller) *Mockpersistent {
+	mock := &Mockpersistent{ctrl: ctrl}
+	mock.recorder = &MockpersistentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockpersistent) EXPECT() *MockpersistentMockRecorder {
+	return m.recorder
+}
+
+// deactivateUser mocks base method.
+func (m *Mockpersistent) deactivateUser(ctx context.Context, req domain.DeactivateUserReq) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "deactivateUser", ctx, req)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// deactivateUser indicates an expected call of deactivateUser.
+func (mr *MockpersistentMockRecorder) deactivateUser(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "deactivateUser", reflect.TypeOf((*Mockpersistent)(nil).deactivateUser), ctx, req)
+}
+
+// getUser mocks base method.
+func (m *Mockpersistent) getUser(ctx context.Context, req domain.GetUserReq) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getUser", ctx, req)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getUser indicates an expected call of getUser.
+func (mr *MockpersistentMockRecorder) getUser(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getUser", reflect.TypeOf((*Mockpersistent)(nil).getUser), ctx, req)
+}
+
+// registerUser mocks base method.
+func (m *Mockpersistent) registerUser(ctx context.Context, req domain.RegisterUserReq) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "registerUser", ctx, req)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// registerUser indicates an expected call of registerUser.
+func (mr *MockpersistentMockRecorder) registerUser(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "registerUser", reflect.TypeOf((*Mockpersistent)(nil).registerUser), ctx, req)
+}
+
+// setNotifyOptOut mocks base method.
+func (m *Mockpersistent) setNotifyOptOut(ctx context.Context, req domain.SetNotifyOptOutReq) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "setNotifyOptOut", ctx, req)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// setNotifyOptOut indicates an expected call of setNotifyOptOut.
+func (mr *MockpersistentMockRecorder) setNotifyOptOut(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "setNotifyOptOut", reflect.TypeOf((*Mockpersistent)(nil).setNotifyOptOut), ctx, req)
+}
+
+// updateUserProfile mocks base method.
+func (m *Mockpersistent) updateUserProfile(ctx context.Context, req domain.UpdateUserProfileReq) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "updateUserProfile", ctx, req)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// updateUserProfile indicates an expected call of updateUserProfile.
+func (mr *MockpersistentMockRecorder) updateUserProfile(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "updateUserProfile", reflect.TypeOf((*Mockpersistent)(nil).updateUserProfile), ctx, req)
+}
\ No newline at end of file
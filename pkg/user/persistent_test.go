@@ -0,0 +1,84 @@
+package user
+
+import (
+	"context"
+	reflect "reflect"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+func Test_newPersistent(t *testing.T) {
+	tests := []struct {
+		name string
+		want persistent
+	}{
+		{
+			name: "success",
+			want: &persistentModule{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newPersistent(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newPersistent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_users(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 4, 1, 9, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	m := &persistentModule{}
+
+	alice, err := m.registerUser(ctx, domain.RegisterUserReq{Name: "Alice", Email: "alice@example.com", PreferredBranch: "central"})
+	if err != nil || alice.ID == 0 || !alice.Active || !alice.CreatedAt.Equal(now) {
+		t.Fatalf("registerUser() = %+v, %v", alice, err)
+	}
+	if _, err := m.registerUser(ctx, domain.RegisterUserReq{Name: "Other", Email: "ALICE@example.com"}); err != domain.ErrEmailTaken {
+		t.Errorf("registerUser() duplicate email error = %v", err)
+	}
+	bob, _ := m.registerUser(ctx, domain.RegisterUserReq{Name: "Bob", Email: "bob@example.com"})
+
+	if got, err := m.getUser(ctx, domain.GetUserReq{ID: alice.ID}); err != nil || !reflect.DeepEqual(got, alice) {
+		t.Errorf("getUser() = %+v, %v", got, err)
+	}
+	if _, err := m.getUser(ctx, domain.GetUserReq{ID: -1}); err != domain.ErrUserNotFound {
+		t.Errorf("getUser() unknown error = %v", err)
+	}
+
+	now = now.Add(time.Hour)
+	if _, err := m.updateUserProfile(ctx, domain.UpdateUserProfileReq{ID: bob.ID, Name: "Bob", Email: "alice@example.com"}); err != domain.ErrEmailTaken {
+		t.Errorf("updateUserProfile() taken email error = %v", err)
+	}
+	updated, err := m.updateUserProfile(ctx, domain.UpdateUserProfileReq{ID: alice.ID, Name: "Alice B", Email: "alice@example.com", Phone: "+62811"})
+	if err != nil || updated.Name != "Alice B" || updated.Phone != "+62811" || !updated.UpdatedAt.Equal(now) {
+		t.Errorf("updateUserProfile() = %+v, %v", updated, err)
+	}
+
+	if got, err := m.setNotifyOptOut(ctx, domain.SetNotifyOptOutReq{ID: bob.ID, OptOut: true}); err != nil || !got.NotifyOptOut {
+		t.Errorf("setNotifyOptOut() = %+v, %v", got, err)
+	}
+	if _, err := m.setNotifyOptOut(ctx, domain.SetNotifyOptOutReq{ID: -1}); err != domain.ErrUserNotFound {
+		t.Errorf("setNotifyOptOut() unknown error = %v", err)
+	}
+
+	deactivated, err := m.deactivateUser(ctx, domain.DeactivateUserReq{ID: alice.ID})
+	if err != nil || deactivated.Active || !deactivated.DeactivatedAt.Equal(now) {
+		t.Errorf("deactivateUser() = %+v, %v", deactivated, err)
+	}
+	if _, err := m.deactivateUser(ctx, domain.DeactivateUserReq{ID: alice.ID}); err != domain.ErrUserInactive {
+		t.Errorf("deactivateUser() twice error = %v", err)
+	}
+	if _, err := m.updateUserProfile(ctx, domain.UpdateUserProfileReq{ID: alice.ID, Name: "Alice"}); err != domain.ErrUserInactive {
+		t.Errorf("updateUserProfile() inactive error = %v", err)
+	}
+	if _, err := m.deactivateUser(ctx, domain.DeactivateUserReq{ID: -1}); err != domain.ErrUserNotFound {
+		t.Errorf("deactivateUser() unknown error = %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package user
+
+import (
+	"context"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+type IResource interface {
+	RegisterUser(ctx context.Context, req domain.RegisterUserReq) (domain.User, error)
+	GetUser(ctx context.Context, req domain.GetUserReq) (domain.User, error)
+	UpdateUserProfile(ctx context.Context, req domain.UpdateUserProfileReq) (domain.User, error)
+	DeactivateUser(ctx context.Context, req domain.DeactivateUserReq) (domain.User, error)
+	SetNotifyOptOut(ctx context.Context, req domain.SetNotifyOptOutReq) (domain.User, error)
+}
+
+type module struct {
+	persistent persistent
+}
+
+func New(cfg *config.GlobalConfig) (IResource, error) {
+	return &module{
+		persistent: newPersistent(),
+	}, nil
+}
+
+func (m module) RegisterUser(ctx context.Context, req domain.RegisterUserReq) (domain.User, error) {
+	return m.persistent.registerUser(ctx, req)
+}
+
+func (m module) GetUser(ctx context.Context, req domain.GetUserReq) (domain.User, error) {
+	return m.persistent.getUser(ctx, req)
+}
+
+func (m module) UpdateUserProfile(ctx context.Context, req domain.UpdateUserProfileReq) (domain.User, error) {
+	return m.persistent.updateUserProfile(ctx, req)
+}
+
+func (m module) DeactivateUser(ctx context.Context, req domain.DeactivateUserReq) (domain.User, error) {
+	return m.persistent.deactivateUser(ctx, req)
+}
+
+func (m module) SetNotifyOptOut(ctx context.Context, req domain.SetNotifyOptOutReq) (domain.User, error) {
+	return m.persistent.setNotifyOptOut(ctx, req)
+}
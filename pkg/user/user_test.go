@@ -0,0 +1,109 @@
+package user
+
+import (
+	"context"
+	reflect "reflect"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+func TestNew(t *testing.T) {
+	got, err := New(&config.GlobalConfig{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	want := &module{
+		persistent: newPersistent(),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("New() = %v, want %v", got, want)
+	}
+}
+
+func Test_RegisterUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	type args struct {
+		ctx context.Context
+		req domain.RegisterUserReq
+	}
+	tests := []struct {
+		name    string
+		mock    func() *module
+		args    args
+		want    domain.User
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx: context.Background(),
+				req: domain.RegisterUserReq{Name: "Jack", Email: "jack@example.com"},
+			},
+			mock: func() *module {
+				pstMock := NewMockpersistent(ctrl)
+				pstMock.EXPECT().registerUser(gomock.Any(), domain.RegisterUserReq{Name: "Jack", Email: "jack@example.com"}).Return(domain.User{ID: 1, Name: "Jack", Email: "jack@example.com", Active: true}, nil)
+				return &module{
+					persistent: pstMock,
+				}
+			},
+			want:    domain.User{ID: 1, Name: "Jack", Email: "jack@example.com", Active: true},
+			wantErr: false,
+		},
+		{
+			name: "email taken",
+			args: args{
+				ctx: context.Background(),
+				req: domain.RegisterUserReq{Name: "Jack", Email: "jack@example.com"},
+			},
+			mock: func() *module {
+				pstMock := NewMockpersistent(ctrl)
+				pstMock.EXPECT().registerUser(gomock.Any(), gomock.Any()).Return(domain.User{}, domain.ErrEmailTaken)
+				return &module{
+					persistent: pstMock,
+				}
+			},
+			want:    domain.User{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.mock()
+			got, err := m.RegisterUser(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RegisterUser() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RegisterUser() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_UserProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	pstMock := NewMockpersistent(ctrl)
+	pstMock.EXPECT().getUser(gomock.Any(), domain.GetUserReq{ID: 1}).Return(domain.User{ID: 1}, nil)
+	pstMock.EXPECT().updateUserProfile(gomock.Any(), domain.UpdateUserProfileReq{ID: 1, Name: "Jack"}).Return(domain.User{ID: 1, Name: "Jack"}, nil)
+	pstMock.EXPECT().deactivateUser(gomock.Any(), domain.DeactivateUserReq{ID: 1}).Return(domain.User{}, domain.ErrUserInactive)
+
+	m := &module{
+		persistent: pstMock,
+	}
+	ctx := context.Background()
+	if got, err := m.GetUser(ctx, domain.GetUserReq{ID: 1}); err != nil || got.ID != 1 {
+		t.Errorf("GetUser() = %v, %v", got, err)
+	}
+	if got, err := m.UpdateUserProfile(ctx, domain.UpdateUserProfileReq{ID: 1, Name: "Jack"}); err != nil || got.Name != "Jack" {
+		t.Errorf("UpdateUserProfile() = %v, %v", got, err)
+	}
+	if _, err := m.DeactivateUser(ctx, domain.DeactivateUserReq{ID: 1}); err != domain.ErrUserInactive {
+		t.Errorf("DeactivateUser() error = %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrAddressNotAllowed is returned when a webhook would be sent to an internal address that is not allowed.
+var ErrAddressNotAllowed = errors.New("webhook address is on an internal network and not in webhooks.allowedhosts")
+
+// HostAllowed reports whether a webhook URL may point at host. Loopback, link-local, private and unspecified
+// addresses, and localhost, are refused unless allowed lists the address or a CIDR range containing it. Other host
+// names are checked once they resolve, when the webhook is sent.
+func HostAllowed(host string, allowed []string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return addrAllowed(net.IPv4(127, 0, 0, 1), allowed)
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return addrAllowed(ip, allowed)
+	}
+	return true
+}
+
+func addrAllowed(ip net.IP, allowed []string) bool {
+	if !internalIP(ip) {
+		return true
+	}
+	for _, a := range allowed {
+		if _, n, err := net.ParseCIDR(a); err == nil && n.Contains(ip) {
+			return true
+		}
+		if allowedIP := net.ParseIP(a); allowedIP != nil && allowedIP.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func internalIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsUnspecified()
+}
+
+// guardedDialer connects only to addresses addrAllowed accepts. The check runs on the resolved address of every
+// connection, so a host name that resolves to an internal address, or a redirect to one, is refused as well.
+func guardedDialer(timeout time.Duration, allowed []string) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	d := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !addrAllowed(ip, allowed) {
+				return ErrAddressNotAllowed
+			}
+			return nil
+		},
+	}
+	return d.DialContext
+}
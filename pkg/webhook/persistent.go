@@ -0,0 +1,319 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+const defaultClaimLimit = 20
+
+type persistent interface {
+	createWebhook(ctx context.Context, req domain.CreateWebhookReq) (domain.WebhookSubscription, error)
+	getWebhooks(ctx context.Context) ([]domain.WebhookSubscription, error)
+	getWebhook(ctx context.Context, req domain.GetWebhookReq) (domain.WebhookSubscription, error)
+	updateWebhook(ctx context.Context, req domain.UpdateWebhookReq) (domain.WebhookSubscription, error)
+	deleteWebhook(ctx context.Context, req domain.DeleteWebhookReq) error
+	enqueueWebhook(ctx context.Context, req domain.EnqueueWebhookReq) ([]domain.WebhookDelivery, error)
+	claimWebhookDeliveries(ctx context.Context, req domain.ClaimWebhookDeliveriesReq) ([]domain.WebhookDelivery, error)
+	recordWebhookAttempt(ctx context.Context, req domain.RecordWebhookAttemptReq) (domain.WebhookDelivery, error)
+	getWebhookDeliveries(ctx context.Context, req domain.GetWebhookDeliveriesReq) ([]domain.WebhookDelivery, error)
+	replayWebhookDelivery(ctx context.Context, req domain.ReplayWebhookDeliveryReq) (domain.WebhookDelivery, error)
+}
+
+type persistentModule struct {
+}
+
+func newPersistent() persistent {
+	return &persistentModule{}
+}
+
+var (
+	// mu guards every subscription and delivery global below.
+	mu sync.Mutex
+
+	subscriptions      map[int]domain.WebhookSubscription = make(map[int]domain.WebhookSubscription)
+	lastSubscriptionID int
+	deliveries         []domain.WebhookDelivery
+	lastDeliveryID     int
+
+	timeNow = time.Now
+)
+
+func (m *persistentModule) createWebhook(ctx context.Context, req domain.CreateWebhookReq) (domain.WebhookSubscription, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	now := timeNow()
+	lastSubscriptionID++
+	sub := domain.WebhookSubscription{
+		ID:        lastSubscriptionID,
+		URL:       req.URL,
+		Secret:    req.Secret,
+		Events:    append([]string(nil), req.Events...),
+		Active:    true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	subscriptions[sub.ID] = sub
+	return sub, nil
+}
+
+func (m *persistentModule) getWebhooks(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	result := []domain.WebhookSubscription{}
+	for _, sub := range subscriptions {
+		result = append(result, sub)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
+
+func (m *persistentModule) getWebhook(ctx context.Context, req domain.GetWebhookReq) (domain.WebhookSubscription, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	sub, ok := subscriptions[req.ID]
+	if !ok {
+		return domain.WebhookSubscription{}, domain.ErrWebhookNotFound
+	}
+	return sub, nil
+}
+
+func (m *persistentModule) updateWebhook(ctx context.Context, req domain.UpdateWebhookReq) (domain.WebhookSubscription, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	sub, ok := subscriptions[req.ID]
+	if !ok {
+		return domain.WebhookSubscription{}, domain.ErrWebhookNotFound
+	}
+
+	now := timeNow()
+	sub.URL = req.URL
+	sub.Events = append([]string(nil), req.Events...)
+	switch {
+	case req.Active && !sub.Active:
+		sub.ConsecutiveFailures = 0
+		sub.DisabledReason = ""
+		sub.DisabledAt = time.Time{}
+	case !req.Active && sub.Active:
+		sub.DisabledReason = "disabled by an admin"
+		sub.DisabledAt = now
+	}
+	sub.Active = req.Active
+	sub.UpdatedAt = now
+	subscriptions[sub.ID] = sub
+	return sub, nil
+}
+
+// deleteWebhook removes a subscription together with its delivery log.
+func (m *persistentModule) deleteWebhook(ctx context.Context, req domain.DeleteWebhookReq) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, ok := subscriptions[req.ID]; !ok {
+		return domain.ErrWebhookNotFound
+	}
+	delete(subscriptions, req.ID)
+
+	kept := deliveries[:0]
+	for _, d := range deliveries {
+		if d.SubscriptionID != req.ID {
+			kept = append(kept, d)
+		}
+	}
+	deliveries = kept
+	return nil
+}
+
+// enqueueWebhook queues the event for its subscribers. An event offered twice is queued once per subscription,
+// so the outbox can deliver it at least once without duplicating webhooks.
+func (m *persistentModule) enqueueWebhook(ctx context.Context, req domain.EnqueueWebhookReq) ([]domain.WebhookDelivery, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	queued := map[int]bool{}
+	for _, d := range deliveries {
+		if d.EventID == req.EventID && d.ReplayOf == 0 {
+			queued[d.SubscriptionID] = true
+		}
+	}
+
+	now := timeNow()
+	result := []domain.WebhookDelivery{}
+	for _, sub := range subscriptions {
+		if !sub.Active || queued[sub.ID] || !subscribed(sub, req.EventType) {
+			continue
+		}
+		result = append(result, addDelivery(domain.WebhookDelivery{
+			SubscriptionID: sub.ID,
+			EventID:        req.EventID,
+			EventType:      req.EventType,
+			Payload:        req.Payload,
+		}, now))
+	}
+	return result, nil
+}
+
+// claimWebhookDeliveries returns the oldest due deliveries of active subscriptions and keeps them from other
+// replicas for req.Lease.
+func (m *persistentModule) claimWebhookDeliveries(ctx context.Context, req domain.ClaimWebhookDeliveriesReq) ([]domain.WebhookDelivery, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultClaimLimit
+	}
+
+	now := timeNow()
+	claimed := []domain.WebhookDelivery{}
+	for i := range deliveries {
+		if len(claimed) == limit {
+			break
+		}
+		d := &deliveries[i]
+		if d.Status != domain.WebhookDeliveryPending || d.NextAttemptAt.After(now) || !subscriptions[d.SubscriptionID].Active {
+			continue
+		}
+		d.NextAttemptAt = now.Add(req.Lease)
+		claimed = append(claimed, *d)
+	}
+	return claimed, nil
+}
+
+// recordWebhookAttempt stores the outcome of a delivery attempt and keeps count of the subscription's failures in
+// a row, disabling it when they reach req.DisableAfter.
+func (m *persistentModule) recordWebhookAttempt(ctx context.Context, req domain.RecordWebhookAttemptReq) (domain.WebhookDelivery, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	d := findDelivery(req.ID)
+	if d == nil {
+		return domain.WebhookDelivery{}, domain.ErrWebhookDeliveryNotFound
+	}
+	if d.Status != domain.WebhookDeliveryPending {
+		return *d, nil
+	}
+
+	now := timeNow()
+	d.Attempts++
+	d.ResponseStatus = req.ResponseStatus
+	d.LastError = req.Error
+	sub, ok := subscriptions[d.SubscriptionID]
+
+	if req.Error == "" {
+		d.Status = domain.WebhookDeliverySucceeded
+		d.CompletedAt = now
+		if ok {
+			sub.ConsecutiveFailures = 0
+			subscriptions[sub.ID] = sub
+		}
+		return *d, nil
+	}
+
+	if req.GiveUp {
+		d.Status = domain.WebhookDeliveryFailed
+		d.CompletedAt = now
+	} else {
+		d.NextAttemptAt = req.RetryAt
+	}
+	if ok {
+		sub.ConsecutiveFailures++
+		if req.DisableAfter > 0 && sub.Active && sub.ConsecutiveFailures >= req.DisableAfter {
+			sub.Active = false
+			sub.DisabledReason = fmt.Sprintf("disabled after %d failed deliveries in a row", sub.ConsecutiveFailures)
+			sub.DisabledAt = now
+		}
+		subscriptions[sub.ID] = sub
+	}
+	return *d, nil
+}
+
+// getWebhookDeliveries lists the delivery log, newest first.
+func (m *persistentModule) getWebhookDeliveries(ctx context.Context, req domain.GetWebhookDeliveriesReq) ([]domain.WebhookDelivery, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	result := []domain.WebhookDelivery{}
+	for i := len(deliveries) - 1; i >= 0; i-- {
+		d := deliveries[i]
+		if req.SubscriptionID != 0 && d.SubscriptionID != req.SubscriptionID {
+			continue
+		}
+		if req.Status != "" && d.Status != req.Status {
+			continue
+		}
+		result = append(result, d)
+	}
+	return result, nil
+}
+
+// replayWebhookDelivery queues a new delivery with the payload of an earlier one, the original stays in the log.
+func (m *persistentModule) replayWebhookDelivery(ctx context.Context, req domain.ReplayWebhookDeliveryReq) (domain.WebhookDelivery, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	d := findDelivery(req.ID)
+	if d == nil {
+		return domain.WebhookDelivery{}, domain.ErrWebhookDeliveryNotFound
+	}
+	sub, ok := subscriptions[d.SubscriptionID]
+	if !ok {
+		return domain.WebhookDelivery{}, domain.ErrWebhookNotFound
+	}
+	if !sub.Active {
+		return domain.WebhookDelivery{}, domain.ErrWebhookDisabled
+	}
+
+	return addDelivery(domain.WebhookDelivery{
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		ReplayOf:       d.ID,
+	}, timeNow()), nil
+}
+
+// addDelivery stores d as a new pending delivery due now and returns it. Callers must hold mu.
+func addDelivery(d domain.WebhookDelivery, now time.Time) domain.WebhookDelivery {
+	lastDeliveryID++
+	d.ID = lastDeliveryID
+	d.Status = domain.WebhookDeliveryPending
+	d.NextAttemptAt = now
+	d.CreatedAt = now
+	deliveries = append(deliveries, d)
+	return d
+}
+
+// findDelivery returns the stored delivery with id. Callers must hold mu.
+func findDelivery(id int) *domain.WebhookDelivery {
+	for i := range deliveries {
+		if deliveries[i].ID == id {
+			return &deliveries[i]
+		}
+	}
+	return nil
+}
+
+// subscribed reports whether sub wants events of eventType.
+func subscribed(sub domain.WebhookSubscription, eventType string) bool {
+	if len(sub.Events) == 0 {
+		return true
+	}
+	for _, e := range sub.Events {
+		if e == eventType {
+			return true
+		}
+	}
+	return false
+}
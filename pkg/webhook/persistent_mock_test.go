@@ -0,0 +1,185 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/webhook/persistent.go
+
+// Package mock_webhook is a generated GoMock package.
+package webhook
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "gihub.com/gadhittana01/book-project/pkg/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mockpersistent is a mock of persistent interface.
+type Mockpersistent struct {
+	ctrl     *gomock.Controller
+	recorder *MockpersistentMockRecorder
+}
+
+// MockpersistentMockRecorder is the mock recorder for Mockpersistent.
+type MockpersistentMockRecorder struct {
+	mock *Mockpersistent
+}
+
+// NewMockpersistent creates a new mock instance.
+func NewMockpersistent(ctrl *gomock.Controller) *Mockpersistent {
+	mock := &Mockpersistent{ctrl: ctrl}
+	mock.recorder = &MockpersistentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockpersistent) EXPECT() *MockpersistentMockRecorder {
+	return m.recorder
+}
+
+// claimWebhookDeliveries mocks base method.
+func (m *Mockpersistent) claimWebhookDeliveries(ctx context.Context, req domain.ClaimWebhookDeliveriesReq) ([]domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "claimWebhookDeliveries", ctx, req)
+	ret0, _ := ret[0].([]domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// claimWebhookDeliveries indicates an expected call of claimWebhookDeliveries.
+func (mr *MockpersistentMockRecorder) claimWebhookDeliveries(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "claimWebhookDeliveries", reflect.TypeOf((*Mockpersistent)(nil).claimWebhookDeliveries), ctx, req)
+}
+
+// createWebhook mocks base method.
+func (m *Mockpersistent) createWebhook(ctx context.Context, req domain.CreateWebhookReq) (domain.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createWebhook", ctx, req)
+	ret0, _ := ret[0].(domain.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// createWebhook indicates an expected call of createWebhook.
+func (mr *MockpersistentMockRecorder) createWebhook(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createWebhook", reflect.TypeOf((*Mockpersistent)(nil).createWebhook), ctx, req)
+}
+
+// deleteWebhook mocks base method.
+func (m *Mockpersistent) deleteWebhook(ctx context.Context, req domain.DeleteWebhookReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "deleteWebhook", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// deleteWebhook indicates an expected call of deleteWebhook.
+func (mr *MockpersistentMockRecorder) deleteWebhook(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "deleteWebhook", reflect.TypeOf((*Mockpersistent)(nil).deleteWebhook), ctx, req)
+}
+
+// enqueueWebhook mocks base method.
+func (m *Mockpersistent) enqueueWebhook(ctx context.Context, req domain.EnqueueWebhookReq) ([]domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "enqueueWebhook", ctx, req)
+	ret0, _ := ret[0].([]domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// enqueueWebhook indicates an expected call of enqueueWebhook.
+func (mr *MockpersistentMockRecorder) enqueueWebhook(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "enqueueWebhook", reflect.TypeOf((*Mockpersistent)(nil).enqueueWebhook), ctx, req)
+}
+
+// getWebhook mocks base method.
+func (m *Mockpersistent) getWebhook(ctx context.Context, req domain.GetWebhookReq) (domain.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getWebhook", ctx, req)
+	ret0, _ := ret[0].(domain.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getWebhook indicates an expected call of getWebhook.
+func (mr *MockpersistentMockRecorder) getWebhook(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getWebhook", reflect.TypeOf((*Mockpersistent)(nil).getWebhook), ctx, req)
+}
+
+// getWebhookDeliveries mocks base method.
+func (m *Mockpersistent) getWebhookDeliveries(ctx context.Context, req domain.GetWebhookDeliveriesReq) ([]domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getWebhookDeliveries", ctx, req)
+	ret0, _ := ret[0].([]domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getWebhookDeliveries indicates an expected call of getWebhookDeliveries.
+func (mr *MockpersistentMockRecorder) getWebhookDeliveries(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getWebhookDeliveries", reflect.TypeOf((*Mockpersistent)(nil).getWebhookDeliveries), ctx, req)
+}
+
+// getWebhooks mocks base method.
+func (m *Mockpersistent) getWebhooks(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getWebhooks", ctx)
+	ret0, _ := ret[0].([]domain.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getWebhooks indicates an expected call of getWebhooks.
+func (mr *MockpersistentMockRecorder) getWebhooks(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getWebhooks", reflect.TypeOf((*Mockpersistent)(nil).getWebhooks), ctx)
+}
+
+// recordWebhookAttempt mocks base method.
+func (m *Mockpersistent) recordWebhookAttempt(ctx context.Context, req domain.RecordWebhookAttemptReq) (domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "recordWebhookAttempt", ctx, req)
+	ret0, _ := ret[0].(domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// recordWebhookAttempt indicates an expected call of recordWebhookAttempt.
+func (mr *MockpersistentMockRecorder) recordWebhookAttempt(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "recordWebhookAttempt", reflect.TypeOf((*Mockpersistent)(nil).recordWebhookAttempt), ctx, req)
+}
+
+// replayWebhookDelivery mocks base method.
+func (m *Mockpersistent) replayWebhookDelivery(ctx context.Context, req domain.ReplayWebhookDeliveryReq) (domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "replayWebhookDelivery", ctx, req)
+	ret0, _ := ret[0].(domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// replayWebhookDelivery indicates an expected call of replayWebhookDelivery.
+func (mr *MockpersistentMockRecorder) replayWebhookDelivery(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "replayWebhookDelivery", reflect.TypeOf((*Mockpersistent)(nil).replayWebhookDelivery), ctx, req)
+}
+
+// updateWebhook mocks base method.
+func (m *Mockpersistent) updateWebhook(ctx context.Context, req domain.UpdateWebhookReq) (domain.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "updateWebhook", ctx, req)
+	ret0, _ := ret[0].(domain.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// updateWebhook indicates an expected call of updateWebhook.
+func (mr *MockpersistentMockRecorder) updateWebhook(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "updateWebhook", reflect.TypeOf((*Mockpersistent)(nil).updateWebhook), ctx, req)
+}
\ No newline at end of file
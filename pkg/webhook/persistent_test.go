@@ -0,0 +1,124 @@
+package webhook
+
+import (
+	"context"
+	reflect "reflect"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+func Test_newPersistent(t *testing.T) {
+	if got, want := newPersistent(), (&persistentModule{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("newPersistent() = %v, want %v", got, want)
+	}
+}
+
+func Test_subscriptions(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 6, 1, 9, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	m := &persistentModule{}
+
+	kiosk, err := m.createWebhook(ctx, domain.CreateWebhookReq{URL: "http://kiosk.local/hook", Secret: "s1", Events: []string{domain.EventReservationCreated}})
+	if err != nil || kiosk.ID == 0 || !kiosk.Active {
+		t.Fatalf("createWebhook() = %+v, %v", kiosk, err)
+	}
+	if got, err := m.getWebhook(ctx, domain.GetWebhookReq{ID: kiosk.ID}); err != nil || !reflect.DeepEqual(got, kiosk) {
+		t.Errorf("getWebhook() = %+v, %v", got, err)
+	}
+
+	updated, err := m.updateWebhook(ctx, domain.UpdateWebhookReq{ID: kiosk.ID, URL: "http://kiosk.local/v2", Active: false})
+	if err != nil || updated.Active || updated.URL != "http://kiosk.local/v2" || updated.DisabledReason == "" || len(updated.Events) != 0 {
+		t.Errorf("updateWebhook() disable = %+v, %v", updated, err)
+	}
+	if updated, _ = m.updateWebhook(ctx, domain.UpdateWebhookReq{ID: kiosk.ID, URL: "http://kiosk.local/v2", Active: true}); !updated.Active || updated.DisabledReason != "" {
+		t.Errorf("updateWebhook() enable = %+v", updated)
+	}
+	if _, err := m.updateWebhook(ctx, domain.UpdateWebhookReq{ID: 9999}); err != domain.ErrWebhookNotFound {
+		t.Errorf("updateWebhook() unknown error = %v", err)
+	}
+
+	if err := m.deleteWebhook(ctx, domain.DeleteWebhookReq{ID: kiosk.ID}); err != nil {
+		t.Errorf("deleteWebhook() error = %v", err)
+	}
+	if _, err := m.getWebhook(ctx, domain.GetWebhookReq{ID: kiosk.ID}); err != domain.ErrWebhookNotFound {
+		t.Errorf("getWebhook() after delete error = %v", err)
+	}
+}
+
+func Test_deliveries(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 6, 2, 9, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	m := &persistentModule{}
+
+	all, _ := m.createWebhook(ctx, domain.CreateWebhookReq{URL: "http://sms.local/hook", Secret: "s1"})
+	cancelsOnly, _ := m.createWebhook(ctx, domain.CreateWebhookReq{URL: "http://kiosk.local/hook", Secret: "s2", Events: []string{domain.EventReservationCancelled}})
+	defer m.deleteWebhook(ctx, domain.DeleteWebhookReq{ID: all.ID})
+	defer m.deleteWebhook(ctx, domain.DeleteWebhookReq{ID: cancelsOnly.ID})
+
+	created := domain.EnqueueWebhookReq{EventID: 9001, EventType: domain.EventReservationCreated, Payload: `{"id":"9001"}`}
+	queued, err := m.enqueueWebhook(ctx, created)
+	if err != nil || len(queued) != 1 || queued[0].SubscriptionID != all.ID {
+		t.Fatalf("enqueueWebhook() = %+v, %v", queued, err)
+	}
+	if again, _ := m.enqueueWebhook(ctx, created); len(again) != 0 {
+		t.Errorf("enqueueWebhook() same event again = %+v, want none", again)
+	}
+	if queued, _ := m.enqueueWebhook(ctx, domain.EnqueueWebhookReq{EventID: 9002, EventType: domain.EventReservationCancelled, Payload: `{"id":"9002"}`}); len(queued) != 2 {
+		t.Errorf("enqueueWebhook() cancellation = %d deliveries, want 2", len(queued))
+	}
+
+	claim := domain.ClaimWebhookDeliveriesReq{Lease: time.Minute}
+	claimed, _ := m.claimWebhookDeliveries(ctx, claim)
+	if len(claimed) != 3 {
+		t.Fatalf("claimWebhookDeliveries() = %d, want 3", len(claimed))
+	}
+	if again, _ := m.claimWebhookDeliveries(ctx, claim); len(again) != 0 {
+		t.Errorf("claimWebhookDeliveries() while leased = %d, want 0", len(again))
+	}
+
+	// The kiosk fails twice in a row and is disabled, its pending delivery is no longer claimed.
+	var kioskDelivery domain.WebhookDelivery
+	for _, d := range claimed {
+		if d.SubscriptionID == cancelsOnly.ID {
+			kioskDelivery = d
+			continue
+		}
+		if got, _ := m.recordWebhookAttempt(ctx, domain.RecordWebhookAttemptReq{ID: d.ID, ResponseStatus: 200}); got.Status != domain.WebhookDeliverySucceeded {
+			t.Errorf("recordWebhookAttempt() success = %+v", got)
+		}
+	}
+	attempt := domain.RecordWebhookAttemptReq{ID: kioskDelivery.ID, ResponseStatus: 500, Error: "webhook answered 500", RetryAt: now, DisableAfter: 2}
+	m.recordWebhookAttempt(ctx, attempt)
+	attempt.GiveUp = true
+	if got, _ := m.recordWebhookAttempt(ctx, attempt); got.Status != domain.WebhookDeliveryFailed || got.Attempts != 2 {
+		t.Errorf("recordWebhookAttempt() give up = %+v", got)
+	}
+	if sub, _ := m.getWebhook(ctx, domain.GetWebhookReq{ID: cancelsOnly.ID}); sub.Active || sub.ConsecutiveFailures != 2 {
+		t.Errorf("subscription after failures = %+v", sub)
+	}
+
+	if _, err := m.replayWebhookDelivery(ctx, domain.ReplayWebhookDeliveryReq{ID: kioskDelivery.ID}); err != domain.ErrWebhookDisabled {
+		t.Errorf("replayWebhookDelivery() disabled error = %v", err)
+	}
+	m.updateWebhook(ctx, domain.UpdateWebhookReq{ID: cancelsOnly.ID, URL: cancelsOnly.URL, Events: cancelsOnly.Events, Active: true})
+	replay, err := m.replayWebhookDelivery(ctx, domain.ReplayWebhookDeliveryReq{ID: kioskDelivery.ID})
+	if err != nil || replay.ReplayOf != kioskDelivery.ID || replay.Payload != `{"id":"9002"}` || replay.Status != domain.WebhookDeliveryPending {
+		t.Errorf("replayWebhookDelivery() = %+v, %v", replay, err)
+	}
+
+	log, _ := m.getWebhookDeliveries(ctx, domain.GetWebhookDeliveriesReq{SubscriptionID: cancelsOnly.ID})
+	if len(log) != 2 || log[0].ID != replay.ID || log[1].Status != domain.WebhookDeliveryFailed {
+		t.Errorf("getWebhookDeliveries() = %+v", log)
+	}
+	if failed, _ := m.getWebhookDeliveries(ctx, domain.GetWebhookDeliveriesReq{Status: domain.WebhookDeliveryFailed}); len(failed) != 1 {
+		t.Errorf("getWebhookDeliveries() failed = %d, want 1", len(failed))
+	}
+}
@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+const (
+	defaultTimeoutMS = 5000
+
+	// SignatureHeader carries "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>" keyed by the secret>".
+	SignatureHeader = "X-Book-Project-Signature"
+	EventHeader     = "X-Book-Project-Event"
+	DeliveryHeader  = "X-Book-Project-Delivery"
+)
+
+type sender interface {
+	send(ctx context.Context, req domain.SendWebhookReq) (int, error)
+}
+
+type httpSender struct {
+	client *http.Client
+}
+
+func newSender(c config.Webhooks) sender {
+	timeout := c.TimeoutMS
+	if timeout <= 0 {
+		timeout = defaultTimeoutMS
+	}
+	// Webhook URLs come from API users, so the sender does not go through a proxy and only dials allowed addresses.
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = guardedDialer(time.Duration(timeout)*time.Millisecond, c.AllowedHosts)
+	return &httpSender{
+		client: &http.Client{
+			Timeout:   time.Duration(timeout) * time.Millisecond,
+			Transport: transport,
+		},
+	}
+}
+
+// send posts the signed payload and returns the response status, any status but 2xx is an error.
+func (s *httpSender) send(ctx context.Context, req domain.SendWebhookReq) (int, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader([]byte(req.Payload)))
+	if err != nil {
+		return 0, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set(EventHeader, req.EventType)
+	httpReq.Header.Set(DeliveryHeader, strconv.Itoa(req.DeliveryID))
+	httpReq.Header.Set(SignatureHeader, Sign(req.Secret, timeNow().Unix(), req.Payload))
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Drain a little of the body so the connection can be reused.
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook answered %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the SignatureHeader value for payload sent at timestamp.
+func Sign(secret string, timestamp int64, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.%s", timestamp, payload)
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
@@ -0,0 +1,117 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+func TestSign(t *testing.T) {
+	// Computed with: printf '1654070400.{"id":"1"}' | openssl dgst -sha256 -hmac secret
+	want := "t=1654070400,v1=da72d7e7d106dd527fa78197bd65842391ea1a165841d5a5b4e2c39c1e491b5c"
+	if got := Sign("secret", 1654070400, `{"id":"1"}`); got != want {
+		t.Errorf("Sign() = %v, want %v", got, want)
+	}
+}
+
+func Test_send(t *testing.T) {
+	now := time.Date(2022, 6, 1, 9, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	tests := []struct {
+		name       string
+		status     int
+		wantStatus int
+		wantErr    bool
+	}{
+		{
+			name:       "accepted",
+			status:     http.StatusNoContent,
+			wantStatus: http.StatusNoContent,
+			wantErr:    false,
+		},
+		{
+			name:       "server error",
+			status:     http.StatusBadGateway,
+			wantStatus: http.StatusBadGateway,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := ioutil.ReadAll(r.Body)
+				if got, want := r.Header.Get(SignatureHeader), Sign("secret", now.Unix(), string(body)); got != want {
+					t.Errorf("signature = %v, want %v", got, want)
+				}
+				if r.Header.Get(EventHeader) != domain.EventReservationCreated || r.Header.Get(DeliveryHeader) != "7" {
+					t.Errorf("headers = %v", r.Header)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			s := newSender(config.Webhooks{AllowedHosts: []string{"127.0.0.0/8"}})
+			status, err := s.send(context.Background(), domain.SendWebhookReq{
+				URL:        srv.URL,
+				Secret:     "secret",
+				EventType:  domain.EventReservationCreated,
+				DeliveryID: 7,
+				Payload:    `{"id":"1"}`,
+			})
+			if (err != nil) != tt.wantErr || status != tt.wantStatus {
+				t.Errorf("send() = %v, %v, want %v, wantErr %v", status, err, tt.wantStatus, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_sendInternalAddress(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	s := newSender(config.Webhooks{})
+	if _, err := s.send(context.Background(), domain.SendWebhookReq{URL: srv.URL, Payload: "{}"}); !errors.Is(err, ErrAddressNotAllowed) {
+		t.Errorf("send() error = %v, want %v", err, ErrAddressNotAllowed)
+	}
+	if called {
+		t.Errorf("send() reached an address that is not allowed")
+	}
+}
+
+func TestHostAllowed(t *testing.T) {
+	tests := []struct {
+		host    string
+		allowed []string
+		want    bool
+	}{
+		{host: "kiosk.example.com", want: true},
+		{host: "203.0.113.9", want: true},
+		{host: "127.0.0.1", want: false},
+		{host: "localhost", want: false},
+		{host: "169.254.169.254", want: false},
+		{host: "10.1.2.3", want: false},
+		{host: "::1", want: false},
+		{host: "fd00::1", want: false},
+		{host: "0.0.0.0", want: false},
+		{host: "10.1.2.3", allowed: []string{"10.0.0.0/8"}, want: true},
+		{host: "localhost", allowed: []string{"127.0.0.1"}, want: true},
+		{host: "192.168.1.5", allowed: []string{"192.168.1.6"}, want: false},
+	}
+	for _, tt := range tests {
+		if got := HostAllowed(tt.host, tt.allowed); got != tt.want {
+			t.Errorf("HostAllowed(%q, %v) = %v, want %v", tt.host, tt.allowed, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	"context"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+type IResource interface {
+	CreateWebhook(ctx context.Context, req domain.CreateWebhookReq) (domain.WebhookSubscription, error)
+	GetWebhooks(ctx context.Context) ([]domain.WebhookSubscription, error)
+	GetWebhook(ctx context.Context, req domain.GetWebhookReq) (domain.WebhookSubscription, error)
+	UpdateWebhook(ctx context.Context, req domain.UpdateWebhookReq) (domain.WebhookSubscription, error)
+	DeleteWebhook(ctx context.Context, req domain.DeleteWebhookReq) error
+	EnqueueWebhook(ctx context.Context, req domain.EnqueueWebhookReq) ([]domain.WebhookDelivery, error)
+	ClaimWebhookDeliveries(ctx context.Context, req domain.ClaimWebhookDeliveriesReq) ([]domain.WebhookDelivery, error)
+	RecordWebhookAttempt(ctx context.Context, req domain.RecordWebhookAttemptReq) (domain.WebhookDelivery, error)
+	GetWebhookDeliveries(ctx context.Context, req domain.GetWebhookDeliveriesReq) ([]domain.WebhookDelivery, error)
+	ReplayWebhookDelivery(ctx context.Context, req domain.ReplayWebhookDeliveryReq) (domain.WebhookDelivery, error)
+	SendWebhook(ctx context.Context, req domain.SendWebhookReq) (int, error)
+}
+
+type module struct {
+	persistent persistent
+	sender     sender
+}
+
+func New(cfg *config.GlobalConfig) (IResource, error) {
+	return &module{
+		persistent: newPersistent(),
+		sender:     newSender(cfg.Webhooks),
+	}, nil
+}
+
+func (m module) CreateWebhook(ctx context.Context, req domain.CreateWebhookReq) (domain.WebhookSubscription, error) {
+	return m.persistent.createWebhook(ctx, req)
+}
+
+func (m module) GetWebhooks(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	return m.persistent.getWebhooks(ctx)
+}
+
+func (m module) GetWebhook(ctx context.Context, req domain.GetWebhookReq) (domain.WebhookSubscription, error) {
+	return m.persistent.getWebhook(ctx, req)
+}
+
+func (m module) UpdateWebhook(ctx context.Context, req domain.UpdateWebhookReq) (domain.WebhookSubscription, error) {
+	return m.persistent.updateWebhook(ctx, req)
+}
+
+func (m module) DeleteWebhook(ctx context.Context, req domain.DeleteWebhookReq) error {
+	return m.persistent.deleteWebhook(ctx, req)
+}
+
+func (m module) EnqueueWebhook(ctx context.Context, req domain.EnqueueWebhookReq) ([]domain.WebhookDelivery, error) {
+	return m.persistent.enqueueWebhook(ctx, req)
+}
+
+func (m module) ClaimWebhookDeliveries(ctx context.Context, req domain.ClaimWebhookDeliveriesReq) ([]domain.WebhookDelivery, error) {
+	return m.persistent.claimWebhookDeliveries(ctx, req)
+}
+
+func (m module) RecordWebhookAttempt(ctx context.Context, req domain.RecordWebhookAttemptReq) (domain.WebhookDelivery, error) {
+	return m.persistent.recordWebhookAttempt(ctx, req)
+}
+
+func (m module) GetWebhookDeliveries(ctx context.Context, req domain.GetWebhookDeliveriesReq) ([]domain.WebhookDelivery, error) {
+	return m.persistent.getWebhookDeliveries(ctx, req)
+}
+
+func (m module) ReplayWebhookDelivery(ctx context.Context, req domain.ReplayWebhookDeliveryReq) (domain.WebhookDelivery, error) {
+	return m.persistent.replayWebhookDelivery(ctx, req)
+}
+
+func (m module) SendWebhook(ctx context.Context, req domain.SendWebhookReq) (int, error) {
+	return m.sender.send(ctx, req)
+}
@@ -0,0 +1,83 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	reflect "reflect"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+func TestNew(t *testing.T) {
+	got, err := New(&config.GlobalConfig{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	// The sender's transport holds functions, which DeepEqual never finds equal, so only its type is compared.
+	m, ok := got.(*module)
+	if !ok || !reflect.DeepEqual(m.persistent, newPersistent()) || reflect.TypeOf(m.sender) != reflect.TypeOf(newSender(config.Webhooks{})) {
+		t.Errorf("New() = %v", got)
+	}
+}
+
+func Test_EnqueueWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	req := domain.EnqueueWebhookReq{EventID: 1, EventType: domain.EventReservationCreated, Payload: "{}"}
+
+	pstMock := NewMockpersistent(ctrl)
+	pstMock.EXPECT().enqueueWebhook(gomock.Any(), req).Return([]domain.WebhookDelivery{{ID: 1, SubscriptionID: 2}}, nil)
+	m := module{persistent: pstMock}
+
+	got, err := m.EnqueueWebhook(context.Background(), req)
+	if err != nil || !reflect.DeepEqual(got, []domain.WebhookDelivery{{ID: 1, SubscriptionID: 2}}) {
+		t.Errorf("EnqueueWebhook() = %v, %v", got, err)
+	}
+}
+
+func Test_SendWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	req := domain.SendWebhookReq{URL: "http://kiosk.local/hook", Secret: "s1", DeliveryID: 1, Payload: "{}"}
+
+	tests := []struct {
+		name    string
+		mock    func() *module
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "success",
+			mock: func() *module {
+				senderMock := NewMocksender(ctrl)
+				senderMock.EXPECT().send(gomock.Any(), req).Return(200, nil)
+				return &module{sender: senderMock}
+			},
+			want:    200,
+			wantErr: false,
+		},
+		{
+			name: "failure",
+			mock: func() *module {
+				senderMock := NewMocksender(ctrl)
+				senderMock.EXPECT().send(gomock.Any(), req).Return(0, errors.New("connection refused"))
+				return &module{sender: senderMock}
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.mock().SendWebhook(context.Background(), req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SendWebhook() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("SendWebhook() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+const (
+	BatchItemCreated = "created"
+	BatchItemFailed  = "failed"
+	BatchItemSkipped = "skipped"
+
+	defaultBatchMaxItems = 50
+)
+
+// BorrowBooks reserves several books in one request, running every item through the same checks as BorrowBook.
+// Item results keep the order of the request.
+func (p bookService) BorrowBooks(ctx context.Context, req BorrowBooksReq) (BorrowBooksRes, error) {
+	limit := p.batchMax
+	if limit <= 0 {
+		limit = defaultBatchMaxItems
+	}
+	if len(req.Items) == 0 {
+		return BorrowBooksRes{}, invalidRequest("Items are empty")
+	}
+	if len(req.Items) > limit {
+		return BorrowBooksRes{}, invalidRequest(fmt.Sprintf("A batch takes at most %d items, got %d", limit, len(req.Items)))
+	}
+
+	result := BorrowBooksRes{
+		Atomic: req.Atomic,
+		Items:  make([]BorrowBookItemRes, len(req.Items)),
+	}
+	for i := range result.Items {
+		result.Items[i].Index = i
+	}
+
+	if !req.Atomic {
+		for i, item := range req.Items {
+			res, err := p.BorrowBook(ctx, item)
+			if err != nil {
+				result.Items[i].fail(err)
+				continue
+			}
+			result.Items[i].Status = BatchItemCreated
+			result.Items[i].Reservation = &res
+		}
+		result.count()
+		return result, nil
+	}
+
+	// Every item is checked before anything is stored. The store checks the policies, where earlier items count
+	// against later ones.
+	prepared := make([]domain.BorrowBookReq, len(req.Items))
+	failed := false
+	for i, item := range req.Items {
+		res, err := p.prepareBorrow(ctx, item)
+		if err != nil {
+			result.Items[i].fail(err)
+			failed = true
+			continue
+		}
+		prepared[i] = res
+	}
+
+	if !failed {
+		stored, err := p.br.BorrowBooks(context.Background(), prepared)
+		var itemErr *domain.BatchItemError
+		switch {
+		case errors.As(err, &itemErr):
+			result.Items[itemErr.Index].fail(itemErr.Err)
+			failed = true
+		case err != nil:
+			return BorrowBooksRes{}, err
+		default:
+			for i := range stored {
+				res := newBorrowBookRes(prepared[i], stored[i])
+				result.Items[i].Status = BatchItemCreated
+				result.Items[i].Reservation = &res
+			}
+		}
+	}
+
+	if failed {
+		for i := range result.Items {
+			if result.Items[i].Status == "" {
+				result.Items[i].Status = BatchItemSkipped
+			}
+		}
+	}
+	result.count()
+	return result, nil
+}
+
+func (r *BorrowBookItemRes) fail(err error) {
+	r.Status = BatchItemFailed
+	r.Error = &ItemErrorRes{
+		Code:    ErrCodeInternal,
+		Message: err.Error(),
+	}
+
+	var se *ServiceError
+	if errors.As(wrapDomainError(err), &se) {
+		r.Error.Code = se.Code
+		r.Error.Message = se.Message
+		r.Error.Details = se.Details
+	}
+}
+
+func (r *BorrowBooksRes) count() {
+	for _, item := range r.Items {
+		switch item.Status {
+		case BatchItemCreated:
+			r.Created++
+		case BatchItemFailed:
+			r.Failed++
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"errors"
+	reflect "reflect"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+func Test_BorrowBooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	matilda := domain.Book{Key: "123", Title: "Matilda"}
+	dune := domain.Book{Key: "456", Title: "Dune"}
+	item := func(key string) BorrowBookReq {
+		return BorrowBookReq{BookKey: key, Branch: "central", PickUpDate: "2022-01-24", UserID: 1}
+	}
+	stored := func(id int, book domain.Book) domain.BorrowBookReq {
+		return domain.BorrowBookReq{ID: id, Book: book, Branch: "central", PickUpDate: "2022-01-24", PickUpSlot: "09:00", UserID: 1, Status: domain.ReservationStatusActive}
+	}
+	created := func(index, id int, book domain.Book) BorrowBookItemRes {
+		return BorrowBookItemRes{
+			Index:  index,
+			Status: BatchItemCreated,
+			Reservation: &BorrowBookRes{
+				ID:         id,
+				Book:       Book{Key: book.Key, Title: book.Title, Authors: []Author{}},
+				Branch:     "central",
+				PickUpDate: "2022-01-24",
+				PickUpSlot: "09:00",
+				UserID:     1,
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		req      BorrowBooksReq
+		mock     func() bookService
+		want     BorrowBooksRes
+		wantCode string
+		wantErr  bool
+	}{
+		{
+			name: "items fail on their own",
+			req:  BorrowBooksReq{Items: []BorrowBookReq{item("123"), {BookKey: "456", UserID: 1}, item("456")}},
+			mock: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().GetBookByKey(gomock.Any(), domain.GeBookByKeyReq{Key: "123"}).Return(matilda, nil)
+				bookMock.EXPECT().BorrowBook(gomock.Any(), gomock.Any()).Return(stored(1, matilda), nil)
+				bookMock.EXPECT().GetBookByKey(gomock.Any(), domain.GeBookByKeyReq{Key: "456"}).Return(dune, nil)
+				bookMock.EXPECT().BorrowBook(gomock.Any(), gomock.Any()).Return(domain.BorrowBookReq{}, domain.ErrFullyReserved)
+				return bookService{br: bookMock}
+			},
+			want: BorrowBooksRes{
+				Created: 1,
+				Failed:  2,
+				Items: []BorrowBookItemRes{
+					created(0, 1, matilda),
+					{Index: 1, Status: BatchItemFailed, Error: &ItemErrorRes{Code: ErrCodeInvalidRequest, Message: "Branch is empty"}},
+					{Index: 2, Status: BatchItemFailed, Error: &ItemErrorRes{Code: ErrCodeConflict, Message: domain.ErrFullyReserved.Error()}},
+				},
+			},
+		},
+		{
+			name: "atomic batch is stored in one step",
+			req:  BorrowBooksReq{Atomic: true, Items: []BorrowBookReq{item("123"), item("456")}},
+			mock: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().GetBookByKey(gomock.Any(), domain.GeBookByKeyReq{Key: "123"}).Return(matilda, nil)
+				bookMock.EXPECT().GetBookByKey(gomock.Any(), domain.GeBookByKeyReq{Key: "456"}).Return(dune, nil)
+				bookMock.EXPECT().BorrowBooks(gomock.Any(), []domain.BorrowBookReq{
+					{Book: matilda, Branch: "central", PickUpDate: "2022-01-24", UserID: 1},
+					{Book: dune, Branch: "central", PickUpDate: "2022-01-24", UserID: 1},
+				}).Return([]domain.BorrowBookReq{stored(1, matilda), stored(2, dune)}, nil)
+				return bookService{br: bookMock}
+			},
+			want: BorrowBooksRes{
+				Atomic:  true,
+				Created: 2,
+				Items:   []BorrowBookItemRes{created(0, 1, matilda), created(1, 2, dune)},
+			},
+		},
+		{
+			name: "atomic batch denied by policy in the store",
+			req:  BorrowBooksReq{Atomic: true, Items: []BorrowBookReq{item("123"), item("123")}},
+			mock: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().GetBookByKey(gomock.Any(), gomock.Any()).Return(matilda, nil).Times(2)
+				bookMock.EXPECT().BorrowBooks(gomock.Any(), gomock.Any()).Return(nil, &domain.BatchItemError{Index: 1, Err: &domain.PolicyError{
+					Violations: []domain.PolicyViolation{{Rule: domain.PolicyOnePerWork, Reason: "User already has a reservation for book with key 123"}},
+				}})
+				return bookService{br: bookMock}
+			},
+			want: BorrowBooksRes{
+				Atomic: true,
+				Failed: 1,
+				Items: []BorrowBookItemRes{
+					{Index: 0, Status: BatchItemSkipped},
+					{Index: 1, Status: BatchItemFailed, Error: &ItemErrorRes{
+						Code:    ErrCodeForbidden,
+						Message: policyDeniedMessage,
+						Details: []ErrorDetail{{Rule: PolicyOnePerWork, Reason: "User already has a reservation for book with key 123"}},
+					}},
+				},
+			},
+		},
+		{
+			name: "atomic batch rolled back by the store",
+			req:  BorrowBooksReq{Atomic: true, Items: []BorrowBookReq{item("123"), item("456")}},
+			mock: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().GetBookByKey(gomock.Any(), gomock.Any()).Return(matilda, nil).Times(2)
+				bookMock.EXPECT().BorrowBooks(gomock.Any(), gomock.Any()).Return(nil, &domain.BatchItemError{Index: 1, Err: domain.ErrPickupSlotFull})
+				return bookService{br: bookMock}
+			},
+			want: BorrowBooksRes{
+				Atomic: true,
+				Failed: 1,
+				Items: []BorrowBookItemRes{
+					{Index: 0, Status: BatchItemSkipped},
+					{Index: 1, Status: BatchItemFailed, Error: &ItemErrorRes{Code: ErrCodeConflict, Message: domain.ErrPickupSlotFull.Error()}},
+				},
+			},
+		},
+		{
+			name: "store error",
+			req:  BorrowBooksReq{Atomic: true, Items: []BorrowBookReq{item("123")}},
+			mock: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().GetBookByKey(gomock.Any(), gomock.Any()).Return(matilda, nil)
+				bookMock.EXPECT().BorrowBooks(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+				return bookService{br: bookMock}
+			},
+			wantErr: true,
+		},
+		{
+			name: "too many items",
+			req:  BorrowBooksReq{Items: []BorrowBookReq{item("1"), item("2"), item("3")}},
+			mock: func() bookService {
+				return bookService{br: NewMockBookResource(ctrl), batchMax: 2}
+			},
+			wantCode: ErrCodeInvalidRequest,
+			wantErr:  true,
+		},
+		{
+			name: "no items",
+			req:  BorrowBooksReq{},
+			mock: func() bookService {
+				return bookService{br: NewMockBookResource(ctrl)}
+			},
+			wantCode: ErrCodeInvalidRequest,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.mock().BorrowBooks(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BorrowBooks() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if se := (*ServiceError)(nil); tt.wantCode != "" && (!errors.As(err, &se) || se.Code != tt.wantCode) {
+				t.Errorf("BorrowBooks() error = %v, wantCode %v", err, tt.wantCode)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BorrowBooks() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 
+	"gihub.com/gadhittana01/book-project/config"
 	"gihub.com/gadhittana01/book-project/pkg/domain"
 )
 
@@ -10,16 +11,46 @@ type BookService interface {
 	GetListOfBooks(ctx context.Context, req GetListOfBooksReq) (GetListOfBooksResp, error)
 	BorrowBook(ctx context.Context, req BorrowBookReq) (BorrowBookRes, error)
 	GetBookReservation(ctx context.Context, req GetBookReservationReq) (map[int][]GetBookReservationRes, error)
+	GetCatalogHealth(ctx context.Context) (GetCatalogHealthRes, error)
+	JoinWaitlist(ctx context.Context, req JoinWaitlistReq) (JoinWaitlistRes, error)
+	CancelReservation(ctx context.Context, req CancelReservationReq) error
+	ConfirmReservation(ctx context.Context, req ConfirmReservationReq) error
+	CheckoutBook(ctx context.Context, req CheckoutBookReq) (LoanRes, error)
+	ReturnBook(ctx context.Context, req ReturnBookReq) (LoanRes, error)
+	RenewLoan(ctx context.Context, req RenewLoanReq) (LoanRes, error)
+	GetLoans(ctx context.Context, req GetLoansReq) (map[int][]LoanRes, error)
+	GetFines(ctx context.Context, req GetFinesReq) (FineLedgerRes, error)
+	SettleFines(ctx context.Context, req SettleFinesReq) (FineLedgerRes, error)
+	WaiveFine(ctx context.Context, req WaiveFineReq) (FineLedgerRes, error)
+	GetBranches(ctx context.Context) ([]BranchRes, error)
+	GetBookAvailability(ctx context.Context, req GetBookAvailabilityReq) ([]BranchAvailabilityRes, error)
+	GetPickupSlots(ctx context.Context, req GetPickupSlotsReq) ([]PickupSlotRes, error)
+	GetOutboxEvents(ctx context.Context, req GetOutboxEventsReq) ([]OutboxEventRes, error)
+	RequeueOutboxEvent(ctx context.Context, req RequeueOutboxEventReq) (OutboxEventRes, error)
+	GetReservationCalendar(ctx context.Context, req GetReservationCalendarReq) (ReservationCalendarRes, error)
+	ExportBookReservation(ctx context.Context, req ExportBookReservationReq, write func(ReservationExportRow) error) error
+	BorrowBooks(ctx context.Context, req BorrowBooksReq) (BorrowBooksRes, error)
 }
 
 type bookService struct {
-	br BookResource
+	br       BookResource
+	ur       UserResource
+	fine     config.Fine
+	calendar config.Calendar
+	batchMax int
 }
 
 func NewBookService(dep BookDependencies) (BookService, error) {
-	return &bookService{
+	svc := &bookService{
 		br: dep.BR,
-	}, nil
+		ur: dep.UR,
+	}
+	if dep.Cfg != nil {
+		svc.fine = dep.Cfg.Fine
+		svc.calendar = dep.Cfg.Calendar
+		svc.batchMax = dep.Cfg.Reservation.BatchMaxItems
+	}
+	return svc, nil
 }
 
 func (p bookService) GetListOfBooks(ctx context.Context, req GetListOfBooksReq) (GetListOfBooksResp, error) {
@@ -49,6 +80,7 @@ func (p bookService) GetListOfBooks(ctx context.Context, req GetListOfBooksReq)
 		})
 
 	}
+	result.Provider = res.Provider
 
 	return result, nil
 }
@@ -56,42 +88,76 @@ func (p bookService) GetListOfBooks(ctx context.Context, req GetListOfBooksReq)
 func (p bookService) BorrowBook(ctx context.Context, req BorrowBookReq) (BorrowBookRes, error) {
 	var result BorrowBookRes
 
+	prepared, err := p.prepareBorrow(ctx, req)
+	if err != nil {
+		return result, err
+	}
+
+	reservation, err := p.br.BorrowBook(context.Background(), prepared)
+	if err != nil {
+		return result, wrapDomainError(err)
+	}
+
+	return newBorrowBookRes(prepared, reservation), nil
+}
+
+// prepareBorrow runs the checks a reservation has to pass before it goes to the store and looks up its book. The
+// store checks the borrowing policies itself.
+func (p bookService) prepareBorrow(ctx context.Context, req BorrowBookReq) (domain.BorrowBookReq, error) {
+	if req.Branch == "" {
+		return domain.BorrowBookReq{}, invalidRequest("Branch is empty")
+	}
+
+	if err := p.checkActiveUser(ctx, req.UserID); err != nil {
+		return domain.BorrowBookReq{}, err
+	}
+
+	if err := p.checkOutstandingFines(ctx, req.UserID); err != nil {
+		return domain.BorrowBookReq{}, err
+	}
+
 	book, err := p.br.GetBookByKey(ctx, domain.GeBookByKeyReq{
 		Key:     req.BookKey,
 		Subject: req.Subject,
 	})
 	if err != nil {
-		return result, err
+		return domain.BorrowBookReq{}, err
 	}
 
-	if err := p.br.BorrowBook(context.Background(), domain.BorrowBookReq{
+	return domain.BorrowBookReq{
 		Book:       book,
+		Subject:    req.Subject,
+		Branch:     req.Branch,
 		PickUpDate: req.PickUpDate,
+		PickUpSlot: req.PickUpSlot,
 		UserID:     req.UserID,
-	}); err != nil {
-		return result, err
-	}
+	}, nil
+}
 
+// newBorrowBookRes describes the stored reservation of req, the store only decides its ID and pickup slot.
+func newBorrowBookRes(req, reservation domain.BorrowBookReq) BorrowBookRes {
 	authors := []Author{}
-	for _, author := range book.Authors {
+	for _, author := range req.Book.Authors {
 		authors = append(authors, Author{
 			Name: author.Name,
 		})
 	}
 
-	result = BorrowBookRes{
+	return BorrowBookRes{
+		ID: reservation.ID,
 		Book: Book{
-			Key:               book.Key,
-			Title:             book.Title,
-			EditionCount:      book.EditionCount,
+			Key:               req.Book.Key,
+			Title:             req.Book.Title,
+			EditionCount:      req.Book.EditionCount,
 			Authors:           authors,
-			LendingIdentifier: book.LendingIdentifier,
+			LendingIdentifier: req.Book.LendingIdentifier,
 		},
+		Branch:     req.Branch,
 		PickUpDate: req.PickUpDate,
+		PickUpSlot: reservation.PickUpSlot,
 		UserID:     req.UserID,
+		Provider:   req.Book.Provider,
 	}
-
-	return result, nil
 }
 
 func (p bookService) GetBookReservation(ctx context.Context, req GetBookReservationReq) (map[int][]GetBookReservationRes, error) {
@@ -99,6 +165,7 @@ func (p bookService) GetBookReservation(ctx context.Context, req GetBookReservat
 
 	res, err := p.br.GetBookReservation(ctx, domain.GetBookReservationReq{
 		UserID: req.UserID,
+		Branch: req.Branch,
 	})
 	if err != nil {
 		return result, err
@@ -107,14 +174,138 @@ func (p bookService) GetBookReservation(ctx context.Context, req GetBookReservat
 	for key, value := range res {
 		var tmp []GetBookReservationRes
 		for _, item := range value {
-			tmp = append(tmp, GetBookReservationRes{
-				BookKey:    item.Book.Key,
-				PickUpDate: item.PickUpDate,
-				UserID:     item.UserID,
-			})
+			row := GetBookReservationRes{
+				ID:            item.ID,
+				BookKey:       item.Book.Key,
+				Branch:        item.Branch,
+				PickUpDate:    item.PickUpDate,
+				PickUpSlot:    item.PickUpSlot,
+				UserID:        item.UserID,
+				Status:        item.Status,
+				QueuePosition: item.QueuePosition,
+			}
+			if !item.ConfirmBy.IsZero() {
+				confirmBy := item.ConfirmBy
+				row.ConfirmBy = &confirmBy
+			}
+			tmp = append(tmp, row)
 		}
 		result[key] = tmp
 	}
 
 	return result, nil
 }
+
+func (p bookService) GetCatalogHealth(ctx context.Context) (GetCatalogHealthRes, error) {
+	var result GetCatalogHealthRes
+
+	res, err := p.br.GetCatalogHealth(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	result.Providers = []ProviderHealth{}
+	for _, item := range res {
+		result.Providers = append(result.Providers, ProviderHealth{
+			Name:                item.Name,
+			Healthy:             item.Healthy,
+			ConsecutiveFailures: item.ConsecutiveFailures,
+			LastError:           item.LastError,
+			LastCheckedAt:       item.LastCheckedAt,
+		})
+		if item.Healthy {
+			result.Ready = true
+		}
+	}
+
+	return result, nil
+}
+
+func (p bookService) JoinWaitlist(ctx context.Context, req JoinWaitlistReq) (JoinWaitlistRes, error) {
+	var result JoinWaitlistRes
+
+	if req.UserID == 0 {
+		return result, invalidRequest("User ID is empty")
+	}
+
+	if req.Branch == "" {
+		return result, invalidRequest("Branch is empty")
+	}
+
+	if err := p.checkActiveUser(ctx, req.UserID); err != nil {
+		return result, err
+	}
+
+	book, err := p.br.GetBookByKey(ctx, domain.GeBookByKeyReq{
+		Key:     req.BookKey,
+		Subject: req.Subject,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	position, err := p.br.JoinWaitlist(ctx, domain.JoinWaitlistReq{
+		Book:       book,
+		Subject:    req.Subject,
+		Branch:     req.Branch,
+		PickUpDate: req.PickUpDate,
+		UserID:     req.UserID,
+	})
+	if err != nil {
+		return result, wrapDomainError(err)
+	}
+
+	result = JoinWaitlistRes{
+		BookKey:       book.Key,
+		Branch:        req.Branch,
+		PickUpDate:    req.PickUpDate,
+		UserID:        req.UserID,
+		QueuePosition: position,
+	}
+
+	return result, nil
+}
+
+func (p bookService) CancelReservation(ctx context.Context, req CancelReservationReq) error {
+	if req.ID == 0 || req.UserID == 0 {
+		return invalidRequest("Reservation ID and user ID are required")
+	}
+
+	_, err := p.br.CancelReservation(ctx, domain.CancelReservationReq{
+		ID:     req.ID,
+		UserID: req.UserID,
+	})
+	if err != nil {
+		return wrapDomainError(err)
+	}
+	return nil
+}
+
+func (p bookService) ConfirmReservation(ctx context.Context, req ConfirmReservationReq) error {
+	if req.ID == 0 || req.UserID == 0 {
+		return invalidRequest("Reservation ID and user ID are required")
+	}
+
+	return wrapDomainError(p.br.ConfirmReservation(ctx, domain.ConfirmReservationReq{
+		ID:     req.ID,
+		UserID: req.UserID,
+	}))
+}
+
+// checkActiveUser makes sure the user borrowing a book is registered and not deactivated.
+func (p bookService) checkActiveUser(ctx context.Context, userID int) error {
+	if p.ur == nil {
+		return nil
+	}
+
+	user, err := p.ur.GetUser(ctx, domain.GetUserReq{
+		ID: userID,
+	})
+	if err != nil {
+		return wrapDomainError(err)
+	}
+	if !user.Active {
+		return wrapDomainError(domain.ErrUserInactive)
+	}
+	return nil
+}
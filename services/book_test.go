@@ -5,6 +5,7 @@ import (
 	"errors"
 	reflect "reflect"
 	"testing"
+	"time"
 
 	"gihub.com/gadhittana01/book-project/pkg/domain"
 	gomock "github.com/golang/mock/gomock"
@@ -90,6 +91,7 @@ func Test_GetListOfBooks(t *testing.T) {
 							LendingIdentifier: "456",
 						},
 					},
+					Provider: "openlibrary",
 				}, nil)
 
 				return bookService{
@@ -110,6 +112,7 @@ func Test_GetListOfBooks(t *testing.T) {
 						LendingIdentifier: "456",
 					},
 				},
+				Provider: "openlibrary",
 			},
 			wantErr: false,
 		},
@@ -170,6 +173,7 @@ func Test_BorrowBook(t *testing.T) {
 				ctx: context.Background(),
 				req: BorrowBookReq{
 					BookKey:    "123",
+					Branch:     "central",
 					PickUpDate: "2022-01-01",
 					Subject:    "love",
 					UserID:     1,
@@ -190,6 +194,7 @@ func Test_BorrowBook(t *testing.T) {
 						},
 					},
 					LendingIdentifier: "456",
+					Provider:          "openlibrary",
 				}, nil)
 
 				bookMock.EXPECT().BorrowBook(gomock.Any(), domain.BorrowBookReq{
@@ -203,16 +208,20 @@ func Test_BorrowBook(t *testing.T) {
 							},
 						},
 						LendingIdentifier: "456",
+						Provider:          "openlibrary",
 					},
+					Subject:    "love",
+					Branch:     "central",
 					PickUpDate: "2022-01-01",
 					UserID:     1,
-				}).Return(nil)
+				}).Return(domain.BorrowBookReq{ID: 7, PickUpSlot: "09:30"}, nil)
 
 				return bookService{
 					br: bookMock,
 				}
 			},
 			want: BorrowBookRes{
+				ID: 7,
 				Book: Book{
 					Key:          "123",
 					Title:        "hello",
@@ -224,17 +233,40 @@ func Test_BorrowBook(t *testing.T) {
 					},
 					LendingIdentifier: "456",
 				},
+				Branch:     "central",
 				PickUpDate: "2022-01-01",
+				PickUpSlot: "09:30",
 				UserID:     1,
+				Provider:   "openlibrary",
 			},
 			wantErr: false,
 		},
+		{
+			name: "empty branch",
+			args: args{
+				ctx: context.Background(),
+				req: BorrowBookReq{
+					BookKey:    "123",
+					PickUpDate: "2022-01-01",
+					Subject:    "love",
+					UserID:     1,
+				},
+			},
+			fields: func() bookService {
+				return bookService{
+					br: NewMockBookResource(ctrl),
+				}
+			},
+			want:    BorrowBookRes{},
+			wantErr: true,
+		},
 		{
 			name: "book not found",
 			args: args{
 				ctx: context.Background(),
 				req: BorrowBookReq{
 					BookKey:    "123",
+					Branch:     "central",
 					PickUpDate: "2022-01-01",
 					Subject:    "love",
 					UserID:     1,
@@ -260,6 +292,7 @@ func Test_BorrowBook(t *testing.T) {
 				ctx: context.Background(),
 				req: BorrowBookReq{
 					BookKey:    "123",
+					Branch:     "central",
 					PickUpDate: "2022-01-01",
 					Subject:    "love",
 					UserID:     1,
@@ -294,9 +327,11 @@ func Test_BorrowBook(t *testing.T) {
 						},
 						LendingIdentifier: "456",
 					},
+					Subject:    "love",
+					Branch:     "central",
 					PickUpDate: "2022-01-01",
 					UserID:     1,
-				}).Return(errors.New("error"))
+				}).Return(domain.BorrowBookReq{}, errors.New("error"))
 
 				return bookService{
 					br: bookMock,
@@ -489,3 +524,378 @@ func Test_GetBookReservation(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetCatalogHealth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	checkedAt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		fields  func() bookService
+		args    args
+		want    GetCatalogHealthRes
+		wantErr bool
+	}{
+		{
+			name: "ready when one provider is healthy",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().GetCatalogHealth(gomock.Any()).Return([]domain.ProviderHealth{
+					{
+						Name:                "openlibrary",
+						Healthy:             false,
+						ConsecutiveFailures: 3,
+						LastError:           "timeout",
+						LastCheckedAt:       checkedAt,
+					},
+					{
+						Name:          "googlebooks",
+						Healthy:       true,
+						LastCheckedAt: checkedAt,
+					},
+				}, nil)
+				return bookService{
+					br: bookMock,
+				}
+			},
+			want: GetCatalogHealthRes{
+				Ready: true,
+				Providers: []ProviderHealth{
+					{
+						Name:                "openlibrary",
+						Healthy:             false,
+						ConsecutiveFailures: 3,
+						LastError:           "timeout",
+						LastCheckedAt:       checkedAt,
+					},
+					{
+						Name:          "googlebooks",
+						Healthy:       true,
+						LastCheckedAt: checkedAt,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "not ready when every provider is unhealthy",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().GetCatalogHealth(gomock.Any()).Return([]domain.ProviderHealth{
+					{
+						Name:                "openlibrary",
+						ConsecutiveFailures: 1,
+						LastError:           "error external call",
+						LastCheckedAt:       checkedAt,
+					},
+				}, nil)
+				return bookService{
+					br: bookMock,
+				}
+			},
+			want: GetCatalogHealthRes{
+				Ready: false,
+				Providers: []ProviderHealth{
+					{
+						Name:                "openlibrary",
+						ConsecutiveFailures: 1,
+						LastError:           "error external call",
+						LastCheckedAt:       checkedAt,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error get catalog health",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().GetCatalogHealth(gomock.Any()).Return(nil, errors.New("error"))
+				return bookService{
+					br: bookMock,
+				}
+			},
+			want:    GetCatalogHealthRes{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.fields()
+			got, err := m.GetCatalogHealth(tt.args.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetCatalogHealth() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetCatalogHealth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_JoinWaitlist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	type args struct {
+		ctx context.Context
+		req JoinWaitlistReq
+	}
+	tests := []struct {
+		name     string
+		fields   func() bookService
+		args     args
+		want     JoinWaitlistRes
+		wantCode string
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx: context.Background(),
+				req: JoinWaitlistReq{
+					BookKey:    "123",
+					Branch:     "central",
+					PickUpDate: "2022-01-01",
+					Subject:    "love",
+					UserID:     1,
+				},
+			},
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().GetBookByKey(gomock.Any(), domain.GeBookByKeyReq{
+					Key:     "123",
+					Subject: "love",
+				}).Return(domain.Book{Key: "123", Title: "hello"}, nil)
+				bookMock.EXPECT().JoinWaitlist(gomock.Any(), domain.JoinWaitlistReq{
+					Book:       domain.Book{Key: "123", Title: "hello"},
+					Subject:    "love",
+					Branch:     "central",
+					PickUpDate: "2022-01-01",
+					UserID:     1,
+				}).Return(3, nil)
+
+				return bookService{
+					br: bookMock,
+				}
+			},
+			want: JoinWaitlistRes{
+				BookKey:       "123",
+				Branch:        "central",
+				PickUpDate:    "2022-01-01",
+				UserID:        1,
+				QueuePosition: 3,
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty user id",
+			args: args{
+				ctx: context.Background(),
+				req: JoinWaitlistReq{
+					BookKey: "123",
+					Subject: "love",
+				},
+			},
+			fields: func() bookService {
+				return bookService{
+					br: NewMockBookResource(ctrl),
+				}
+			},
+			want:     JoinWaitlistRes{},
+			wantCode: ErrCodeInvalidRequest,
+			wantErr:  true,
+		},
+		{
+			name: "book available",
+			args: args{
+				ctx: context.Background(),
+				req: JoinWaitlistReq{
+					BookKey: "123",
+					Branch:  "central",
+					Subject: "love",
+					UserID:  1,
+				},
+			},
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().GetBookByKey(gomock.Any(), gomock.Any()).Return(domain.Book{Key: "123"}, nil)
+				bookMock.EXPECT().JoinWaitlist(gomock.Any(), gomock.Any()).Return(0, domain.ErrBookAvailable)
+
+				return bookService{
+					br: bookMock,
+				}
+			},
+			want:     JoinWaitlistRes{},
+			wantCode: ErrCodeConflict,
+			wantErr:  true,
+		},
+		{
+			name: "denied by policy",
+			args: args{
+				ctx: context.Background(),
+				req: JoinWaitlistReq{
+					BookKey: "123",
+					Branch:  "central",
+					Subject: "love",
+					UserID:  1,
+				},
+			},
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().GetBookByKey(gomock.Any(), gomock.Any()).Return(domain.Book{Key: "123"}, nil)
+				bookMock.EXPECT().JoinWaitlist(gomock.Any(), gomock.Any()).Return(0, &domain.PolicyError{
+					Violations: []domain.PolicyViolation{{Rule: domain.PolicyOnePerWork, Reason: "User already has a reservation for book with key 123"}},
+				})
+
+				return bookService{
+					br: bookMock,
+				}
+			},
+			want:     JoinWaitlistRes{},
+			wantCode: ErrCodeForbidden,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.fields()
+			got, err := m.JoinWaitlist(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("JoinWaitlist() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if se := (*ServiceError)(nil); tt.wantCode != "" && (!errors.As(err, &se) || se.Code != tt.wantCode) {
+				t.Errorf("JoinWaitlist() error = %v, want code %v", err, tt.wantCode)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("JoinWaitlist() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_CancelReservation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		fields   func() bookService
+		req      CancelReservationReq
+		wantCode string
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().CancelReservation(gomock.Any(), domain.CancelReservationReq{ID: 4, UserID: 1}).Return(domain.BorrowBookReq{ID: 4}, nil)
+				return bookService{br: bookMock}
+			},
+			req:     CancelReservationReq{ID: 4, UserID: 1},
+			wantErr: false,
+		},
+		{
+			name: "missing id",
+			fields: func() bookService {
+				return bookService{br: NewMockBookResource(ctrl)}
+			},
+			req:      CancelReservationReq{UserID: 1},
+			wantCode: ErrCodeInvalidRequest,
+			wantErr:  true,
+		},
+		{
+			name: "not found",
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().CancelReservation(gomock.Any(), gomock.Any()).Return(domain.BorrowBookReq{}, domain.ErrReservationNotFound)
+				return bookService{br: bookMock}
+			},
+			req:      CancelReservationReq{ID: 4, UserID: 1},
+			wantCode: ErrCodeNotFound,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.fields()
+			err := m.CancelReservation(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CancelReservation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if se := (*ServiceError)(nil); tt.wantCode != "" && (!errors.As(err, &se) || se.Code != tt.wantCode) {
+				t.Errorf("CancelReservation() error = %v, want code %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_ConfirmReservation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		fields   func() bookService
+		req      ConfirmReservationReq
+		wantCode string
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().ConfirmReservation(gomock.Any(), domain.ConfirmReservationReq{ID: 4, UserID: 1}).Return(nil)
+				return bookService{br: bookMock}
+			},
+			req:     ConfirmReservationReq{ID: 4, UserID: 1},
+			wantErr: false,
+		},
+		{
+			name: "window passed",
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().ConfirmReservation(gomock.Any(), gomock.Any()).Return(domain.ErrConfirmWindowPassed)
+				return bookService{br: bookMock}
+			},
+			req:      ConfirmReservationReq{ID: 4, UserID: 1},
+			wantCode: ErrCodeConflict,
+			wantErr:  true,
+		},
+		{
+			name: "unexpected error",
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().ConfirmReservation(gomock.Any(), gomock.Any()).Return(errors.New("error"))
+				return bookService{br: bookMock}
+			},
+			req:     ConfirmReservationReq{ID: 4, UserID: 1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.fields()
+			err := m.ConfirmReservation(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ConfirmReservation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if se := (*ServiceError)(nil); tt.wantCode != "" && (!errors.As(err, &se) || se.Code != tt.wantCode) {
+				t.Errorf("ConfirmReservation() error = %v, want code %v", err, tt.wantCode)
+			}
+		})
+	}
+}
@@ -1,7 +1,15 @@
 package services
 
+import (
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+)
+
 type BookDependencies struct {
-	BR BookResource
+	BR  BookResource
+	UR  UserResource
+	Cfg *config.GlobalConfig
 }
 
 type GetListOfBooksReq struct {
@@ -9,7 +17,8 @@ type GetListOfBooksReq struct {
 }
 
 type GetListOfBooksResp struct {
-	Books []Book `json:"books"`
+	Books    []Book `json:"books"`
+	Provider string `json:"provider"`
 }
 
 type Book struct {
@@ -24,25 +33,263 @@ type Author struct {
 	Name string `json:"name"`
 }
 
+// BorrowBookReq PickUpSlot is the HH:MM start of a pickup slot, the first free one is booked when it is empty.
 type BorrowBookReq struct {
 	BookKey    string `json:"key"`
+	Branch     string `json:"branch"`
 	PickUpDate string `json:"pickup_date"`
+	PickUpSlot string `json:"pickup_slot"`
 	Subject    string `json:"subject"`
 	UserID     int    `json:"user_id"`
 }
 
+// BorrowBookRes Provider is the catalog provider that answered the book lookup.
 type BorrowBookRes struct {
+	ID         int    `json:"id"`
 	Book       Book   `json:"book"`
+	Branch     string `json:"branch"`
 	PickUpDate string `json:"pickup_date"`
+	PickUpSlot string `json:"pickup_slot,omitempty"`
 	UserID     int    `json:"user_id"`
+	Provider   string `json:"provider,omitempty"`
 }
 
 type GetBookReservationReq struct {
-	UserID int `json:"user_id"`
+	UserID int    `json:"user_id"`
+	Branch string `json:"branch"`
 }
 
 type GetBookReservationRes struct {
+	ID            int        `json:"id,omitempty"`
+	BookKey       string     `json:"key"`
+	Branch        string     `json:"branch"`
+	PickUpDate    string     `json:"pickup_date"`
+	PickUpSlot    string     `json:"pickup_slot,omitempty"`
+	UserID        int        `json:"user_id"`
+	Status        string     `json:"status"`
+	QueuePosition int        `json:"queue_position,omitempty"`
+	ConfirmBy     *time.Time `json:"confirm_by,omitempty"`
+}
+
+type JoinWaitlistReq struct {
 	BookKey    string `json:"key"`
+	Branch     string `json:"branch"`
 	PickUpDate string `json:"pickup_date"`
+	Subject    string `json:"subject"`
 	UserID     int    `json:"user_id"`
 }
+
+type JoinWaitlistRes struct {
+	BookKey       string `json:"key"`
+	Branch        string `json:"branch"`
+	PickUpDate    string `json:"pickup_date"`
+	UserID        int    `json:"user_id"`
+	QueuePosition int    `json:"queue_position"`
+}
+
+type CancelReservationReq struct {
+	ID     int `json:"id"`
+	UserID int `json:"user_id"`
+}
+
+type ConfirmReservationReq struct {
+	ID     int `json:"id"`
+	UserID int `json:"user_id"`
+}
+
+type GetCatalogHealthRes struct {
+	Ready     bool             `json:"ready"`
+	Providers []ProviderHealth `json:"providers"`
+}
+
+type ProviderHealth struct {
+	Name                string    `json:"name"`
+	Healthy             bool      `json:"healthy"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	LastError           string    `json:"last_error,omitempty"`
+	LastCheckedAt       time.Time `json:"last_checked_at"`
+}
+
+type BranchRes struct {
+	Code           string            `json:"code"`
+	Name           string            `json:"name"`
+	Address        string            `json:"address"`
+	OpeningHours   map[string]string `json:"opening_hours"`
+	ClosedDates    []string          `json:"closed_dates"`
+	PickupCapacity int               `json:"pickup_capacity"`
+	CopiesPerWork  int               `json:"copies_per_work"`
+	SlotMinutes    int               `json:"slot_minutes"`
+	SlotCapacity   int               `json:"slot_capacity"`
+}
+
+type GetBookAvailabilityReq struct {
+	BookKey string `json:"key"`
+}
+
+// BranchAvailabilityRes Copies is 0 when the branch has no copy limit.
+type BranchAvailabilityRes struct {
+	Branch    string `json:"branch"`
+	Copies    int    `json:"copies"`
+	Reserved  int    `json:"reserved"`
+	Available int    `json:"available"`
+	Waiting   int    `json:"waiting"`
+}
+
+type GetPickupSlotsReq struct {
+	Branch string `json:"branch"`
+	Date   string `json:"date"`
+}
+
+// PickupSlotRes Capacity is 0 and Available is -1 when the slot has no pickup limit.
+type PickupSlotRes struct {
+	Start     string `json:"start"`
+	End       string `json:"end"`
+	Capacity  int    `json:"capacity"`
+	Booked    int    `json:"booked"`
+	Available int    `json:"available"`
+}
+
+type CheckoutBookReq struct {
+	ReservationID int `json:"reservation_id"`
+}
+
+type ReturnBookReq struct {
+	LoanID int `json:"loan_id"`
+}
+
+type RenewLoanReq struct {
+	LoanID int `json:"loan_id"`
+}
+
+type GetLoansReq struct {
+	UserID int `json:"user_id"`
+}
+
+type LoanRes struct {
+	ID            int        `json:"id"`
+	ReservationID int        `json:"reservation_id"`
+	BookKey       string     `json:"key"`
+	Title         string     `json:"title"`
+	Branch        string     `json:"branch"`
+	UserID        int        `json:"user_id"`
+	CheckedOutAt  time.Time  `json:"checked_out_at"`
+	DueDate       time.Time  `json:"due_date"`
+	ReturnedAt    *time.Time `json:"returned_at,omitempty"`
+	Renewals      int        `json:"renewals"`
+	Status        string     `json:"status"`
+}
+
+type GetFinesReq struct {
+	UserID int `json:"user_id"`
+}
+
+type SettleFinesReq struct {
+	UserID   int    `json:"user_id"`
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+type WaiveFineReq struct {
+	FineID int    `json:"fine_id"`
+	Amount int64  `json:"amount"`
+	Note   string `json:"note"`
+}
+
+// FineLedgerRes amounts are in minor units of Currency.
+type FineLedgerRes struct {
+	UserID   int            `json:"user_id"`
+	Currency string         `json:"currency"`
+	Balance  int64          `json:"balance"`
+	Accruing int64          `json:"accruing"`
+	Entries  []FineEntryRes `json:"entries"`
+}
+
+type FineEntryRes struct {
+	ID        int       `json:"id"`
+	LoanID    int       `json:"loan_id,omitempty"`
+	FineID    int       `json:"fine_id,omitempty"`
+	Type      string    `json:"type"`
+	Amount    int64     `json:"amount"`
+	Currency  string    `json:"currency"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GetReservationCalendarReq struct {
+	UserID int `json:"user_id"`
+}
+
+type ReservationCalendarRes struct {
+	UserID int                `json:"user_id"`
+	Events []CalendarEventRes `json:"events"`
+}
+
+// CalendarEventRes is one pickup. Start and End are local times at the branch, for a pickup without a slot AllDay
+// is set and only the date of Start counts.
+type CalendarEventRes struct {
+	UID          string    `json:"uid"`
+	Sequence     int       `json:"sequence"`
+	Status       string    `json:"status"`
+	Summary      string    `json:"summary"`
+	Description  string    `json:"description"`
+	Location     string    `json:"location"`
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+	AllDay       bool      `json:"all_day"`
+	AlarmMinutes int       `json:"alarm_minutes"`
+	Stamp        time.Time `json:"stamp"`
+}
+
+// ExportBookReservationReq filters the export like GetReservationPageReq of the domain, From and To are YYYY-MM-DD.
+type ExportBookReservationReq struct {
+	UserID   int    `json:"user_id"`
+	Branch   string `json:"branch"`
+	Status   string `json:"status"`
+	Key      string `json:"key"`
+	Query    string `json:"q"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	PageSize int    `json:"page_size"`
+}
+
+// ReservationExportRow is one reservation flattened for reports, Author joins the author names with "; ".
+type ReservationExportRow struct {
+	ID         int       `json:"id"`
+	Title      string    `json:"title"`
+	Author     string    `json:"author"`
+	BookKey    string    `json:"key"`
+	Branch     string    `json:"branch"`
+	PickUpDate string    `json:"pickup_date"`
+	PickUpSlot string    `json:"pickup_slot"`
+	UserID     int       `json:"user_id"`
+	Status     string    `json:"status"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// BorrowBooksReq with Atomic stores every item or none of them, otherwise each item succeeds or fails on its own.
+type BorrowBooksReq struct {
+	Items  []BorrowBookReq `json:"items"`
+	Atomic bool            `json:"atomic"`
+}
+
+type BorrowBooksRes struct {
+	Atomic  bool                `json:"atomic"`
+	Created int                 `json:"created"`
+	Failed  int                 `json:"failed"`
+	Items   []BorrowBookItemRes `json:"items"`
+}
+
+// BorrowBookItemRes Status is created, failed, or skipped for a valid item of an atomic batch that was not stored
+// because another item failed.
+type BorrowBookItemRes struct {
+	Index       int            `json:"index"`
+	Status      string         `json:"status"`
+	Reservation *BorrowBookRes `json:"reservation,omitempty"`
+	Error       *ItemErrorRes  `json:"error,omitempty"`
+}
+
+type ItemErrorRes struct {
+	Code    string        `json:"code"`
+	Message string        `json:"message"`
+	Details []ErrorDetail `json:"details,omitempty"`
+}
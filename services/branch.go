@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+func (p bookService) GetBranches(ctx context.Context) ([]BranchRes, error) {
+	res, err := p.br.GetBranches(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []BranchRes{}
+	for _, item := range res {
+		result = append(result, BranchRes{
+			Code:           item.Code,
+			Name:           item.Name,
+			Address:        item.Address,
+			OpeningHours:   item.OpeningHours,
+			ClosedDates:    item.ClosedDates,
+			PickupCapacity: item.PickupCapacity,
+			CopiesPerWork:  item.CopiesPerWork,
+			SlotMinutes:    item.SlotMinutes,
+			SlotCapacity:   item.SlotCapacity,
+		})
+	}
+	return result, nil
+}
+
+func (p bookService) GetBookAvailability(ctx context.Context, req GetBookAvailabilityReq) ([]BranchAvailabilityRes, error) {
+	if req.BookKey == "" {
+		return nil, invalidRequest("Book key is empty")
+	}
+
+	res, err := p.br.GetAvailability(ctx, domain.GetAvailabilityReq{
+		Key: req.BookKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := []BranchAvailabilityRes{}
+	for _, item := range res {
+		result = append(result, BranchAvailabilityRes{
+			Branch:    item.Branch,
+			Copies:    item.Copies,
+			Reserved:  item.Reserved,
+			Available: item.Available,
+			Waiting:   item.Waiting,
+		})
+	}
+	return result, nil
+}
+
+func (p bookService) GetPickupSlots(ctx context.Context, req GetPickupSlotsReq) ([]PickupSlotRes, error) {
+	if req.Branch == "" || req.Date == "" {
+		return nil, invalidRequest("Branch and date are required")
+	}
+
+	res, err := p.br.GetPickupSlots(ctx, domain.GetPickupSlotsReq{
+		Branch: req.Branch,
+		Date:   req.Date,
+	})
+	if err != nil {
+		return nil, wrapDomainError(err)
+	}
+
+	result := []PickupSlotRes{}
+	for _, item := range res {
+		result = append(result, PickupSlotRes{
+			Start:     item.Start,
+			End:       item.End,
+			Capacity:  item.Capacity,
+			Booked:    item.Booked,
+			Available: item.Available,
+		})
+	}
+	return result, nil
+}
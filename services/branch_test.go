@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"errors"
+	reflect "reflect"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+func Test_GetBranches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	bookMock := NewMockBookResource(ctrl)
+	bookMock.EXPECT().GetBranches(gomock.Any()).Return([]domain.Branch{
+		{Code: "central", Name: "Central Library", OpeningHours: map[string]string{"monday": "09:00-17:00"}, PickupCapacity: 40},
+	}, nil)
+
+	got, err := bookService{br: bookMock}.GetBranches(context.Background())
+	want := []BranchRes{
+		{Code: "central", Name: "Central Library", OpeningHours: map[string]string{"monday": "09:00-17:00"}, PickupCapacity: 40},
+	}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("GetBranches() = %v, %v, want %v", got, err, want)
+	}
+}
+
+func Test_GetBookAvailability(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		fields   func() bookService
+		req      GetBookAvailabilityReq
+		want     []BranchAvailabilityRes
+		wantCode string
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().GetAvailability(gomock.Any(), domain.GetAvailabilityReq{Key: "123"}).Return([]domain.BranchAvailability{
+					{Branch: "central", Copies: 2, Reserved: 1, Available: 1},
+					{Branch: "north", Copies: 1, Reserved: 1, Waiting: 2},
+				}, nil)
+				return bookService{br: bookMock}
+			},
+			req: GetBookAvailabilityReq{BookKey: "123"},
+			want: []BranchAvailabilityRes{
+				{Branch: "central", Copies: 2, Reserved: 1, Available: 1},
+				{Branch: "north", Copies: 1, Reserved: 1, Waiting: 2},
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing key",
+			fields: func() bookService {
+				return bookService{br: NewMockBookResource(ctrl)}
+			},
+			req:      GetBookAvailabilityReq{},
+			wantCode: ErrCodeInvalidRequest,
+			wantErr:  true,
+		},
+		{
+			name: "error",
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().GetAvailability(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+				return bookService{br: bookMock}
+			},
+			req:     GetBookAvailabilityReq{BookKey: "123"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.fields()
+			got, err := m.GetBookAvailability(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetBookAvailability() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if se := (*ServiceError)(nil); tt.wantCode != "" && (!errors.As(err, &se) || se.Code != tt.wantCode) {
+				t.Errorf("GetBookAvailability() error = %v, want code %v", err, tt.wantCode)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetBookAvailability() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_GetPickupSlots(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		fields   func() bookService
+		req      GetPickupSlotsReq
+		want     []PickupSlotRes
+		wantCode string
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().GetPickupSlots(gomock.Any(), domain.GetPickupSlotsReq{Branch: "central", Date: "2022-01-24"}).Return([]domain.PickupSlot{
+					{Start: "09:00", End: "09:30", Capacity: 4, Booked: 4},
+					{Start: "09:30", End: "10:00", Capacity: 4, Booked: 1, Available: 3},
+				}, nil)
+				return bookService{br: bookMock}
+			},
+			req: GetPickupSlotsReq{Branch: "central", Date: "2022-01-24"},
+			want: []PickupSlotRes{
+				{Start: "09:00", End: "09:30", Capacity: 4, Booked: 4},
+				{Start: "09:30", End: "10:00", Capacity: 4, Booked: 1, Available: 3},
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing date",
+			fields: func() bookService {
+				return bookService{br: NewMockBookResource(ctrl)}
+			},
+			req:      GetPickupSlotsReq{Branch: "central"},
+			wantCode: ErrCodeInvalidRequest,
+			wantErr:  true,
+		},
+		{
+			name: "branch closed",
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().GetPickupSlots(gomock.Any(), gomock.Any()).Return(nil, domain.ErrBranchClosed)
+				return bookService{br: bookMock}
+			},
+			req:      GetPickupSlotsReq{Branch: "central", Date: "2022-01-23"},
+			wantCode: ErrCodeConflict,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.fields()
+			got, err := m.GetPickupSlots(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetPickupSlots() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if se := (*ServiceError)(nil); tt.wantCode != "" && (!errors.As(err, &se) || se.Code != tt.wantCode) {
+				t.Errorf("GetPickupSlots() error = %v, want code %v", err, tt.wantCode)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetPickupSlots() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
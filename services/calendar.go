@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+const (
+	// CalendarStatus values follow the STATUS property of RFC 5545 events.
+	CalendarStatusTentative = "TENTATIVE"
+	CalendarStatusConfirmed = "CONFIRMED"
+	CalendarStatusCancelled = "CANCELLED"
+
+	defaultAlarmMinutes = 60
+	defaultSlotMinutes  = 30
+	calendarUIDDomain   = "book-project"
+)
+
+// calendarSequence orders the states a reservation moves through, so the event of a reservation that was confirmed,
+// cancelled or expired since the calendar was last fetched replaces the older copy. A pickup does not change the
+// event, so picked up and returned reservations keep the sequence of the confirmed one.
+var calendarSequence = map[string]int{
+	domain.ReservationStatusProvisional: 0,
+	domain.ReservationStatusActive:      1,
+	domain.ReservationStatusPickedUp:    1,
+	domain.ReservationStatusReturned:    1,
+	domain.ReservationStatusCancelled:   2,
+	domain.ReservationStatusExpired:     2,
+}
+
+var calendarStatus = map[string]string{
+	domain.ReservationStatusProvisional: CalendarStatusTentative,
+	domain.ReservationStatusActive:      CalendarStatusConfirmed,
+	domain.ReservationStatusPickedUp:    CalendarStatusConfirmed,
+	domain.ReservationStatusReturned:    CalendarStatusConfirmed,
+	domain.ReservationStatusCancelled:   CalendarStatusCancelled,
+	domain.ReservationStatusExpired:     CalendarStatusCancelled,
+}
+
+// GetReservationCalendar lists the pickups of a user as calendar events. Cancelled and expired reservations stay in
+// the list as cancelled events so calendars that imported them drop them too, collected ones stay confirmed.
+func (p bookService) GetReservationCalendar(ctx context.Context, req GetReservationCalendarReq) (ReservationCalendarRes, error) {
+	if req.UserID <= 0 {
+		return ReservationCalendarRes{}, invalidRequest("User ID is required")
+	}
+	if p.ur != nil {
+		if _, err := p.ur.GetUser(ctx, domain.GetUserReq{ID: req.UserID}); err != nil {
+			return ReservationCalendarRes{}, wrapDomainError(err)
+		}
+	}
+
+	branches, err := p.br.GetBranches(ctx)
+	if err != nil {
+		return ReservationCalendarRes{}, err
+	}
+	branchByCode := map[string]domain.Branch{}
+	for _, item := range branches {
+		branchByCode[item.Code] = item
+	}
+
+	res, err := p.br.GetBookReservation(ctx, domain.GetBookReservationReq{
+		UserID: req.UserID,
+	})
+	if err != nil {
+		return ReservationCalendarRes{}, err
+	}
+
+	alarmMinutes := p.calendar.AlarmMinutes
+	if alarmMinutes <= 0 {
+		alarmMinutes = defaultAlarmMinutes
+	}
+
+	result := ReservationCalendarRes{
+		UserID: req.UserID,
+		Events: []CalendarEventRes{},
+	}
+	for _, item := range res[req.UserID] {
+		status, ok := calendarStatus[item.Status]
+		if !ok || item.ID == 0 {
+			continue
+		}
+		event, err := newCalendarEvent(item, branchByCode[item.Branch])
+		if err != nil {
+			// A reservation with an unreadable pickup date cannot be placed in a calendar.
+			continue
+		}
+		event.Status = status
+		event.Sequence = calendarSequence[item.Status]
+		event.AlarmMinutes = alarmMinutes
+		result.Events = append(result.Events, event)
+	}
+	sort.Slice(result.Events, func(i, j int) bool {
+		return result.Events[i].Start.Before(result.Events[j].Start)
+	})
+	return result, nil
+}
+
+func newCalendarEvent(item domain.BorrowBookReq, branch domain.Branch) (CalendarEventRes, error) {
+	title := item.Book.Title
+	if title == "" {
+		title = item.Book.Key
+	}
+	branchName := branch.Name
+	if branchName == "" {
+		branchName = item.Branch
+	}
+	location := branchName
+	if branch.Address != "" {
+		location = branchName + ", " + branch.Address
+	}
+
+	event := CalendarEventRes{
+		UID:         fmt.Sprintf("reservation-%d@%s", item.ID, calendarUIDDomain),
+		Summary:     "Pick up " + title,
+		Description: fmt.Sprintf("Pick up %s at %s. Reservation #%d", title, branchName, item.ID),
+		Location:    location,
+		Stamp:       item.CreatedAt.UTC(),
+	}
+
+	if item.PickUpSlot == "" {
+		day, err := time.Parse(pickUpDateLayout, item.PickUpDate)
+		if err != nil {
+			return CalendarEventRes{}, err
+		}
+		event.AllDay = true
+		event.Start = day
+		event.End = day.AddDate(0, 0, 1)
+		return event, nil
+	}
+
+	start, err := time.Parse(pickUpDateLayout+" 15:04", item.PickUpDate+" "+item.PickUpSlot)
+	if err != nil {
+		return CalendarEventRes{}, err
+	}
+	slotMinutes := branch.SlotMinutes
+	if slotMinutes <= 0 {
+		slotMinutes = defaultSlotMinutes
+	}
+	event.Start = start
+	event.End = start.Add(time.Duration(slotMinutes) * time.Minute)
+	return event, nil
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"errors"
+	reflect "reflect"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+func Test_GetReservationCalendar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	createdAt := time.Date(2022, 1, 20, 8, 0, 0, 0, time.UTC)
+	branches := []domain.Branch{{Code: "central", Name: "Central Library", Address: "1 Main St", SlotMinutes: 15}}
+
+	tests := []struct {
+		name     string
+		req      GetReservationCalendarReq
+		mock     func() bookService
+		want     ReservationCalendarRes
+		wantCode string
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			req:  GetReservationCalendarReq{UserID: 1},
+			mock: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().GetBranches(gomock.Any()).Return(branches, nil)
+				bookMock.EXPECT().GetBookReservation(gomock.Any(), domain.GetBookReservationReq{UserID: 1}).Return(map[int][]domain.BorrowBookReq{
+					1: {
+						{ID: 7, Book: domain.Book{Key: "456", Title: "Dune"}, Branch: "north", PickUpDate: "2022-01-26", UserID: 1, Status: domain.ReservationStatusCancelled, CreatedAt: createdAt},
+						{ID: 4, Book: domain.Book{Key: "123", Title: "Matilda"}, Branch: "central", PickUpDate: "2022-01-24", PickUpSlot: "09:30", UserID: 1, Status: domain.ReservationStatusActive, CreatedAt: createdAt},
+						{ID: 5, Book: domain.Book{Key: "789"}, Branch: "central", PickUpDate: "2022-01-25", UserID: 1, Status: domain.ReservationStatusPickedUp, CreatedAt: createdAt},
+						{ID: 8, Book: domain.Book{Key: "321", Title: "Emma"}, Branch: "central", PickUpDate: "2022-01-21", UserID: 1, Status: domain.ReservationStatusExpired, CreatedAt: createdAt},
+						{ID: 6, Book: domain.Book{Key: "654", Title: "Heidi"}, Branch: "central", PickUpDate: "2022-01-22", UserID: 1, Status: domain.ReservationStatusReturned, CreatedAt: createdAt},
+						{Book: domain.Book{Key: "999"}, Branch: "central", PickUpDate: "2022-01-25", UserID: 1, Status: domain.ReservationStatusWaiting},
+					},
+				}, nil)
+				userMock := NewMockUserResource(ctrl)
+				userMock.EXPECT().GetUser(gomock.Any(), domain.GetUserReq{ID: 1}).Return(domain.User{ID: 1, Active: true}, nil)
+				return bookService{br: bookMock, ur: userMock, calendar: config.Calendar{AlarmMinutes: 30}}
+			},
+			want: ReservationCalendarRes{
+				UserID: 1,
+				Events: []CalendarEventRes{
+					{
+						UID:          "reservation-8@book-project",
+						Sequence:     2,
+						Status:       CalendarStatusCancelled,
+						Summary:      "Pick up Emma",
+						Description:  "Pick up Emma at Central Library. Reservation #8",
+						Location:     "Central Library, 1 Main St",
+						Start:        time.Date(2022, 1, 21, 0, 0, 0, 0, time.UTC),
+						End:          time.Date(2022, 1, 22, 0, 0, 0, 0, time.UTC),
+						AllDay:       true,
+						AlarmMinutes: 30,
+						Stamp:        createdAt,
+					},
+					{
+						UID:          "reservation-6@book-project",
+						Sequence:     1,
+						Status:       CalendarStatusConfirmed,
+						Summary:      "Pick up Heidi",
+						Description:  "Pick up Heidi at Central Library. Reservation #6",
+						Location:     "Central Library, 1 Main St",
+						Start:        time.Date(2022, 1, 22, 0, 0, 0, 0, time.UTC),
+						End:          time.Date(2022, 1, 23, 0, 0, 0, 0, time.UTC),
+						AllDay:       true,
+						AlarmMinutes: 30,
+						Stamp:        createdAt,
+					},
+					{
+						UID:          "reservation-4@book-project",
+						Sequence:     1,
+						Status:       CalendarStatusConfirmed,
+						Summary:      "Pick up Matilda",
+						Description:  "Pick up Matilda at Central Library. Reservation #4",
+						Location:     "Central Library, 1 Main St",
+						Start:        time.Date(2022, 1, 24, 9, 30, 0, 0, time.UTC),
+						End:          time.Date(2022, 1, 24, 9, 45, 0, 0, time.UTC),
+						AlarmMinutes: 30,
+						Stamp:        createdAt,
+					},
+					{
+						UID:          "reservation-5@book-project",
+						Sequence:     1,
+						Status:       CalendarStatusConfirmed,
+						Summary:      "Pick up 789",
+						Description:  "Pick up 789 at Central Library. Reservation #5",
+						Location:     "Central Library, 1 Main St",
+						Start:        time.Date(2022, 1, 25, 0, 0, 0, 0, time.UTC),
+						End:          time.Date(2022, 1, 26, 0, 0, 0, 0, time.UTC),
+						AllDay:       true,
+						AlarmMinutes: 30,
+						Stamp:        createdAt,
+					},
+					{
+						UID:          "reservation-7@book-project",
+						Sequence:     2,
+						Status:       CalendarStatusCancelled,
+						Summary:      "Pick up Dune",
+						Description:  "Pick up Dune at north. Reservation #7",
+						Location:     "north",
+						Start:        time.Date(2022, 1, 26, 0, 0, 0, 0, time.UTC),
+						End:          time.Date(2022, 1, 27, 0, 0, 0, 0, time.UTC),
+						AllDay:       true,
+						AlarmMinutes: 30,
+						Stamp:        createdAt,
+					},
+				},
+			},
+		},
+		{
+			name: "unknown user",
+			req:  GetReservationCalendarReq{UserID: 2},
+			mock: func() bookService {
+				userMock := NewMockUserResource(ctrl)
+				userMock.EXPECT().GetUser(gomock.Any(), domain.GetUserReq{ID: 2}).Return(domain.User{}, domain.ErrUserNotFound)
+				return bookService{br: NewMockBookResource(ctrl), ur: userMock}
+			},
+			wantCode: ErrCodeNotFound,
+			wantErr:  true,
+		},
+		{
+			name: "missing user id",
+			req:  GetReservationCalendarReq{},
+			mock: func() bookService {
+				return bookService{br: NewMockBookResource(ctrl)}
+			},
+			wantCode: ErrCodeInvalidRequest,
+			wantErr:  true,
+		},
+		{
+			name: "branches error",
+			req:  GetReservationCalendarReq{UserID: 1},
+			mock: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().GetBranches(gomock.Any()).Return(nil, errors.New("error"))
+				return bookService{br: bookMock}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.mock().GetReservationCalendar(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetReservationCalendar() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if se := (*ServiceError)(nil); tt.wantCode != "" && (!errors.As(err, &se) || se.Code != tt.wantCode) {
+				t.Errorf("GetReservationCalendar() error = %v, wantCode %v", err, tt.wantCode)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetReservationCalendar() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -9,8 +9,78 @@ import (
 type (
 	BookResource interface {
 		GetListOfBooks(ctx context.Context, req domain.GetListOfBooksReq) (domain.GetListOfBooksResp, error)
-		BorrowBook(ctx context.Context, req domain.BorrowBookReq) error
+		BorrowBook(ctx context.Context, req domain.BorrowBookReq) (domain.BorrowBookReq, error)
 		GetBookByKey(ctx context.Context, req domain.GeBookByKeyReq) (domain.Book, error)
 		GetBookReservation(ctx context.Context, req domain.GetBookReservationReq) (map[int][]domain.BorrowBookReq, error)
+		GetReservationPage(ctx context.Context, req domain.GetReservationPageReq) ([]domain.BorrowBookReq, error)
+		BorrowBooks(ctx context.Context, req []domain.BorrowBookReq) ([]domain.BorrowBookReq, error)
+		GetCatalogHealth(ctx context.Context) ([]domain.ProviderHealth, error)
+		JoinWaitlist(ctx context.Context, req domain.JoinWaitlistReq) (int, error)
+		CancelReservation(ctx context.Context, req domain.CancelReservationReq) (domain.BorrowBookReq, error)
+		ConfirmReservation(ctx context.Context, req domain.ConfirmReservationReq) error
+		CheckoutBook(ctx context.Context, req domain.CheckoutBookReq) (domain.Loan, error)
+		ReturnBook(ctx context.Context, req domain.ReturnBookReq) (domain.Loan, error)
+		RenewLoan(ctx context.Context, req domain.RenewLoanReq) (domain.Loan, error)
+		GetLoans(ctx context.Context, req domain.GetLoansReq) (map[int][]domain.Loan, error)
+		GetFines(ctx context.Context, req domain.GetFinesReq) (domain.FineLedger, error)
+		SettleFines(ctx context.Context, req domain.SettleFinesReq) (domain.FineLedger, error)
+		WaiveFine(ctx context.Context, req domain.WaiveFineReq) (domain.FineLedger, error)
+		GetBranches(ctx context.Context) ([]domain.Branch, error)
+		GetAvailability(ctx context.Context, req domain.GetAvailabilityReq) ([]domain.BranchAvailability, error)
+		GetPickupSlots(ctx context.Context, req domain.GetPickupSlotsReq) ([]domain.PickupSlot, error)
+		AcquireLease(ctx context.Context, req domain.AcquireLeaseReq) (bool, error)
+		ExpireUncollected(ctx context.Context, req domain.ExpireUncollectedReq) ([]domain.BorrowBookReq, error)
+		ClaimReminders(ctx context.Context, req domain.ClaimRemindersReq) ([]domain.BorrowBookReq, error)
+		ReleaseReminder(ctx context.Context, req domain.ReleaseReminderReq) error
+		ClaimOutbox(ctx context.Context, req domain.ClaimOutboxReq) ([]domain.OutboxEvent, error)
+		AckOutbox(ctx context.Context, req domain.AckOutboxReq) error
+		GetOutbox(ctx context.Context, req domain.GetOutboxReq) ([]domain.OutboxEvent, error)
+		RequeueOutbox(ctx context.Context, req domain.RequeueOutboxReq) (domain.OutboxEvent, error)
+	}
+
+	UserResource interface {
+		RegisterUser(ctx context.Context, req domain.RegisterUserReq) (domain.User, error)
+		GetUser(ctx context.Context, req domain.GetUserReq) (domain.User, error)
+		UpdateUserProfile(ctx context.Context, req domain.UpdateUserProfileReq) (domain.User, error)
+		DeactivateUser(ctx context.Context, req domain.DeactivateUserReq) (domain.User, error)
+		SetNotifyOptOut(ctx context.Context, req domain.SetNotifyOptOutReq) (domain.User, error)
+	}
+
+	WebhookResource interface {
+		CreateWebhook(ctx context.Context, req domain.CreateWebhookReq) (domain.WebhookSubscription, error)
+		GetWebhooks(ctx context.Context) ([]domain.WebhookSubscription, error)
+		GetWebhook(ctx context.Context, req domain.GetWebhookReq) (domain.WebhookSubscription, error)
+		UpdateWebhook(ctx context.Context, req domain.UpdateWebhookReq) (domain.WebhookSubscription, error)
+		DeleteWebhook(ctx context.Context, req domain.DeleteWebhookReq) error
+		EnqueueWebhook(ctx context.Context, req domain.EnqueueWebhookReq) ([]domain.WebhookDelivery, error)
+		ClaimWebhookDeliveries(ctx context.Context, req domain.ClaimWebhookDeliveriesReq) ([]domain.WebhookDelivery, error)
+		RecordWebhookAttempt(ctx context.Context, req domain.RecordWebhookAttemptReq) (domain.WebhookDelivery, error)
+		GetWebhookDeliveries(ctx context.Context, req domain.GetWebhookDeliveriesReq) ([]domain.WebhookDelivery, error)
+		ReplayWebhookDelivery(ctx context.Context, req domain.ReplayWebhookDeliveryReq) (domain.WebhookDelivery, error)
+		SendWebhook(ctx context.Context, req domain.SendWebhookReq) (int, error)
+	}
+
+	IdempotencyResource interface {
+		BeginIdempotency(ctx context.Context, req domain.BeginIdempotencyReq) (domain.IdempotencyRecord, bool, error)
+		CompleteIdempotency(ctx context.Context, req domain.CompleteIdempotencyReq) error
+		ReleaseIdempotency(ctx context.Context, req domain.ReleaseIdempotencyReq) error
+	}
+
+	MigrationResource interface {
+		GetMigrations(ctx context.Context) ([]domain.Migration, error)
+		GetAppliedMigrations(ctx context.Context) ([]domain.AppliedMigration, error)
+		AcquireMigrationLock(ctx context.Context, req domain.AcquireMigrationLockReq) (bool, error)
+		ReleaseMigrationLock(ctx context.Context, req domain.ReleaseMigrationLockReq) error
+		ApplyMigration(ctx context.Context, req domain.Migration) (domain.AppliedMigration, error)
+	}
+
+	Notifier interface {
+		Notify(ctx context.Context, msg domain.Notification) error
+	}
+
+	// EventHandler reacts to reservation events from the outbox. Events are delivered at least once, so a
+	// handler may see the same event again after a failure.
+	EventHandler interface {
+		HandleEvent(ctx context.Context, event domain.OutboxEvent) error
 	}
 )
@@ -35,20 +35,169 @@ func (m *MockBookResource) EXPECT() *MockBookResourceMockRecorder {
 	return m.recorder
 }
 
-// BorrowBook mocks base method.
-func (m *MockBookResource) BorrowBook(ctx context.Context, req domain.BorrowBookReq) error {
+// AckOutbox mocks base method.
+func (m *MockBookResource) AckOutbox(ctx context.Context, req domain.AckOutboxReq) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BorrowBook", ctx, req)
+	ret := m.ctrl.Call(m, "AckOutbox", ctx, req)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// AckOutbox indicates an expected call of AckOutbox.
+func (mr *MockBookResourceMockRecorder) AckOutbox(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AckOutbox", reflect.TypeOf((*MockBookResource)(nil).AckOutbox), ctx, req)
+}
+
+// AcquireLease mocks base method.
+func (m *MockBookResource) AcquireLease(ctx context.Context, req domain.AcquireLeaseReq) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireLease", ctx, req)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireLease indicates an expected call of AcquireLease.
+func (mr *MockBookResourceMockRecorder) AcquireLease(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireLease", reflect.TypeOf((*MockBookResource)(nil).AcquireLease), ctx, req)
+}
+
+// BorrowBook mocks base method.
+func (m *MockBookResource) BorrowBook(ctx context.Context, req domain.BorrowBookReq) (domain.BorrowBookReq, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BorrowBook", ctx, req)
+	ret0, _ := ret[0].(domain.BorrowBookReq)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // BorrowBook indicates an expected call of BorrowBook.
 func (mr *MockBookResourceMockRecorder) BorrowBook(ctx, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BorrowBook", reflect.TypeOf((*MockBookResource)(nil).BorrowBook), ctx, req)
 }
 
+// BorrowBooks mocks base method.
+func (m *MockBookResource) BorrowBooks(ctx context.Context, req []domain.BorrowBookReq) ([]domain.BorrowBookReq, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BorrowBooks", ctx, req)
+	ret0, _ := ret[0].([]domain.BorrowBookReq)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BorrowBooks indicates an expected call of BorrowBooks.
+func (mr *MockBookResourceMockRecorder) BorrowBooks(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BorrowBooks", reflect.TypeOf((*MockBookResource)(nil).BorrowBooks), ctx, req)
+}
+
+// CancelReservation mocks base method.
+func (m *MockBookResource) CancelReservation(ctx context.Context, req domain.CancelReservationReq) (domain.BorrowBookReq, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelReservation", ctx, req)
+	ret0, _ := ret[0].(domain.BorrowBookReq)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelReservation indicates an expected call of CancelReservation.
+func (mr *MockBookResourceMockRecorder) CancelReservation(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelReservation", reflect.TypeOf((*MockBookResource)(nil).CancelReservation), ctx, req)
+}
+
+// CheckoutBook mocks base method.
+func (m *MockBookResource) CheckoutBook(ctx context.Context, req domain.CheckoutBookReq) (domain.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckoutBook", ctx, req)
+	ret0, _ := ret[0].(domain.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckoutBook indicates an expected call of CheckoutBook.
+func (mr *MockBookResourceMockRecorder) CheckoutBook(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckoutBook", reflect.TypeOf((*MockBookResource)(nil).CheckoutBook), ctx, req)
+}
+
+// ClaimOutbox mocks base method.
+func (m *MockBookResource) ClaimOutbox(ctx context.Context, req domain.ClaimOutboxReq) ([]domain.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimOutbox", ctx, req)
+	ret0, _ := ret[0].([]domain.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimOutbox indicates an expected call of ClaimOutbox.
+func (mr *MockBookResourceMockRecorder) ClaimOutbox(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimOutbox", reflect.TypeOf((*MockBookResource)(nil).ClaimOutbox), ctx, req)
+}
+
+// ClaimReminders mocks base method.
+func (m *MockBookResource) ClaimReminders(ctx context.Context, req domain.ClaimRemindersReq) ([]domain.BorrowBookReq, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimReminders", ctx, req)
+	ret0, _ := ret[0].([]domain.BorrowBookReq)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimReminders indicates an expected call of ClaimReminders.
+func (mr *MockBookResourceMockRecorder) ClaimReminders(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimReminders", reflect.TypeOf((*MockBookResource)(nil).ClaimReminders), ctx, req)
+}
+
+// ConfirmReservation mocks base method.
+func (m *MockBookResource) ConfirmReservation(ctx context.Context, req domain.ConfirmReservationReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmReservation", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmReservation indicates an expected call of ConfirmReservation.
+func (mr *MockBookResourceMockRecorder) ConfirmReservation(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmReservation", reflect.TypeOf((*MockBookResource)(nil).ConfirmReservation), ctx, req)
+}
+
+// ExpireUncollected mocks base method.
+func (m *MockBookResource) ExpireUncollected(ctx context.Context, req domain.ExpireUncollectedReq) ([]domain.BorrowBookReq, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireUncollected", ctx, req)
+	ret0, _ := ret[0].([]domain.BorrowBookReq)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireUncollected indicates an expected call of ExpireUncollected.
+func (mr *MockBookResourceMockRecorder) ExpireUncollected(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireUncollected", reflect.TypeOf((*MockBookResource)(nil).ExpireUncollected), ctx, req)
+}
+
+// GetAvailability mocks base method.
+func (m *MockBookResource) GetAvailability(ctx context.Context, req domain.GetAvailabilityReq) ([]domain.BranchAvailability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvailability", ctx, req)
+	ret0, _ := ret[0].([]domain.BranchAvailability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvailability indicates an expected call of GetAvailability.
+func (mr *MockBookResourceMockRecorder) GetAvailability(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailability", reflect.TypeOf((*MockBookResource)(nil).GetAvailability), ctx, req)
+}
+
 // GetBookByKey mocks base method.
 func (m *MockBookResource) GetBookByKey(ctx context.Context, req domain.GeBookByKeyReq) (domain.Book, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +228,51 @@ func (mr *MockBookResourceMockRecorder) GetBookReservation(ctx, req interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookReservation", reflect.TypeOf((*MockBookResource)(nil).GetBookReservation), ctx, req)
 }
 
+// GetBranches mocks base method.
+func (m *MockBookResource) GetBranches(ctx context.Context) ([]domain.Branch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBranches", ctx)
+	ret0, _ := ret[0].([]domain.Branch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBranches indicates an expected call of GetBranches.
+func (mr *MockBookResourceMockRecorder) GetBranches(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranches", reflect.TypeOf((*MockBookResource)(nil).GetBranches), ctx)
+}
+
+// GetCatalogHealth mocks base method.
+func (m *MockBookResource) GetCatalogHealth(ctx context.Context) ([]domain.ProviderHealth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCatalogHealth", ctx)
+	ret0, _ := ret[0].([]domain.ProviderHealth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCatalogHealth indicates an expected call of GetCatalogHealth.
+func (mr *MockBookResourceMockRecorder) GetCatalogHealth(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogHealth", reflect.TypeOf((*MockBookResource)(nil).GetCatalogHealth), ctx)
+}
+
+// GetFines mocks base method.
+func (m *MockBookResource) GetFines(ctx context.Context, req domain.GetFinesReq) (domain.FineLedger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFines", ctx, req)
+	ret0, _ := ret[0].(domain.FineLedger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFines indicates an expected call of GetFines.
+func (mr *MockBookResourceMockRecorder) GetFines(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFines", reflect.TypeOf((*MockBookResource)(nil).GetFines), ctx, req)
+}
+
 // GetListOfBooks mocks base method.
 func (m *MockBookResource) GetListOfBooks(ctx context.Context, req domain.GetListOfBooksReq) (domain.GetListOfBooksResp, error) {
 	m.ctrl.T.Helper()
@@ -92,4 +286,691 @@ func (m *MockBookResource) GetListOfBooks(ctx context.Context, req domain.GetLis
 func (mr *MockBookResourceMockRecorder) GetListOfBooks(ctx, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListOfBooks", reflect.TypeOf((*MockBookResource)(nil).GetListOfBooks), ctx, req)
+}
+
+// GetLoans mocks base method.
+func (m *MockBookResource) GetLoans(ctx context.Context, req domain.GetLoansReq) (map[int][]domain.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoans", ctx, req)
+	ret0, _ := ret[0].(map[int][]domain.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoans indicates an expected call of GetLoans.
+func (mr *MockBookResourceMockRecorder) GetLoans(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoans", reflect.TypeOf((*MockBookResource)(nil).GetLoans), ctx, req)
+}
+
+// GetOutbox mocks base method.
+func (m *MockBookResource) GetOutbox(ctx context.Context, req domain.GetOutboxReq) ([]domain.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutbox", ctx, req)
+	ret0, _ := ret[0].([]domain.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutbox indicates an expected call of GetOutbox.
+func (mr *MockBookResourceMockRecorder) GetOutbox(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutbox", reflect.TypeOf((*MockBookResource)(nil).GetOutbox), ctx, req)
+}
+
+// GetPickupSlots mocks base method.
+func (m *MockBookResource) GetPickupSlots(ctx context.Context, req domain.GetPickupSlotsReq) ([]domain.PickupSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPickupSlots", ctx, req)
+	ret0, _ := ret[0].([]domain.PickupSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPickupSlots indicates an expected call of GetPickupSlots.
+func (mr *MockBookResourceMockRecorder) GetPickupSlots(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPickupSlots", reflect.TypeOf((*MockBookResource)(nil).GetPickupSlots), ctx, req)
+}
+
+// GetReservationPage mocks base method.
+func (m *MockBookResource) GetReservationPage(ctx context.Context, req domain.GetReservationPageReq) ([]domain.BorrowBookReq, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReservationPage", ctx, req)
+	ret0, _ := ret[0].([]domain.BorrowBookReq)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReservationPage indicates an expected call of GetReservationPage.
+func (mr *MockBookResourceMockRecorder) GetReservationPage(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservationPage", reflect.TypeOf((*MockBookResource)(nil).GetReservationPage), ctx, req)
+}
+
+// JoinWaitlist mocks base method.
+func (m *MockBookResource) JoinWaitlist(ctx context.Context, req domain.JoinWaitlistReq) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JoinWaitlist", ctx, req)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// JoinWaitlist indicates an expected call of JoinWaitlist.
+func (mr *MockBookResourceMockRecorder) JoinWaitlist(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JoinWaitlist", reflect.TypeOf((*MockBookResource)(nil).JoinWaitlist), ctx, req)
+}
+
+// ReleaseReminder mocks base method.
+func (m *MockBookResource) ReleaseReminder(ctx context.Context, req domain.ReleaseReminderReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseReminder", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseReminder indicates an expected call of ReleaseReminder.
+func (mr *MockBookResourceMockRecorder) ReleaseReminder(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseReminder", reflect.TypeOf((*MockBookResource)(nil).ReleaseReminder), ctx, req)
+}
+
+// RenewLoan mocks base method.
+func (m *MockBookResource) RenewLoan(ctx context.Context, req domain.RenewLoanReq) (domain.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewLoan", ctx, req)
+	ret0, _ := ret[0].(domain.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenewLoan indicates an expected call of RenewLoan.
+func (mr *MockBookResourceMockRecorder) RenewLoan(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewLoan", reflect.TypeOf((*MockBookResource)(nil).RenewLoan), ctx, req)
+}
+
+// RequeueOutbox mocks base method.
+func (m *MockBookResource) RequeueOutbox(ctx context.Context, req domain.RequeueOutboxReq) (domain.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueOutbox", ctx, req)
+	ret0, _ := ret[0].(domain.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequeueOutbox indicates an expected call of RequeueOutbox.
+func (mr *MockBookResourceMockRecorder) RequeueOutbox(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueOutbox", reflect.TypeOf((*MockBookResource)(nil).RequeueOutbox), ctx, req)
+}
+
+// ReturnBook mocks base method.
+func (m *MockBookResource) ReturnBook(ctx context.Context, req domain.ReturnBookReq) (domain.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReturnBook", ctx, req)
+	ret0, _ := ret[0].(domain.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReturnBook indicates an expected call of ReturnBook.
+func (mr *MockBookResourceMockRecorder) ReturnBook(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnBook", reflect.TypeOf((*MockBookResource)(nil).ReturnBook), ctx, req)
+}
+
+// SettleFines mocks base method.
+func (m *MockBookResource) SettleFines(ctx context.Context, req domain.SettleFinesReq) (domain.FineLedger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SettleFines", ctx, req)
+	ret0, _ := ret[0].(domain.FineLedger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SettleFines indicates an expected call of SettleFines.
+func (mr *MockBookResourceMockRecorder) SettleFines(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettleFines", reflect.TypeOf((*MockBookResource)(nil).SettleFines), ctx, req)
+}
+
+// WaiveFine mocks base method.
+func (m *MockBookResource) WaiveFine(ctx context.Context, req domain.WaiveFineReq) (domain.FineLedger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaiveFine", ctx, req)
+	ret0, _ := ret[0].(domain.FineLedger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaiveFine indicates an expected call of WaiveFine.
+func (mr *MockBookResourceMockRecorder) WaiveFine(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaiveFine", reflect.TypeOf((*MockBookResource)(nil).WaiveFine), ctx, req)
+}
+
+// MockUserResource is a mock of UserResource interface.
+type MockUserResource struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserResourceMockRecorder
+}
+
+// MockUserResourceMockRecorder is the mock recorder for MockUserResource.
+type MockUserResourceMockRecorder struct {
+	mock *MockUserResource
+}
+
+// NewMockUserResource creates a new mock instance.
+func NewMockUserResource(ctrl *gomock.Controller) *MockUserResource {
+	mock := &MockUserResource{ctrl: ctrl}
+	mock.recorder = &MockUserResourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserResource) EXPECT() *MockUserResourceMockRecorder {
+	return m.recorder
+}
+
+// DeactivateUser mocks base method.
+func (m *MockUserResource) DeactivateUser(ctx context.Context, req domain.DeactivateUserReq) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateUser", ctx, req)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateUser indicates an expected call of DeactivateUser.
+func (mr *MockUserResourceMockRecorder) DeactivateUser(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateUser", reflect.TypeOf((*MockUserResource)(nil).DeactivateUser), ctx, req)
+}
+
+// GetUser mocks base method.
+func (m *MockUserResource) GetUser(ctx context.Context, req domain.GetUserReq) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, req)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockUserResourceMockRecorder) GetUser(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserResource)(nil).GetUser), ctx, req)
+}
+
+// RegisterUser mocks base method.
+func (m *MockUserResource) RegisterUser(ctx context.Context, req domain.RegisterUserReq) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterUser", ctx, req)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterUser indicates an expected call of RegisterUser.
+func (mr *MockUserResourceMockRecorder) RegisterUser(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockUserResource)(nil).RegisterUser), ctx, req)
+}
+
+// SetNotifyOptOut mocks base method.
+func (m *MockUserResource) SetNotifyOptOut(ctx context.Context, req domain.SetNotifyOptOutReq) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNotifyOptOut", ctx, req)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetNotifyOptOut indicates an expected call of SetNotifyOptOut.
+func (mr *MockUserResourceMockRecorder) SetNotifyOptOut(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNotifyOptOut", reflect.TypeOf((*MockUserResource)(nil).SetNotifyOptOut), ctx, req)
+}
+
+// UpdateUserProfile mocks base method.
+func (m *MockUserResource) UpdateUserProfile(ctx context.Context, req domain.UpdateUserProfileReq) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserProfile", ctx, req)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserProfile indicates an expected call of UpdateUserProfile.
+func (mr *MockUserResourceMockRecorder) UpdateUserProfile(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserProfile", reflect.TypeOf((*MockUserResource)(nil).UpdateUserProfile), ctx, req)
+}
+
+// MockWebhookResource is a mock of WebhookResource interface.
+type MockWebhookResource struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookResourceMockRecorder
+}
+
+// MockWebhookResourceMockRecorder is the mock recorder for MockWebhookResource.
+type MockWebhookResourceMockRecorder struct {
+	mock *MockWebhookResource
+}
+
+// NewMockWebhookResource creates a new mock instance.
+func NewMockWebhookResource(ctrl *gomock.Controller) *MockWebhookResource {
+	mock := &MockWebhookResource{ctrl: ctrl}
+	mock.recorder = &MockWebhookResourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookResource) EXPECT() *MockWebhookResourceMockRecorder {
+	return m.recorder
+}
+
+// ClaimWebhookDeliveries mocks base method.
+func (m *MockWebhookResource) ClaimWebhookDeliveries(ctx context.Context, req domain.ClaimWebhookDeliveriesReq) ([]domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimWebhookDeliveries", ctx, req)
+	ret0, _ := ret[0].([]domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimWebhookDeliveries indicates an expected call of ClaimWebhookDeliveries.
+func (mr *MockWebhookResourceMockRecorder) ClaimWebhookDeliveries(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimWebhookDeliveries", reflect.TypeOf((*MockWebhookResource)(nil).ClaimWebhookDeliveries), ctx, req)
+}
+
+// CreateWebhook mocks base method.
+func (m *MockWebhookResource) CreateWebhook(ctx context.Context, req domain.CreateWebhookReq) (domain.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", ctx, req)
+	ret0, _ := ret[0].(domain.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockWebhookResourceMockRecorder) CreateWebhook(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockWebhookResource)(nil).CreateWebhook), ctx, req)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhookResource) DeleteWebhook(ctx context.Context, req domain.DeleteWebhookReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookResourceMockRecorder) DeleteWebhook(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhookResource)(nil).DeleteWebhook), ctx, req)
+}
+
+// EnqueueWebhook mocks base method.
+func (m *MockWebhookResource) EnqueueWebhook(ctx context.Context, req domain.EnqueueWebhookReq) ([]domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueWebhook", ctx, req)
+	ret0, _ := ret[0].([]domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqueueWebhook indicates an expected call of EnqueueWebhook.
+func (mr *MockWebhookResourceMockRecorder) EnqueueWebhook(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueWebhook", reflect.TypeOf((*MockWebhookResource)(nil).EnqueueWebhook), ctx, req)
+}
+
+// GetWebhook mocks base method.
+func (m *MockWebhookResource) GetWebhook(ctx context.Context, req domain.GetWebhookReq) (domain.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhook", ctx, req)
+	ret0, _ := ret[0].(domain.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockWebhookResourceMockRecorder) GetWebhook(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*MockWebhookResource)(nil).GetWebhook), ctx, req)
+}
+
+// GetWebhookDeliveries mocks base method.
+func (m *MockWebhookResource) GetWebhookDeliveries(ctx context.Context, req domain.GetWebhookDeliveriesReq) ([]domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDeliveries", ctx, req)
+	ret0, _ := ret[0].([]domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDeliveries indicates an expected call of GetWebhookDeliveries.
+func (mr *MockWebhookResourceMockRecorder) GetWebhookDeliveries(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDeliveries", reflect.TypeOf((*MockWebhookResource)(nil).GetWebhookDeliveries), ctx, req)
+}
+
+// GetWebhooks mocks base method.
+func (m *MockWebhookResource) GetWebhooks(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", ctx)
+	ret0, _ := ret[0].([]domain.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks.
+func (mr *MockWebhookResourceMockRecorder) GetWebhooks(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*MockWebhookResource)(nil).GetWebhooks), ctx)
+}
+
+// RecordWebhookAttempt mocks base method.
+func (m *MockWebhookResource) RecordWebhookAttempt(ctx context.Context, req domain.RecordWebhookAttemptReq) (domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordWebhookAttempt", ctx, req)
+	ret0, _ := ret[0].(domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordWebhookAttempt indicates an expected call of RecordWebhookAttempt.
+func (mr *MockWebhookResourceMockRecorder) RecordWebhookAttempt(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordWebhookAttempt", reflect.TypeOf((*MockWebhookResource)(nil).RecordWebhookAttempt), ctx, req)
+}
+
+// ReplayWebhookDelivery mocks base method.
+func (m *MockWebhookResource) ReplayWebhookDelivery(ctx context.Context, req domain.ReplayWebhookDeliveryReq) (domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayWebhookDelivery", ctx, req)
+	ret0, _ := ret[0].(domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplayWebhookDelivery indicates an expected call of ReplayWebhookDelivery.
+func (mr *MockWebhookResourceMockRecorder) ReplayWebhookDelivery(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayWebhookDelivery", reflect.TypeOf((*MockWebhookResource)(nil).ReplayWebhookDelivery), ctx, req)
+}
+
+// SendWebhook mocks base method.
+func (m *MockWebhookResource) SendWebhook(ctx context.Context, req domain.SendWebhookReq) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendWebhook", ctx, req)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendWebhook indicates an expected call of SendWebhook.
+func (mr *MockWebhookResourceMockRecorder) SendWebhook(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendWebhook", reflect.TypeOf((*MockWebhookResource)(nil).SendWebhook), ctx, req)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockWebhookResource) UpdateWebhook(ctx context.Context, req domain.UpdateWebhookReq) (domain.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", ctx, req)
+	ret0, _ := ret[0].(domain.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockWebhookResourceMockRecorder) UpdateWebhook(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockWebhookResource)(nil).UpdateWebhook), ctx, req)
+}
+
+// MockIdempotencyResource is a mock of IdempotencyResource interface.
+type MockIdempotencyResource struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyResourceMockRecorder
+}
+
+// MockIdempotencyResourceMockRecorder is the mock recorder for MockIdempotencyResource.
+type MockIdempotencyResourceMockRecorder struct {
+	mock *MockIdempotencyResource
+}
+
+// NewMockIdempotencyResource creates a new mock instance.
+func NewMockIdempotencyResource(ctrl *gomock.Controller) *MockIdempotencyResource {
+	mock := &MockIdempotencyResource{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyResourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyResource) EXPECT() *MockIdempotencyResourceMockRecorder {
+	return m.recorder
+}
+
+// BeginIdempotency mocks base method.
+func (m *MockIdempotencyResource) BeginIdempotency(ctx context.Context, req domain.BeginIdempotencyReq) (domain.IdempotencyRecord, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginIdempotency", ctx, req)
+	ret0, _ := ret[0].(domain.IdempotencyRecord)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BeginIdempotency indicates an expected call of BeginIdempotency.
+func (mr *MockIdempotencyResourceMockRecorder) BeginIdempotency(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginIdempotency", reflect.TypeOf((*MockIdempotencyResource)(nil).BeginIdempotency), ctx, req)
+}
+
+// CompleteIdempotency mocks base method.
+func (m *MockIdempotencyResource) CompleteIdempotency(ctx context.Context, req domain.CompleteIdempotencyReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteIdempotency", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteIdempotency indicates an expected call of CompleteIdempotency.
+func (mr *MockIdempotencyResourceMockRecorder) CompleteIdempotency(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteIdempotency", reflect.TypeOf((*MockIdempotencyResource)(nil).CompleteIdempotency), ctx, req)
+}
+
+// ReleaseIdempotency mocks base method.
+func (m *MockIdempotencyResource) ReleaseIdempotency(ctx context.Context, req domain.ReleaseIdempotencyReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseIdempotency", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseIdempotency indicates an expected call of ReleaseIdempotency.
+func (mr *MockIdempotencyResourceMockRecorder) ReleaseIdempotency(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseIdempotency", reflect.TypeOf((*MockIdempotencyResource)(nil).ReleaseIdempotency), ctx, req)
+}
+
+// MockMigrationResource is a mock of MigrationResource interface.
+type MockMigrationResource struct {
+	ctrl     *gomock.Controller
+	recorder *MockMigrationResourceMockRecorder
+}
+
+// MockMigrationResourceMockRecorder is the mock recorder for MockMigrationResource.
+type MockMigrationResourceMockRecorder struct {
+	mock *MockMigrationResource
+}
+
+// NewMockMigrationResource creates a new mock instance.
+func NewMockMigrationResource(ctrl *gomock.Controller) *MockMigrationResource {
+	mock := &MockMigrationResource{ctrl: ctrl}
+	mock.recorder = &MockMigrationResourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMigrationResource) EXPECT() *MockMigrationResourceMockRecorder {
+	return m.recorder
+}
+
+// AcquireMigrationLock mocks base method.
+func (m *MockMigrationResource) AcquireMigrationLock(ctx context.Context, req domain.AcquireMigrationLockReq) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireMigrationLock", ctx, req)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireMigrationLock indicates an expected call of AcquireMigrationLock.
+func (mr *MockMigrationResourceMockRecorder) AcquireMigrationLock(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireMigrationLock", reflect.TypeOf((*MockMigrationResource)(nil).AcquireMigrationLock), ctx, req)
+}
+
+// ApplyMigration mocks base method.
+func (m *MockMigrationResource) ApplyMigration(ctx context.Context, req domain.Migration) (domain.AppliedMigration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyMigration", ctx, req)
+	ret0, _ := ret[0].(domain.AppliedMigration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyMigration indicates an expected call of ApplyMigration.
+func (mr *MockMigrationResourceMockRecorder) ApplyMigration(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyMigration", reflect.TypeOf((*MockMigrationResource)(nil).ApplyMigration), ctx, req)
+}
+
+// GetAppliedMigrations mocks base method.
+func (m *MockMigrationResource) GetAppliedMigrations(ctx context.Context) ([]domain.AppliedMigration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAppliedMigrations", ctx)
+	ret0, _ := ret[0].([]domain.AppliedMigration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAppliedMigrations indicates an expected call of GetAppliedMigrations.
+func (mr *MockMigrationResourceMockRecorder) GetAppliedMigrations(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppliedMigrations", reflect.TypeOf((*MockMigrationResource)(nil).GetAppliedMigrations), ctx)
+}
+
+// GetMigrations mocks base method.
+func (m *MockMigrationResource) GetMigrations(ctx context.Context) ([]domain.Migration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMigrations", ctx)
+	ret0, _ := ret[0].([]domain.Migration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMigrations indicates an expected call of GetMigrations.
+func (mr *MockMigrationResourceMockRecorder) GetMigrations(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMigrations", reflect.TypeOf((*MockMigrationResource)(nil).GetMigrations), ctx)
+}
+
+// ReleaseMigrationLock mocks base method.
+func (m *MockMigrationResource) ReleaseMigrationLock(ctx context.Context, req domain.ReleaseMigrationLockReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseMigrationLock", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseMigrationLock indicates an expected call of ReleaseMigrationLock.
+func (mr *MockMigrationResourceMockRecorder) ReleaseMigrationLock(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseMigrationLock", reflect.TypeOf((*MockMigrationResource)(nil).ReleaseMigrationLock), ctx, req)
+}
+
+// MockNotifier is a mock of Notifier interface.
+type MockNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotifierMockRecorder
+}
+
+// MockNotifierMockRecorder is the mock recorder for MockNotifier.
+type MockNotifierMockRecorder struct {
+	mock *MockNotifier
+}
+
+// NewMockNotifier creates a new mock instance.
+func NewMockNotifier(ctrl *gomock.Controller) *MockNotifier {
+	mock := &MockNotifier{ctrl: ctrl}
+	mock.recorder = &MockNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotifier) EXPECT() *MockNotifierMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *MockNotifier) Notify(ctx context.Context, msg domain.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", ctx, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockNotifierMockRecorder) Notify(ctx, msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockNotifier)(nil).Notify), ctx, msg)
+}
+
+// MockEventHandler is a mock of EventHandler interface.
+type MockEventHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventHandlerMockRecorder
+}
+
+// MockEventHandlerMockRecorder is the mock recorder for MockEventHandler.
+type MockEventHandlerMockRecorder struct {
+	mock *MockEventHandler
+}
+
+// NewMockEventHandler creates a new mock instance.
+func NewMockEventHandler(ctrl *gomock.Controller) *MockEventHandler {
+	mock := &MockEventHandler{ctrl: ctrl}
+	mock.recorder = &MockEventHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventHandler) EXPECT() *MockEventHandlerMockRecorder {
+	return m.recorder
+}
+
+// HandleEvent mocks base method.
+func (m *MockEventHandler) HandleEvent(ctx context.Context, event domain.OutboxEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleEvent indicates an expected call of HandleEvent.
+func (mr *MockEventHandlerMockRecorder) HandleEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleEvent", reflect.TypeOf((*MockEventHandler)(nil).HandleEvent), ctx, event)
 }
\ No newline at end of file
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"errors"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+const (
+	ErrCodeNotFound       = "not_found"
+	ErrCodeConflict       = "conflict"
+	ErrCodeInvalidRequest = "invalid_request"
+	ErrCodeForbidden      = "forbidden"
+	ErrCodeUnprocessable  = "unprocessable"
+	// ErrCodeInternal is only used where an error without a code has to be reported with one, e.g. batch items.
+	ErrCodeInternal = "internal"
+)
+
+// ServiceError is an error the transport layer can map to a status code.
+type ServiceError struct {
+	Code    string
+	Message string
+	Details []ErrorDetail
+	Err     error
+}
+
+// ErrorDetail explains one reason behind a ServiceError, e.g. a policy that denied a reservation.
+type ErrorDetail struct {
+	Rule   string `json:"rule"`
+	Reason string `json:"reason"`
+}
+
+func (e *ServiceError) Error() string {
+	return e.Message
+}
+
+func (e *ServiceError) Unwrap() error {
+	return e.Err
+}
+
+func newServiceError(code string, err error) *ServiceError {
+	return &ServiceError{
+		Code:    code,
+		Message: err.Error(),
+		Err:     err,
+	}
+}
+
+func invalidRequest(msg string) *ServiceError {
+	return newServiceError(ErrCodeInvalidRequest, errors.New(msg))
+}
+
+// wrapDomainError gives known domain errors a ServiceError code, other errors are returned as they are.
+func wrapDomainError(err error) error {
+	var policyErr *domain.PolicyError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &policyErr):
+		return policyDenied(policyErr)
+	case errors.Is(err, domain.ErrReservationNotFound),
+		errors.Is(err, domain.ErrLoanNotFound),
+		errors.Is(err, domain.ErrFineNotFound),
+		errors.Is(err, domain.ErrUserNotFound),
+		errors.Is(err, domain.ErrBranchNotFound),
+		errors.Is(err, domain.ErrOutboxEventNotFound),
+		errors.Is(err, domain.ErrWebhookNotFound),
+		errors.Is(err, domain.ErrWebhookDeliveryNotFound),
+		errors.Is(err, domain.ErrIdempotencyKeyNotFound):
+		return newServiceError(ErrCodeNotFound, err)
+	case errors.Is(err, domain.ErrFullyReserved),
+		errors.Is(err, domain.ErrBookAvailable),
+		errors.Is(err, domain.ErrAlreadyInWaitlist),
+		errors.Is(err, domain.ErrInvalidReservationState),
+		errors.Is(err, domain.ErrConfirmWindowPassed),
+		errors.Is(err, domain.ErrLoanClosed),
+		errors.Is(err, domain.ErrRenewalLimitReached),
+		errors.Is(err, domain.ErrWorkOnHold),
+		errors.Is(err, domain.ErrLoanOverdue),
+		errors.Is(err, domain.ErrEmailTaken),
+		errors.Is(err, domain.ErrBranchClosed),
+		errors.Is(err, domain.ErrPickupCapacityReached),
+		errors.Is(err, domain.ErrPickupSlotFull),
+		errors.Is(err, domain.ErrOutboxEventNotDead),
+		errors.Is(err, domain.ErrWebhookDisabled),
+		errors.Is(err, domain.ErrIdempotencyInProgress),
+		errors.Is(err, domain.ErrMigrationChecksum),
+		errors.Is(err, domain.ErrMigrationUnknown),
+		errors.Is(err, domain.ErrMigrationOutOfOrder),
+		errors.Is(err, domain.ErrMigrationLocked):
+		return newServiceError(ErrCodeConflict, err)
+	case errors.Is(err, domain.ErrInvalidAmount),
+		errors.Is(err, domain.ErrCurrencyMismatch),
+		errors.Is(err, domain.ErrInvalidPickUpDate),
+		errors.Is(err, domain.ErrInvalidPickUpSlot):
+		return newServiceError(ErrCodeInvalidRequest, err)
+	case errors.Is(err, domain.ErrIdempotencyKeyReused):
+		return newServiceError(ErrCodeUnprocessable, err)
+	case errors.Is(err, domain.ErrOutstandingFines),
+		errors.Is(err, domain.ErrUserInactive):
+		return newServiceError(ErrCodeForbidden, err)
+	}
+	return err
+}
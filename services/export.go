@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+const defaultExportPageSize = 500
+
+// ExportBookReservation walks the reservations page by page and hands every row to write, so only one page is held
+// in memory however large the listing is. It stops at the first error of write or when ctx is done.
+func (p bookService) ExportBookReservation(ctx context.Context, req ExportBookReservationReq, write func(ReservationExportRow) error) error {
+	if req.UserID < 0 {
+		return invalidRequest("User ID cannot be negative")
+	}
+	for _, date := range []string{req.From, req.To} {
+		if _, err := time.Parse(pickUpDateLayout, date); date != "" && err != nil {
+			return invalidRequest("From and to must be formatted as YYYY-MM-DD")
+		}
+	}
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultExportPageSize
+	}
+
+	afterID := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		page, err := p.br.GetReservationPage(ctx, domain.GetReservationPageReq{
+			UserID:  req.UserID,
+			Branch:  req.Branch,
+			Status:  req.Status,
+			Key:     req.Key,
+			Query:   req.Query,
+			From:    req.From,
+			To:      req.To,
+			AfterID: afterID,
+			Limit:   pageSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, item := range page {
+			if err := write(newReservationExportRow(item)); err != nil {
+				return err
+			}
+			afterID = item.ID
+		}
+		if len(page) < pageSize {
+			return nil
+		}
+	}
+}
+
+func newReservationExportRow(item domain.BorrowBookReq) ReservationExportRow {
+	authors := []string{}
+	for _, author := range item.Book.Authors {
+		authors = append(authors, author.Name)
+	}
+	return ReservationExportRow{
+		ID:         item.ID,
+		Title:      item.Book.Title,
+		Author:     strings.Join(authors, "; "),
+		BookKey:    item.Book.Key,
+		Branch:     item.Branch,
+		PickUpDate: item.PickUpDate,
+		PickUpSlot: item.PickUpSlot,
+		UserID:     item.UserID,
+		Status:     item.Status,
+		CreatedAt:  item.CreatedAt,
+	}
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"errors"
+	reflect "reflect"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+func Test_ExportBookReservation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	matilda := domain.Book{Key: "123", Title: "Matilda", Authors: []domain.Author{{Name: "Roald Dahl"}, {Name: "Quentin Blake"}}}
+
+	tests := []struct {
+		name     string
+		req      ExportBookReservationReq
+		mock     func() bookService
+		writeErr error
+		wantIDs  []int
+		wantErr  bool
+	}{
+		{
+			name: "pages until a short page",
+			req:  ExportBookReservationReq{Branch: "central", PageSize: 2},
+			mock: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				gomock.InOrder(
+					bookMock.EXPECT().GetReservationPage(gomock.Any(), domain.GetReservationPageReq{Branch: "central", Limit: 2}).Return([]domain.BorrowBookReq{
+						{ID: 1, Book: matilda, Branch: "central", UserID: 1, Status: domain.ReservationStatusActive},
+						{ID: 3, Book: matilda, Branch: "central", UserID: 2, Status: domain.ReservationStatusCancelled},
+					}, nil),
+					bookMock.EXPECT().GetReservationPage(gomock.Any(), domain.GetReservationPageReq{Branch: "central", AfterID: 3, Limit: 2}).Return([]domain.BorrowBookReq{
+						{ID: 4, Book: matilda, Branch: "central", UserID: 1, Status: domain.ReservationStatusActive},
+					}, nil),
+				)
+				return bookService{br: bookMock}
+			},
+			wantIDs: []int{1, 3, 4},
+		},
+		{
+			name: "filters are passed to the store",
+			req:  ExportBookReservationReq{Status: "active", Key: "123", Query: "dahl", From: "2022-01-20", To: "2022-01-31"},
+			mock: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().GetReservationPage(gomock.Any(), domain.GetReservationPageReq{
+					Status: "active",
+					Key:    "123",
+					Query:  "dahl",
+					From:   "2022-01-20",
+					To:     "2022-01-31",
+					Limit:  defaultExportPageSize,
+				}).Return([]domain.BorrowBookReq{
+					{ID: 1, Book: matilda, Status: domain.ReservationStatusActive},
+				}, nil)
+				return bookService{br: bookMock}
+			},
+			wantIDs: []int{1},
+		},
+		{
+			name: "bad pickup date filter",
+			req:  ExportBookReservationReq{From: "20-01-2022"},
+			mock: func() bookService {
+				return bookService{br: NewMockBookResource(ctrl)}
+			},
+			wantIDs: []int{},
+			wantErr: true,
+		},
+		{
+			name: "write error stops the export",
+			req:  ExportBookReservationReq{PageSize: 2},
+			mock: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().GetReservationPage(gomock.Any(), gomock.Any()).Return([]domain.BorrowBookReq{
+					{ID: 1, Book: matilda},
+					{ID: 2, Book: matilda},
+				}, nil)
+				return bookService{br: bookMock}
+			},
+			writeErr: errors.New("broken pipe"),
+			wantIDs:  []int{1},
+			wantErr:  true,
+		},
+		{
+			name: "page error",
+			mock: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().GetReservationPage(gomock.Any(), domain.GetReservationPageReq{Limit: defaultExportPageSize}).Return(nil, errors.New("error"))
+				return bookService{br: bookMock}
+			},
+			wantIDs: []int{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := []int{}
+			err := tt.mock().ExportBookReservation(context.Background(), tt.req, func(row ReservationExportRow) error {
+				ids = append(ids, row.ID)
+				if row.Title != "Matilda" || row.Author != "Roald Dahl; Quentin Blake" {
+					t.Errorf("ExportBookReservation() row = %+v", row)
+				}
+				return tt.writeErr
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExportBookReservation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("ExportBookReservation() rows = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
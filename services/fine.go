@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+func (p bookService) GetFines(ctx context.Context, req GetFinesReq) (FineLedgerRes, error) {
+	if req.UserID == 0 {
+		return FineLedgerRes{}, invalidRequest("User ID is empty")
+	}
+
+	ledger, err := p.br.GetFines(ctx, domain.GetFinesReq{
+		UserID: req.UserID,
+	})
+	if err != nil {
+		return FineLedgerRes{}, wrapDomainError(err)
+	}
+	return newFineLedgerRes(ledger), nil
+}
+
+func (p bookService) SettleFines(ctx context.Context, req SettleFinesReq) (FineLedgerRes, error) {
+	if req.UserID == 0 {
+		return FineLedgerRes{}, invalidRequest("User ID is empty")
+	}
+
+	ledger, err := p.br.SettleFines(ctx, domain.SettleFinesReq{
+		UserID:   req.UserID,
+		Amount:   req.Amount,
+		Currency: req.Currency,
+	})
+	if err != nil {
+		return FineLedgerRes{}, wrapDomainError(err)
+	}
+	return newFineLedgerRes(ledger), nil
+}
+
+func (p bookService) WaiveFine(ctx context.Context, req WaiveFineReq) (FineLedgerRes, error) {
+	if req.FineID == 0 {
+		return FineLedgerRes{}, invalidRequest("Fine ID is empty")
+	}
+
+	ledger, err := p.br.WaiveFine(ctx, domain.WaiveFineReq{
+		FineID: req.FineID,
+		Amount: req.Amount,
+		Note:   req.Note,
+	})
+	if err != nil {
+		return FineLedgerRes{}, wrapDomainError(err)
+	}
+	return newFineLedgerRes(ledger), nil
+}
+
+// checkOutstandingFines refuses new reservations from users owing more than the configured balance, counting the
+// fines still accruing on overdue loans as owed.
+func (p bookService) checkOutstandingFines(ctx context.Context, userID int) error {
+	if p.fine.MaxOutstanding <= 0 || userID == 0 {
+		return nil
+	}
+
+	ledger, err := p.br.GetFines(ctx, domain.GetFinesReq{
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+	if ledger.Balance+ledger.Accruing > p.fine.MaxOutstanding {
+		return wrapDomainError(domain.ErrOutstandingFines)
+	}
+	return nil
+}
+
+func newFineLedgerRes(ledger domain.FineLedger) FineLedgerRes {
+	res := FineLedgerRes{
+		UserID:   ledger.UserID,
+		Currency: ledger.Currency,
+		Balance:  ledger.Balance,
+		Accruing: ledger.Accruing,
+		Entries:  []FineEntryRes{},
+	}
+	for _, entry := range ledger.Entries {
+		res.Entries = append(res.Entries, FineEntryRes{
+			ID:        entry.ID,
+			LoanID:    entry.LoanID,
+			FineID:    entry.FineID,
+			Type:      entry.Type,
+			Amount:    entry.Amount,
+			Currency:  entry.Currency,
+			Note:      entry.Note,
+			CreatedAt: entry.CreatedAt,
+		})
+	}
+	return res
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"errors"
+	reflect "reflect"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+func Test_GetFines(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		fields   func() bookService
+		req      GetFinesReq
+		want     FineLedgerRes
+		wantCode string
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().GetFines(gomock.Any(), domain.GetFinesReq{UserID: 2}).Return(domain.FineLedger{
+					UserID:   2,
+					Currency: "USD",
+					Balance:  75,
+					Accruing: 25,
+					Entries: []domain.FineEntry{
+						{ID: 1, UserID: 2, LoanID: 4, Type: domain.FineEntryCharge, Amount: 75, Currency: "USD"},
+					},
+				}, nil)
+				return bookService{br: bookMock}
+			},
+			req: GetFinesReq{UserID: 2},
+			want: FineLedgerRes{
+				UserID:   2,
+				Currency: "USD",
+				Balance:  75,
+				Accruing: 25,
+				Entries: []FineEntryRes{
+					{ID: 1, LoanID: 4, Type: domain.FineEntryCharge, Amount: 75, Currency: "USD"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing user id",
+			fields: func() bookService {
+				return bookService{br: NewMockBookResource(ctrl)}
+			},
+			req:      GetFinesReq{},
+			want:     FineLedgerRes{},
+			wantCode: ErrCodeInvalidRequest,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.fields()
+			got, err := m.GetFines(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetFines() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if se := (*ServiceError)(nil); tt.wantCode != "" && (!errors.As(err, &se) || se.Code != tt.wantCode) {
+				t.Errorf("GetFines() error = %v, want code %v", err, tt.wantCode)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetFines() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_SettleFines(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		fields   func() bookService
+		req      SettleFinesReq
+		want     FineLedgerRes
+		wantCode string
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().SettleFines(gomock.Any(), domain.SettleFinesReq{UserID: 2, Amount: 75, Currency: "USD"}).Return(domain.FineLedger{UserID: 2, Currency: "USD"}, nil)
+				return bookService{br: bookMock}
+			},
+			req:     SettleFinesReq{UserID: 2, Amount: 75, Currency: "USD"},
+			want:    FineLedgerRes{UserID: 2, Currency: "USD", Entries: []FineEntryRes{}},
+			wantErr: false,
+		},
+		{
+			name: "amount above balance",
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().SettleFines(gomock.Any(), gomock.Any()).Return(domain.FineLedger{}, domain.ErrInvalidAmount)
+				return bookService{br: bookMock}
+			},
+			req:      SettleFinesReq{UserID: 2, Amount: 1000},
+			want:     FineLedgerRes{},
+			wantCode: ErrCodeInvalidRequest,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.fields()
+			got, err := m.SettleFines(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SettleFines() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if se := (*ServiceError)(nil); tt.wantCode != "" && (!errors.As(err, &se) || se.Code != tt.wantCode) {
+				t.Errorf("SettleFines() error = %v, want code %v", err, tt.wantCode)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SettleFines() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_WaiveFine(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		fields   func() bookService
+		req      WaiveFineReq
+		wantCode string
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().WaiveFine(gomock.Any(), domain.WaiveFineReq{FineID: 1, Note: "first offence"}).Return(domain.FineLedger{UserID: 2}, nil)
+				return bookService{br: bookMock}
+			},
+			req:     WaiveFineReq{FineID: 1, Note: "first offence"},
+			wantErr: false,
+		},
+		{
+			name: "fine not found",
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().WaiveFine(gomock.Any(), gomock.Any()).Return(domain.FineLedger{}, domain.ErrFineNotFound)
+				return bookService{br: bookMock}
+			},
+			req:      WaiveFineReq{FineID: 1},
+			wantCode: ErrCodeNotFound,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.fields()
+			_, err := m.WaiveFine(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WaiveFine() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if se := (*ServiceError)(nil); tt.wantCode != "" && (!errors.As(err, &se) || se.Code != tt.wantCode) {
+				t.Errorf("WaiveFine() error = %v, want code %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_BorrowBookOutstandingFines(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		balance  int64
+		accruing int64
+		wantCode string
+		wantErr  bool
+	}{
+		{
+			name:    "at the limit",
+			balance: 500,
+			wantErr: false,
+		},
+		{
+			name:     "above the limit",
+			balance:  501,
+			wantCode: ErrCodeForbidden,
+			wantErr:  true,
+		},
+		{
+			name:     "above the limit with overdue loans",
+			balance:  300,
+			accruing: 250,
+			wantCode: ErrCodeForbidden,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bookMock := NewMockBookResource(ctrl)
+			bookMock.EXPECT().GetFines(gomock.Any(), domain.GetFinesReq{UserID: 1}).Return(domain.FineLedger{Balance: tt.balance, Accruing: tt.accruing}, nil)
+			if !tt.wantErr {
+				bookMock.EXPECT().GetBookByKey(gomock.Any(), gomock.Any()).Return(domain.Book{Key: "123"}, nil)
+				bookMock.EXPECT().BorrowBook(gomock.Any(), gomock.Any()).Return(domain.BorrowBookReq{}, nil)
+			}
+
+			svc, _ := NewBookService(BookDependencies{
+				BR:  bookMock,
+				Cfg: &config.GlobalConfig{Fine: config.Fine{MaxOutstanding: 500}},
+			})
+			_, err := svc.BorrowBook(context.Background(), BorrowBookReq{BookKey: "123", Branch: "central", UserID: 1})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BorrowBook() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if se := (*ServiceError)(nil); tt.wantCode != "" && (!errors.As(err, &se) || se.Code != tt.wantCode) {
+				t.Errorf("BorrowBook() error = %v, want code %v", err, tt.wantCode)
+			}
+		})
+	}
+}
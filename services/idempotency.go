@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+const (
+	defaultIdempotencyTTLHours = 24
+	defaultIdempotencyLockSec  = 60
+	maxIdempotencyKeyLength    = 255
+)
+
+// IdempotencyService remembers the response to a request sent with an idempotency key so retries of the same
+// request get it again instead of repeating the request.
+type IdempotencyService interface {
+	BeginIdempotentRequest(ctx context.Context, req BeginIdempotentRequestReq) (IdempotentRequestRes, error)
+	CompleteIdempotentRequest(ctx context.Context, req CompleteIdempotentRequestReq) error
+	ReleaseIdempotentRequest(ctx context.Context, req ReleaseIdempotentRequestReq) error
+}
+
+type idempotencyService struct {
+	ir   IdempotencyResource
+	ttl  time.Duration
+	lock time.Duration
+}
+
+func NewIdempotencyService(dep IdempotencyDependencies) (IdempotencyService, error) {
+	svc := &idempotencyService{
+		ir:   dep.IR,
+		ttl:  defaultIdempotencyTTLHours * time.Hour,
+		lock: defaultIdempotencyLockSec * time.Second,
+	}
+	if dep.Cfg != nil {
+		if dep.Cfg.Idempotency.TTLHours > 0 {
+			svc.ttl = time.Duration(dep.Cfg.Idempotency.TTLHours) * time.Hour
+		}
+		if dep.Cfg.Idempotency.LockSec > 0 {
+			svc.lock = time.Duration(dep.Cfg.Idempotency.LockSec) * time.Second
+		}
+	}
+	return svc, nil
+}
+
+// BeginIdempotentRequest claims the key for a new request or returns the response stored for a retry. A key sent
+// with another request is unprocessable, a retry while the first request still runs is a conflict.
+func (p *idempotencyService) BeginIdempotentRequest(ctx context.Context, req BeginIdempotentRequestReq) (IdempotentRequestRes, error) {
+	if err := validateIdempotencyKey(req.Key); err != nil {
+		return IdempotentRequestRes{}, err
+	}
+
+	record, started, err := p.ir.BeginIdempotency(ctx, domain.BeginIdempotencyReq{
+		Scope:       req.Scope,
+		Key:         req.Key,
+		Fingerprint: req.Fingerprint,
+		TTL:         p.ttl,
+		Lock:        p.lock,
+	})
+	if err != nil {
+		return IdempotentRequestRes{}, wrapDomainError(err)
+	}
+	if started {
+		return IdempotentRequestRes{}, nil
+	}
+
+	return IdempotentRequestRes{
+		Replay:      true,
+		StatusCode:  record.StatusCode,
+		ContentType: record.ContentType,
+		Body:        record.Body,
+	}, nil
+}
+
+func (p *idempotencyService) CompleteIdempotentRequest(ctx context.Context, req CompleteIdempotentRequestReq) error {
+	return wrapDomainError(p.ir.CompleteIdempotency(ctx, domain.CompleteIdempotencyReq{
+		Scope:       req.Scope,
+		Key:         req.Key,
+		StatusCode:  req.StatusCode,
+		ContentType: req.ContentType,
+		Body:        req.Body,
+	}))
+}
+
+func (p *idempotencyService) ReleaseIdempotentRequest(ctx context.Context, req ReleaseIdempotentRequestReq) error {
+	return wrapDomainError(p.ir.ReleaseIdempotency(ctx, domain.ReleaseIdempotencyReq{
+		Scope: req.Scope,
+		Key:   req.Key,
+	}))
+}
+
+// validateIdempotencyKey accepts up to 255 visible ASCII characters, e.g. a UUID.
+func validateIdempotencyKey(key string) error {
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		return invalidRequest("Idempotency key must be 1 to 255 characters")
+	}
+	for _, c := range key {
+		if c <= ' ' || c > '~' {
+			return invalidRequest("Idempotency key may only contain visible ASCII characters")
+		}
+	}
+	return nil
+}
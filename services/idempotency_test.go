@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"errors"
+	reflect "reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+func Test_BeginIdempotentRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	req := BeginIdempotentRequestReq{Scope: "/borrow-book", Key: "3f1c", Fingerprint: "f1"}
+	begin := domain.BeginIdempotencyReq{Scope: "/borrow-book", Key: "3f1c", Fingerprint: "f1", TTL: 2 * time.Hour, Lock: defaultIdempotencyLockSec * time.Second}
+
+	tests := []struct {
+		name     string
+		req      BeginIdempotentRequestReq
+		mock     func() IdempotencyResource
+		want     IdempotentRequestRes
+		wantCode string
+		wantErr  bool
+	}{
+		{
+			name: "new request",
+			req:  req,
+			mock: func() IdempotencyResource {
+				idemMock := NewMockIdempotencyResource(ctrl)
+				idemMock.EXPECT().BeginIdempotency(gomock.Any(), begin).Return(domain.IdempotencyRecord{}, true, nil)
+				return idemMock
+			},
+			want: IdempotentRequestRes{},
+		},
+		{
+			name: "retry is replayed",
+			req:  req,
+			mock: func() IdempotencyResource {
+				idemMock := NewMockIdempotencyResource(ctrl)
+				idemMock.EXPECT().BeginIdempotency(gomock.Any(), begin).Return(domain.IdempotencyRecord{
+					Status:      domain.IdempotencyStatusCompleted,
+					StatusCode:  200,
+					ContentType: "application/json",
+					Body:        []byte(`{"id":1}`),
+				}, false, nil)
+				return idemMock
+			},
+			want: IdempotentRequestRes{Replay: true, StatusCode: 200, ContentType: "application/json", Body: []byte(`{"id":1}`)},
+		},
+		{
+			name: "key reused with another body",
+			req:  req,
+			mock: func() IdempotencyResource {
+				idemMock := NewMockIdempotencyResource(ctrl)
+				idemMock.EXPECT().BeginIdempotency(gomock.Any(), begin).Return(domain.IdempotencyRecord{}, false, domain.ErrIdempotencyKeyReused)
+				return idemMock
+			},
+			wantCode: ErrCodeUnprocessable,
+			wantErr:  true,
+		},
+		{
+			name: "first request still running",
+			req:  req,
+			mock: func() IdempotencyResource {
+				idemMock := NewMockIdempotencyResource(ctrl)
+				idemMock.EXPECT().BeginIdempotency(gomock.Any(), begin).Return(domain.IdempotencyRecord{}, false, domain.ErrIdempotencyInProgress)
+				return idemMock
+			},
+			wantCode: ErrCodeConflict,
+			wantErr:  true,
+		},
+		{
+			name: "key too long",
+			req:  BeginIdempotentRequestReq{Scope: "/borrow-book", Key: strings.Repeat("k", 256)},
+			mock: func() IdempotencyResource {
+				return NewMockIdempotencyResource(ctrl)
+			},
+			wantCode: ErrCodeInvalidRequest,
+			wantErr:  true,
+		},
+		{
+			name: "key with spaces",
+			req:  BeginIdempotentRequestReq{Scope: "/borrow-book", Key: "a b"},
+			mock: func() IdempotencyResource {
+				return NewMockIdempotencyResource(ctrl)
+			},
+			wantCode: ErrCodeInvalidRequest,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, _ := NewIdempotencyService(IdempotencyDependencies{
+				IR:  tt.mock(),
+				Cfg: &config.GlobalConfig{Idempotency: config.Idempotency{TTLHours: 2}},
+			})
+			got, err := p.BeginIdempotentRequest(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BeginIdempotentRequest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if se := (*ServiceError)(nil); tt.wantCode != "" && (!errors.As(err, &se) || se.Code != tt.wantCode) {
+				t.Errorf("BeginIdempotentRequest() error = %v, wantCode %v", err, tt.wantCode)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BeginIdempotentRequest() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_CompleteIdempotentRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	idemMock := NewMockIdempotencyResource(ctrl)
+	idemMock.EXPECT().CompleteIdempotency(gomock.Any(), domain.CompleteIdempotencyReq{Scope: "/borrow-book", Key: "3f1c", StatusCode: 200, Body: []byte("{}")}).Return(nil)
+	idemMock.EXPECT().ReleaseIdempotency(gomock.Any(), domain.ReleaseIdempotencyReq{Scope: "/borrow-book", Key: "gone"}).Return(domain.ErrIdempotencyKeyNotFound)
+	p, _ := NewIdempotencyService(IdempotencyDependencies{IR: idemMock})
+
+	if err := p.CompleteIdempotentRequest(context.Background(), CompleteIdempotentRequestReq{Scope: "/borrow-book", Key: "3f1c", StatusCode: 200, Body: []byte("{}")}); err != nil {
+		t.Errorf("CompleteIdempotentRequest() error = %v", err)
+	}
+	err := p.ReleaseIdempotentRequest(context.Background(), ReleaseIdempotentRequestReq{Scope: "/borrow-book", Key: "gone"})
+	if se := (*ServiceError)(nil); !errors.As(err, &se) || se.Code != ErrCodeNotFound {
+		t.Errorf("ReleaseIdempotentRequest() error = %v, want not found", err)
+	}
+}
@@ -0,0 +1,36 @@
+package services
+
+import "gihub.com/gadhittana01/book-project/config"
+
+type IdempotencyDependencies struct {
+	IR  IdempotencyResource
+	Cfg *config.GlobalConfig
+}
+
+// BeginIdempotentRequestReq Scope is the endpoint, Fingerprint identifies the request body sent with Key.
+type BeginIdempotentRequestReq struct {
+	Scope       string `json:"scope"`
+	Key         string `json:"key"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// IdempotentRequestRes with Replay set carries the stored response to send again, otherwise the request runs.
+type IdempotentRequestRes struct {
+	Replay      bool   `json:"replay"`
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+type CompleteIdempotentRequestReq struct {
+	Scope       string `json:"scope"`
+	Key         string `json:"key"`
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+type ReleaseIdempotentRequestReq struct {
+	Scope string `json:"scope"`
+	Key   string `json:"key"`
+}
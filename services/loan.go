@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+func (p bookService) CheckoutBook(ctx context.Context, req CheckoutBookReq) (LoanRes, error) {
+	if req.ReservationID == 0 {
+		return LoanRes{}, invalidRequest("Reservation ID is empty")
+	}
+
+	loan, err := p.br.CheckoutBook(ctx, domain.CheckoutBookReq{
+		ReservationID: req.ReservationID,
+	})
+	if err != nil {
+		return LoanRes{}, wrapDomainError(err)
+	}
+	return newLoanRes(loan), nil
+}
+
+func (p bookService) ReturnBook(ctx context.Context, req ReturnBookReq) (LoanRes, error) {
+	if req.LoanID == 0 {
+		return LoanRes{}, invalidRequest("Loan ID is empty")
+	}
+
+	loan, err := p.br.ReturnBook(ctx, domain.ReturnBookReq{
+		LoanID: req.LoanID,
+	})
+	if err != nil {
+		return LoanRes{}, wrapDomainError(err)
+	}
+	return newLoanRes(loan), nil
+}
+
+func (p bookService) RenewLoan(ctx context.Context, req RenewLoanReq) (LoanRes, error) {
+	if req.LoanID == 0 {
+		return LoanRes{}, invalidRequest("Loan ID is empty")
+	}
+
+	loan, err := p.br.RenewLoan(ctx, domain.RenewLoanReq{
+		LoanID: req.LoanID,
+	})
+	if err != nil {
+		return LoanRes{}, wrapDomainError(err)
+	}
+	return newLoanRes(loan), nil
+}
+
+func (p bookService) GetLoans(ctx context.Context, req GetLoansReq) (map[int][]LoanRes, error) {
+	var result map[int][]LoanRes = make(map[int][]LoanRes)
+
+	res, err := p.br.GetLoans(ctx, domain.GetLoansReq{
+		UserID: req.UserID,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	for key, value := range res {
+		var tmp []LoanRes
+		for _, item := range value {
+			tmp = append(tmp, newLoanRes(item))
+		}
+		result[key] = tmp
+	}
+
+	return result, nil
+}
+
+func newLoanRes(loan domain.Loan) LoanRes {
+	res := LoanRes{
+		ID:            loan.ID,
+		ReservationID: loan.ReservationID,
+		BookKey:       loan.Book.Key,
+		Title:         loan.Book.Title,
+		Branch:        loan.Branch,
+		UserID:        loan.UserID,
+		CheckedOutAt:  loan.CheckedOutAt,
+		DueDate:       loan.DueDate,
+		Renewals:      loan.Renewals,
+		Status:        loan.Status,
+	}
+	if !loan.ReturnedAt.IsZero() {
+		returnedAt := loan.ReturnedAt
+		res.ReturnedAt = &returnedAt
+	}
+	return res
+}
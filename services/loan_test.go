@@ -0,0 +1,272 @@
+package services
+
+import (
+	"context"
+	"errors"
+	reflect "reflect"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+func Test_CheckoutBook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	now := time.Date(2022, 2, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		fields   func() bookService
+		req      CheckoutBookReq
+		want     LoanRes
+		wantCode string
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().CheckoutBook(gomock.Any(), domain.CheckoutBookReq{ReservationID: 3}).Return(domain.Loan{
+					ID:            1,
+					ReservationID: 3,
+					Book:          domain.Book{Key: "123", Title: "hello"},
+					UserID:        2,
+					CheckedOutAt:  now,
+					DueDate:       now.AddDate(0, 0, 14),
+					Status:        domain.LoanStatusOpen,
+				}, nil)
+				return bookService{br: bookMock}
+			},
+			req: CheckoutBookReq{ReservationID: 3},
+			want: LoanRes{
+				ID:            1,
+				ReservationID: 3,
+				BookKey:       "123",
+				Title:         "hello",
+				UserID:        2,
+				CheckedOutAt:  now,
+				DueDate:       now.AddDate(0, 0, 14),
+				Status:        domain.LoanStatusOpen,
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing reservation id",
+			fields: func() bookService {
+				return bookService{br: NewMockBookResource(ctrl)}
+			},
+			req:      CheckoutBookReq{},
+			want:     LoanRes{},
+			wantCode: ErrCodeInvalidRequest,
+			wantErr:  true,
+		},
+		{
+			name: "reservation not active",
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().CheckoutBook(gomock.Any(), gomock.Any()).Return(domain.Loan{}, domain.ErrInvalidReservationState)
+				return bookService{br: bookMock}
+			},
+			req:      CheckoutBookReq{ReservationID: 3},
+			want:     LoanRes{},
+			wantCode: ErrCodeConflict,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.fields()
+			got, err := m.CheckoutBook(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckoutBook() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if se := (*ServiceError)(nil); tt.wantCode != "" && (!errors.As(err, &se) || se.Code != tt.wantCode) {
+				t.Errorf("CheckoutBook() error = %v, want code %v", err, tt.wantCode)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckoutBook() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ReturnBook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	now := time.Date(2022, 2, 10, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		fields   func() bookService
+		req      ReturnBookReq
+		want     LoanRes
+		wantCode string
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().ReturnBook(gomock.Any(), domain.ReturnBookReq{LoanID: 1}).Return(domain.Loan{
+					ID:         1,
+					Book:       domain.Book{Key: "123"},
+					ReturnedAt: now,
+					Status:     domain.LoanStatusReturned,
+				}, nil)
+				return bookService{br: bookMock}
+			},
+			req: ReturnBookReq{LoanID: 1},
+			want: LoanRes{
+				ID:         1,
+				BookKey:    "123",
+				ReturnedAt: &now,
+				Status:     domain.LoanStatusReturned,
+			},
+			wantErr: false,
+		},
+		{
+			name: "loan not found",
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().ReturnBook(gomock.Any(), gomock.Any()).Return(domain.Loan{}, domain.ErrLoanNotFound)
+				return bookService{br: bookMock}
+			},
+			req:      ReturnBookReq{LoanID: 1},
+			want:     LoanRes{},
+			wantCode: ErrCodeNotFound,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.fields()
+			got, err := m.ReturnBook(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReturnBook() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if se := (*ServiceError)(nil); tt.wantCode != "" && (!errors.As(err, &se) || se.Code != tt.wantCode) {
+				t.Errorf("ReturnBook() error = %v, want code %v", err, tt.wantCode)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReturnBook() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_RenewLoan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		fields   func() bookService
+		req      RenewLoanReq
+		want     LoanRes
+		wantCode string
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().RenewLoan(gomock.Any(), domain.RenewLoanReq{LoanID: 1}).Return(domain.Loan{ID: 1, Renewals: 1, Status: domain.LoanStatusOpen}, nil)
+				return bookService{br: bookMock}
+			},
+			req:     RenewLoanReq{LoanID: 1},
+			want:    LoanRes{ID: 1, Renewals: 1, Status: domain.LoanStatusOpen},
+			wantErr: false,
+		},
+		{
+			name: "work on hold",
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().RenewLoan(gomock.Any(), gomock.Any()).Return(domain.Loan{}, domain.ErrWorkOnHold)
+				return bookService{br: bookMock}
+			},
+			req:      RenewLoanReq{LoanID: 1},
+			want:     LoanRes{},
+			wantCode: ErrCodeConflict,
+			wantErr:  true,
+		},
+		{
+			name: "missing loan id",
+			fields: func() bookService {
+				return bookService{br: NewMockBookResource(ctrl)}
+			},
+			req:      RenewLoanReq{},
+			want:     LoanRes{},
+			wantCode: ErrCodeInvalidRequest,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.fields()
+			got, err := m.RenewLoan(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RenewLoan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if se := (*ServiceError)(nil); tt.wantCode != "" && (!errors.As(err, &se) || se.Code != tt.wantCode) {
+				t.Errorf("RenewLoan() error = %v, want code %v", err, tt.wantCode)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RenewLoan() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_GetLoans(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name    string
+		fields  func() bookService
+		req     GetLoansReq
+		want    map[int][]LoanRes
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().GetLoans(gomock.Any(), domain.GetLoansReq{UserID: 2}).Return(map[int][]domain.Loan{
+					2: {{ID: 1, Book: domain.Book{Key: "123"}, UserID: 2, Status: domain.LoanStatusOpen}},
+				}, nil)
+				return bookService{br: bookMock}
+			},
+			req: GetLoansReq{UserID: 2},
+			want: map[int][]LoanRes{
+				2: {{ID: 1, BookKey: "123", UserID: 2, Status: domain.LoanStatusOpen}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error",
+			fields: func() bookService {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().GetLoans(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+				return bookService{br: bookMock}
+			},
+			req:     GetLoansReq{},
+			want:    map[int][]LoanRes{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.fields()
+			got, err := m.GetLoans(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetLoans() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetLoans() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
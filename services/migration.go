@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+const (
+	defaultMigrationLockSec = 300
+	defaultMigrationWaitSec = 120
+)
+
+// migrationLockPoll is how often a replica waiting for the migration lock tries again.
+var migrationLockPoll = time.Second
+
+type Migrator interface {
+	Migrate(ctx context.Context, req MigrateReq) (MigrateRes, error)
+}
+
+type migrator struct {
+	mr     MigrationResource
+	cfg    config.Migration
+	holder string
+}
+
+func NewMigrator(dep MigrationDependencies) (Migrator, error) {
+	svc := &migrator{
+		mr:     dep.MR,
+		holder: dep.Holder,
+	}
+	if dep.Cfg != nil {
+		svc.cfg = dep.Cfg.Migration
+	}
+	if svc.holder == "" {
+		host, _ := os.Hostname()
+		svc.holder = fmt.Sprintf("%s-%d", host, os.Getpid())
+	}
+	return svc, nil
+}
+
+// Migrate applies the pending migrations in version order while holding the migration lock, so replicas starting
+// together migrate once. It stops at the first failure, the migrations before it stay applied.
+func (p migrator) Migrate(ctx context.Context, req MigrateReq) (MigrateRes, error) {
+	result := MigrateRes{
+		DryRun:  req.DryRun,
+		Applied: []MigrationRes{},
+		Pending: []MigrationRes{},
+	}
+
+	migrations, err := p.mr.GetMigrations(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	if req.DryRun {
+		version, pending, err := p.plan(ctx, migrations)
+		if err != nil {
+			return result, err
+		}
+		result.Version = version
+		for _, m := range pending {
+			result.Pending = append(result.Pending, MigrationRes{
+				Version:  m.Version,
+				Name:     m.Name,
+				Checksum: m.Checksum,
+				SQL:      m.SQL,
+			})
+		}
+		return result, nil
+	}
+
+	if err := p.lock(ctx); err != nil {
+		return result, err
+	}
+	defer p.mr.ReleaseMigrationLock(context.Background(), domain.ReleaseMigrationLockReq{
+		Holder: p.holder,
+	})
+
+	// Another replica may have migrated while this one waited, so the plan is made under the lock.
+	version, pending, err := p.plan(ctx, migrations)
+	if err != nil {
+		return result, err
+	}
+	result.Version = version
+	for _, m := range pending {
+		row, err := p.mr.ApplyMigration(ctx, m)
+		if err != nil {
+			return result, wrapDomainError(fmt.Errorf("migration %d %s: %w", m.Version, m.Name, err))
+		}
+		appliedAt := row.AppliedAt
+		result.Applied = append(result.Applied, MigrationRes{
+			Version:   row.Version,
+			Name:      row.Name,
+			Checksum:  row.Checksum,
+			AppliedAt: &appliedAt,
+		})
+		result.Version = row.Version
+	}
+	return result, nil
+}
+
+// plan checks the applied migrations against the embedded ones and returns the schema version and the migrations
+// still to apply. An applied migration that was edited, removed or that a newer one was added below fails the plan.
+func (p migrator) plan(ctx context.Context, migrations []domain.Migration) (int, []domain.Migration, error) {
+	applied, err := p.mr.GetAppliedMigrations(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	known := map[int]domain.Migration{}
+	for _, m := range migrations {
+		known[m.Version] = m
+	}
+	version := 0
+	done := map[int]bool{}
+	for _, row := range applied {
+		m, ok := known[row.Version]
+		if !ok {
+			return 0, nil, wrapDomainError(fmt.Errorf("migration %d %s: %w", row.Version, row.Name, domain.ErrMigrationUnknown))
+		}
+		if m.Checksum != row.Checksum {
+			return 0, nil, wrapDomainError(fmt.Errorf("migration %d %s: %w", row.Version, row.Name, domain.ErrMigrationChecksum))
+		}
+		done[row.Version] = true
+		if row.Version > version {
+			version = row.Version
+		}
+	}
+
+	pending := []domain.Migration{}
+	for _, m := range migrations {
+		if done[m.Version] {
+			continue
+		}
+		if m.Version < version {
+			return 0, nil, wrapDomainError(fmt.Errorf("migration %d %s: %w", m.Version, m.Name, domain.ErrMigrationOutOfOrder))
+		}
+		pending = append(pending, m)
+	}
+	return version, pending, nil
+}
+
+// lock waits up to the configured time for the migration lock.
+func (p migrator) lock(ctx context.Context) error {
+	wait := p.cfg.WaitSec
+	if wait <= 0 {
+		wait = defaultMigrationWaitSec
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(wait)*time.Second)
+	defer cancel()
+
+	for {
+		ok, err := p.mr.AcquireMigrationLock(ctx, domain.AcquireMigrationLockReq{
+			Holder: p.holder,
+			TTL:    p.lockTTL(),
+		})
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return wrapDomainError(domain.ErrMigrationLocked)
+		case <-time.After(migrationLockPoll):
+		}
+	}
+}
+
+func (p migrator) lockTTL() time.Duration {
+	sec := p.cfg.LockSec
+	if sec <= 0 {
+		sec = defaultMigrationLockSec
+	}
+	return time.Duration(sec) * time.Second
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+func Test_Migrate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	migrations := []domain.Migration{
+		{Version: 1, Name: "create_users", SQL: "CREATE TABLE users ();", Checksum: "c1"},
+		{Version: 2, Name: "create_reservations", SQL: "CREATE TABLE reservations ();", Checksum: "c2"},
+	}
+	now := time.Date(2022, 5, 2, 10, 0, 0, 0, time.UTC)
+	lock := domain.AcquireMigrationLockReq{Holder: "replica-1", TTL: defaultMigrationLockSec * time.Second}
+	release := domain.ReleaseMigrationLockReq{Holder: "replica-1"}
+
+	tests := []struct {
+		name        string
+		req         MigrateReq
+		mock        func() MigrationResource
+		wantVersion int
+		wantApplied int
+		wantPending int
+		wantCode    string
+		wantErr     bool
+	}{
+		{
+			name: "dry run lists pending SQL without the lock",
+			req:  MigrateReq{DryRun: true},
+			mock: func() MigrationResource {
+				migMock := NewMockMigrationResource(ctrl)
+				migMock.EXPECT().GetMigrations(gomock.Any()).Return(migrations, nil)
+				migMock.EXPECT().GetAppliedMigrations(gomock.Any()).Return([]domain.AppliedMigration{{Version: 1, Name: "create_users", Checksum: "c1"}}, nil)
+				return migMock
+			},
+			wantVersion: 1,
+			wantPending: 1,
+		},
+		{
+			name: "applies pending migrations in order",
+			mock: func() MigrationResource {
+				migMock := NewMockMigrationResource(ctrl)
+				migMock.EXPECT().GetMigrations(gomock.Any()).Return(migrations, nil)
+				migMock.EXPECT().AcquireMigrationLock(gomock.Any(), lock).Return(true, nil)
+				migMock.EXPECT().GetAppliedMigrations(gomock.Any()).Return([]domain.AppliedMigration{}, nil)
+				gomock.InOrder(
+					migMock.EXPECT().ApplyMigration(gomock.Any(), migrations[0]).Return(domain.AppliedMigration{Version: 1, Name: "create_users", Checksum: "c1", AppliedAt: now}, nil),
+					migMock.EXPECT().ApplyMigration(gomock.Any(), migrations[1]).Return(domain.AppliedMigration{Version: 2, Name: "create_reservations", Checksum: "c2", AppliedAt: now}, nil),
+				)
+				migMock.EXPECT().ReleaseMigrationLock(gomock.Any(), release).Return(nil)
+				return migMock
+			},
+			wantVersion: 2,
+			wantApplied: 2,
+		},
+		{
+			name: "nothing to apply",
+			mock: func() MigrationResource {
+				migMock := NewMockMigrationResource(ctrl)
+				migMock.EXPECT().GetMigrations(gomock.Any()).Return(migrations, nil)
+				migMock.EXPECT().AcquireMigrationLock(gomock.Any(), lock).Return(true, nil)
+				migMock.EXPECT().GetAppliedMigrations(gomock.Any()).Return([]domain.AppliedMigration{
+					{Version: 1, Name: "create_users", Checksum: "c1"},
+					{Version: 2, Name: "create_reservations", Checksum: "c2"},
+				}, nil)
+				migMock.EXPECT().ReleaseMigrationLock(gomock.Any(), release).Return(nil)
+				return migMock
+			},
+			wantVersion: 2,
+		},
+		{
+			name: "applied migration was edited",
+			mock: func() MigrationResource {
+				migMock := NewMockMigrationResource(ctrl)
+				migMock.EXPECT().GetMigrations(gomock.Any()).Return(migrations, nil)
+				migMock.EXPECT().AcquireMigrationLock(gomock.Any(), lock).Return(true, nil)
+				migMock.EXPECT().GetAppliedMigrations(gomock.Any()).Return([]domain.AppliedMigration{{Version: 1, Name: "create_users", Checksum: "old"}}, nil)
+				migMock.EXPECT().ReleaseMigrationLock(gomock.Any(), release).Return(nil)
+				return migMock
+			},
+			wantCode: ErrCodeConflict,
+			wantErr:  true,
+		},
+		{
+			name: "applied migration is not in this build",
+			req:  MigrateReq{DryRun: true},
+			mock: func() MigrationResource {
+				migMock := NewMockMigrationResource(ctrl)
+				migMock.EXPECT().GetMigrations(gomock.Any()).Return(migrations, nil)
+				migMock.EXPECT().GetAppliedMigrations(gomock.Any()).Return([]domain.AppliedMigration{{Version: 3, Name: "create_loans", Checksum: "c3"}}, nil)
+				return migMock
+			},
+			wantCode: ErrCodeConflict,
+			wantErr:  true,
+		},
+		{
+			name: "new migration below the applied version",
+			req:  MigrateReq{DryRun: true},
+			mock: func() MigrationResource {
+				migMock := NewMockMigrationResource(ctrl)
+				migMock.EXPECT().GetMigrations(gomock.Any()).Return(migrations, nil)
+				migMock.EXPECT().GetAppliedMigrations(gomock.Any()).Return([]domain.AppliedMigration{{Version: 2, Name: "create_reservations", Checksum: "c2"}}, nil)
+				return migMock
+			},
+			wantCode: ErrCodeConflict,
+			wantErr:  true,
+		},
+		{
+			name: "failed migration stops the run",
+			mock: func() MigrationResource {
+				migMock := NewMockMigrationResource(ctrl)
+				migMock.EXPECT().GetMigrations(gomock.Any()).Return(migrations, nil)
+				migMock.EXPECT().AcquireMigrationLock(gomock.Any(), lock).Return(true, nil)
+				migMock.EXPECT().GetAppliedMigrations(gomock.Any()).Return([]domain.AppliedMigration{}, nil)
+				migMock.EXPECT().ApplyMigration(gomock.Any(), migrations[0]).Return(domain.AppliedMigration{}, errors.New("syntax error"))
+				migMock.EXPECT().ReleaseMigrationLock(gomock.Any(), release).Return(nil)
+				return migMock
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, _ := NewMigrator(MigrationDependencies{
+				MR:     tt.mock(),
+				Holder: "replica-1",
+			})
+			got, err := p.Migrate(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Migrate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if se := (*ServiceError)(nil); tt.wantCode != "" && (!errors.As(err, &se) || se.Code != tt.wantCode) {
+				t.Errorf("Migrate() error = %v, wantCode %v", err, tt.wantCode)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Version != tt.wantVersion || len(got.Applied) != tt.wantApplied || len(got.Pending) != tt.wantPending {
+				t.Errorf("Migrate() = version %d, %d applied, %d pending, want %d, %d, %d", got.Version, len(got.Applied), len(got.Pending), tt.wantVersion, tt.wantApplied, tt.wantPending)
+			}
+			for _, m := range got.Pending {
+				if m.SQL == "" {
+					t.Errorf("Migrate() pending migration %d has no SQL", m.Version)
+				}
+			}
+		})
+	}
+}
+
+func Test_Migrate_locked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	migrationLockPoll = time.Millisecond
+	defer func() { migrationLockPoll = time.Second }()
+
+	migMock := NewMockMigrationResource(ctrl)
+	migMock.EXPECT().GetMigrations(gomock.Any()).Return([]domain.Migration{{Version: 1, Checksum: "c1"}}, nil)
+	migMock.EXPECT().AcquireMigrationLock(gomock.Any(), gomock.Any()).Return(false, nil).MinTimes(2)
+	p, _ := NewMigrator(MigrationDependencies{MR: migMock, Holder: "replica-2"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := p.Migrate(ctx, MigrateReq{})
+	if se := (*ServiceError)(nil); !errors.As(err, &se) || se.Code != ErrCodeConflict || !errors.Is(err, domain.ErrMigrationLocked) {
+		t.Errorf("Migrate() error = %v, want migration locked", err)
+	}
+}
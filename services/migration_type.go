@@ -0,0 +1,36 @@
+package services
+
+import (
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+)
+
+// MigrationDependencies Holder names this process when it takes the migration lock, hostname and pid by default.
+type MigrationDependencies struct {
+	MR     MigrationResource
+	Cfg    *config.GlobalConfig
+	Holder string
+}
+
+// MigrateReq with DryRun only reports the pending migrations and their SQL.
+type MigrateReq struct {
+	DryRun bool `json:"dry_run"`
+}
+
+// MigrateRes Version is the schema version after the run, Applied the migrations this run applied and Pending
+// those a dry run would apply.
+type MigrateRes struct {
+	DryRun  bool           `json:"dry_run"`
+	Version int            `json:"version"`
+	Applied []MigrationRes `json:"applied"`
+	Pending []MigrationRes `json:"pending"`
+}
+
+type MigrationRes struct {
+	Version   int        `json:"version"`
+	Name      string     `json:"name"`
+	Checksum  string     `json:"checksum"`
+	SQL       string     `json:"sql,omitempty"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"text/template"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+// defaultNotificationTemplates are used for events notification.templates does not override.
+var defaultNotificationTemplates = map[string]config.NotificationTemplate{
+	domain.NotifyReservationConfirmed: {
+		Subject: "Reservation confirmed: {{.Title}}",
+		Body:    "Hi {{.UserName}},\n\n{{.Title}} is reserved for you. Pick it up at {{.Branch}} on {{.PickUpDate}}{{if .PickUpSlot}} at {{.PickUpSlot}}{{end}}.\n\nReservation #{{.ReservationID}}",
+	},
+	domain.NotifyPickupReminder: {
+		Subject: "Reminder: pick up {{.Title}} tomorrow",
+		Body:    "Hi {{.UserName}},\n\nDon't forget to pick up {{.Title}} at {{.Branch}} tomorrow, {{.PickUpDate}}{{if .PickUpSlot}} at {{.PickUpSlot}}{{end}}.\n\nReservation #{{.ReservationID}}",
+	},
+	domain.NotifyReservationCancelled: {
+		Subject: "Reservation cancelled: {{.Title}}",
+		Body:    "Hi {{.UserName}},\n\nYour reservation of {{.Title}} for {{.PickUpDate}} at {{.Branch}} has been cancelled.\n\nReservation #{{.ReservationID}}",
+	},
+	domain.NotifyHoldReady: {
+		Subject: "Your hold is ready: {{.Title}}",
+		Body:    "Hi {{.UserName}},\n\n{{.Title}} from the waitlist is ready for you at {{.Branch}} on {{.PickUpDate}}{{if .PickUpSlot}} at {{.PickUpSlot}}{{end}}. Confirm it by {{.ConfirmBy}} or it goes to the next user in the queue.\n\nReservation #{{.ReservationID}}",
+	},
+}
+
+const notificationTimeLayout = "2006-01-02 15:04 MST"
+
+// notificationData is what notification templates can use.
+type notificationData struct {
+	ReservationID int
+	UserName      string
+	Title         string
+	Branch        string
+	PickUpDate    string
+	PickUpSlot    string
+	ConfirmBy     string
+}
+
+type notificationTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+// notificationSender renders reservation notifications and hands them to a Notifier.
+type notificationSender struct {
+	notifier  Notifier
+	ur        UserResource
+	templates map[string]notificationTemplate
+}
+
+// NewNotificationHandler returns the EventHandler that notifies users about their reservations.
+func NewNotificationHandler(dep NotificationDependencies) (EventHandler, error) {
+	if dep.Notifier == nil {
+		return nil, errors.New("notification handler needs a notifier")
+	}
+
+	var cfg config.Notification
+	if dep.Cfg != nil {
+		cfg = dep.Cfg.Notification
+	}
+	return newNotificationSender(dep.Notifier, dep.UR, cfg)
+}
+
+func newNotificationSender(n Notifier, ur UserResource, cfg config.Notification) (*notificationSender, error) {
+	s := &notificationSender{
+		notifier:  n,
+		ur:        ur,
+		templates: map[string]notificationTemplate{},
+	}
+	for event, t := range defaultNotificationTemplates {
+		if custom, ok := cfg.Templates[event]; ok {
+			if custom.Subject != "" {
+				t.Subject = custom.Subject
+			}
+			if custom.Body != "" {
+				t.Body = custom.Body
+			}
+		}
+
+		subject, err := template.New(event + " subject").Parse(t.Subject)
+		if err != nil {
+			return nil, fmt.Errorf("notification template %s: %w", event, err)
+		}
+		body, err := template.New(event + " body").Parse(t.Body)
+		if err != nil {
+			return nil, fmt.Errorf("notification template %s: %w", event, err)
+		}
+		s.templates[event] = notificationTemplate{subject: subject, body: body}
+	}
+	return s, nil
+}
+
+// HandleEvent sends the confirmation, hold ready and cancellation notices of reservation events. A hold offered from
+// the waitlist is confirmed once the user confirms it. Failed deliveries are retried by the outbox dispatcher.
+func (s *notificationSender) HandleEvent(ctx context.Context, event domain.OutboxEvent) error {
+	switch {
+	case event.Type == domain.EventReservationCreated && event.Reservation.Status == domain.ReservationStatusActive:
+		return s.deliver(ctx, domain.NotifyReservationConfirmed, event.Reservation)
+	case event.Type == domain.EventReservationCreated && event.Reservation.Status == domain.ReservationStatusProvisional:
+		return s.deliver(ctx, domain.NotifyHoldReady, event.Reservation)
+	case event.Type == domain.EventReservationConfirmed:
+		return s.deliver(ctx, domain.NotifyReservationConfirmed, event.Reservation)
+	case event.Type == domain.EventReservationCancelled:
+		return s.deliver(ctx, domain.NotifyReservationCancelled, event.Reservation)
+	}
+	return nil
+}
+
+func (s *notificationSender) deliver(ctx context.Context, event string, res domain.BorrowBookReq) error {
+	msg, ok, err := s.render(ctx, event, res)
+	if err != nil || !ok {
+		return err
+	}
+	return s.notifier.Notify(ctx, msg)
+}
+
+// render builds the notification for res, ok is false when the user opted out.
+func (s *notificationSender) render(ctx context.Context, event string, res domain.BorrowBookReq) (domain.Notification, bool, error) {
+	t, found := s.templates[event]
+	if !found {
+		return domain.Notification{}, false, fmt.Errorf("no template for event %s", event)
+	}
+
+	var user domain.User
+	if s.ur != nil {
+		var err error
+		user, err = s.ur.GetUser(ctx, domain.GetUserReq{
+			ID: res.UserID,
+		})
+		if err != nil {
+			return domain.Notification{}, false, err
+		}
+		if user.NotifyOptOut {
+			return domain.Notification{}, false, nil
+		}
+	}
+
+	data := notificationData{
+		ReservationID: res.ID,
+		UserName:      user.Name,
+		Title:         res.Book.Title,
+		Branch:        res.Branch,
+		PickUpDate:    res.PickUpDate,
+		PickUpSlot:    res.PickUpSlot,
+	}
+	if !res.ConfirmBy.IsZero() {
+		data.ConfirmBy = res.ConfirmBy.Format(notificationTimeLayout)
+	}
+	var subject, body bytes.Buffer
+	if err := t.subject.Execute(&subject, data); err != nil {
+		return domain.Notification{}, false, err
+	}
+	if err := t.body.Execute(&body, data); err != nil {
+		return domain.Notification{}, false, err
+	}
+
+	return domain.Notification{
+		Event:         event,
+		UserID:        res.UserID,
+		ReservationID: res.ID,
+		To:            user.Email,
+		Subject:       subject.String(),
+		Body:          body.String(),
+	}, true, nil
+}
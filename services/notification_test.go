@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+func Test_notificationSender(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	res := domain.BorrowBookReq{
+		ID:         4,
+		Book:       domain.Book{Key: "123", Title: "Matilda"},
+		Branch:     "central",
+		PickUpDate: "2022-01-24",
+		PickUpSlot: "09:30",
+		UserID:     1,
+	}
+	cfg := config.Notification{
+		Templates: map[string]config.NotificationTemplate{
+			domain.NotifyReservationCancelled: {Subject: "{{.Title}} is off"},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		event string
+		user  domain.User
+		err   error
+		want  *domain.Notification
+	}{
+		{
+			name:  "default template",
+			event: domain.NotifyReservationConfirmed,
+			user:  domain.User{ID: 1, Name: "Giri", Email: "giri@example.com"},
+			want: &domain.Notification{
+				Event:         domain.NotifyReservationConfirmed,
+				UserID:        1,
+				ReservationID: 4,
+				To:            "giri@example.com",
+				Subject:       "Reservation confirmed: Matilda",
+				Body:          "Hi Giri,\n\nMatilda is reserved for you. Pick it up at central on 2022-01-24 at 09:30.\n\nReservation #4",
+			},
+		},
+		{
+			name:  "configured subject keeps the default body",
+			event: domain.NotifyReservationCancelled,
+			user:  domain.User{ID: 1, Name: "Giri", Email: "giri@example.com"},
+			want: &domain.Notification{
+				Event:         domain.NotifyReservationCancelled,
+				UserID:        1,
+				ReservationID: 4,
+				To:            "giri@example.com",
+				Subject:       "Matilda is off",
+				Body:          "Hi Giri,\n\nYour reservation of Matilda for 2022-01-24 at central has been cancelled.\n\nReservation #4",
+			},
+		},
+		{
+			name:  "opted out",
+			event: domain.NotifyPickupReminder,
+			user:  domain.User{ID: 1, Email: "giri@example.com", NotifyOptOut: true},
+		},
+		{
+			name:  "unknown user",
+			event: domain.NotifyPickupReminder,
+			err:   domain.ErrUserNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userMock := NewMockUserResource(ctrl)
+			userMock.EXPECT().GetUser(gomock.Any(), domain.GetUserReq{ID: 1}).Return(tt.user, tt.err)
+			notifierMock := NewMockNotifier(ctrl)
+			if tt.want != nil {
+				notifierMock.EXPECT().Notify(gomock.Any(), *tt.want).Return(nil)
+			}
+
+			s, err := newNotificationSender(notifierMock, userMock, cfg)
+			if err != nil {
+				t.Fatalf("newNotificationSender() error = %v", err)
+			}
+			if err := s.deliver(context.Background(), tt.event, res); !errors.Is(err, tt.err) {
+				t.Errorf("deliver() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func Test_newNotificationSenderBadTemplate(t *testing.T) {
+	_, err := newNotificationSender(NewMockNotifier(gomock.NewController(t)), nil, config.Notification{
+		Templates: map[string]config.NotificationTemplate{
+			domain.NotifyPickupReminder: {Body: "{{.Title"},
+		},
+	})
+	if err == nil {
+		t.Errorf("newNotificationSender() error = nil, want template error")
+	}
+}
+
+func Test_Remind(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	bookMock := NewMockBookResource(ctrl)
+	bookMock.EXPECT().ClaimReminders(gomock.Any(), domain.ClaimRemindersReq{PickUpDate: "2022-01-25"}).Return([]domain.BorrowBookReq{
+		{ID: 4, Book: domain.Book{Title: "Matilda"}, Branch: "central", PickUpDate: "2022-01-25", UserID: 1},
+		{ID: 5, Book: domain.Book{Title: "Matilda"}, Branch: "north", PickUpDate: "2022-01-25", UserID: 1},
+	}, nil)
+	userMock := NewMockUserResource(ctrl)
+	userMock.EXPECT().GetUser(gomock.Any(), domain.GetUserReq{ID: 1}).Return(domain.User{ID: 1, Name: "Giri", Email: "giri@example.com"}, nil).Times(2)
+	notifierMock := NewMockNotifier(ctrl)
+	notifierMock.EXPECT().Notify(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, msg domain.Notification) error {
+		if msg.Event != domain.NotifyPickupReminder || msg.Subject != "Reminder: pick up Matilda tomorrow" {
+			t.Errorf("Notify() msg = %+v", msg)
+		}
+		if msg.ReservationID == 5 {
+			return errors.New("smtp down")
+		}
+		return nil
+	}).Times(2)
+	// The failed reminder is handed back to be sent again on the next run.
+	bookMock.EXPECT().ReleaseReminder(gomock.Any(), domain.ReleaseReminderReq{ID: 5}).Return(nil)
+
+	got, err := NewReminder(ReminderDependencies{BR: bookMock, UR: userMock, Notifier: notifierMock})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := got.(*reminder)
+	r.timeNow = func() time.Time { return time.Date(2022, 1, 24, 8, 0, 0, 0, time.UTC) }
+
+	res, err := r.Remind(context.Background())
+	if err != nil || res.Reminded != 1 || res.PickUpDate != "2022-01-25" {
+		t.Errorf("Remind() = %+v, %v", res, err)
+	}
+
+	if _, err := NewReminder(ReminderDependencies{BR: bookMock}); err == nil {
+		t.Errorf("NewReminder() without notifier error = nil")
+	}
+}
+
+func Test_notificationHandleEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	res := domain.BorrowBookReq{ID: 9, Book: domain.Book{Key: "123", Title: "Matilda"}, Branch: "central", UserID: 1, Status: domain.ReservationStatusActive}
+	provisional := res
+	provisional.Status = domain.ReservationStatusProvisional
+	provisional.ConfirmBy = time.Date(2022, 1, 24, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		event     domain.OutboxEvent
+		wantEvent string
+		wantBody  string
+		notifyErr error
+		wantErr   bool
+	}{
+		{
+			name:      "created active reservation is confirmed",
+			event:     domain.OutboxEvent{Type: domain.EventReservationCreated, Reservation: res},
+			wantEvent: domain.NotifyReservationConfirmed,
+		},
+		{
+			name:      "cancellation",
+			event:     domain.OutboxEvent{Type: domain.EventReservationCancelled, Reservation: res},
+			wantEvent: domain.NotifyReservationCancelled,
+		},
+		{
+			name:      "failed delivery is reported for a retry",
+			event:     domain.OutboxEvent{Type: domain.EventReservationCancelled, Reservation: res},
+			wantEvent: domain.NotifyReservationCancelled,
+			notifyErr: errors.New("smtp down"),
+			wantErr:   true,
+		},
+		{
+			name:      "provisional hold is ready",
+			event:     domain.OutboxEvent{Type: domain.EventReservationCreated, Reservation: provisional},
+			wantEvent: domain.NotifyHoldReady,
+			wantBody:  "Confirm it by 2022-01-24 12:00 UTC",
+		},
+		{
+			name:      "confirmed hold",
+			event:     domain.OutboxEvent{Type: domain.EventReservationConfirmed, Reservation: res},
+			wantEvent: domain.NotifyReservationConfirmed,
+		},
+		{
+			name:  "other events send nothing",
+			event: domain.OutboxEvent{Type: domain.EventReservationPickedUp, Reservation: res},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userMock := NewMockUserResource(ctrl)
+			notifierMock := NewMockNotifier(ctrl)
+			if tt.wantEvent != "" {
+				userMock.EXPECT().GetUser(gomock.Any(), domain.GetUserReq{ID: 1}).Return(domain.User{ID: 1, Email: "giri@example.com"}, nil)
+				notifierMock.EXPECT().Notify(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, msg domain.Notification) error {
+					if msg.Event != tt.wantEvent || msg.ReservationID != 9 || msg.To != "giri@example.com" || !strings.Contains(msg.Body, tt.wantBody) {
+						t.Errorf("Notify() msg = %+v", msg)
+					}
+					return tt.notifyErr
+				})
+			}
+
+			h, err := NewNotificationHandler(NotificationDependencies{UR: userMock, Notifier: notifierMock})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := h.HandleEvent(context.Background(), tt.event); (err != nil) != tt.wantErr {
+				t.Errorf("HandleEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := NewNotificationHandler(NotificationDependencies{}); err == nil {
+		t.Errorf("NewNotificationHandler() without notifier error = nil")
+	}
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"log"
+	"strings"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+const (
+	defaultOutboxIntervalMS   = 1000
+	defaultOutboxMaxAttempts  = 8
+	defaultOutboxBackoffMS    = 2000
+	defaultOutboxMaxBackoffMS = 300000
+	defaultOutboxClaimSec     = 60
+)
+
+// Outbox metrics are published on /debug/vars.
+var (
+	outboxDelivered = expvar.NewInt("outbox_delivered_total")
+	outboxRetried   = expvar.NewInt("outbox_retried_total")
+	outboxDead      = expvar.NewInt("outbox_dead_total")
+	outboxErrors    = expvar.NewInt("outbox_errors")
+)
+
+// Dispatcher delivers reservation events from the outbox to the event handlers.
+type Dispatcher interface {
+	Run(ctx context.Context)
+	Dispatch(ctx context.Context) (DispatchRes, error)
+}
+
+type dispatcher struct {
+	br       BookResource
+	handlers []EventHandler
+	cfg      config.Outbox
+	timeNow  func() time.Time
+}
+
+func NewDispatcher(dep DispatcherDependencies) (Dispatcher, error) {
+	if len(dep.Handlers) == 0 {
+		return nil, errors.New("dispatcher needs at least one event handler")
+	}
+
+	svc := &dispatcher{
+		br:       dep.BR,
+		handlers: dep.Handlers,
+		timeNow:  time.Now,
+	}
+	if dep.Cfg != nil {
+		svc.cfg = dep.Cfg.Outbox
+	}
+	return svc, nil
+}
+
+// Run dispatches every configured interval until ctx is done.
+func (p dispatcher) Run(ctx context.Context) {
+	ms := p.cfg.IntervalMS
+	if ms <= 0 {
+		ms = defaultOutboxIntervalMS
+	}
+	ticker := time.NewTicker(time.Duration(ms) * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		if res, err := p.Dispatch(ctx); err != nil {
+			log.Println("outbox dispatcher:", err)
+		} else if res.Dead > 0 {
+			log.Printf("outbox dispatcher: dead-lettered %d events", res.Dead)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Dispatch hands the due outbox events to every handler once. An event is acknowledged only after all handlers
+// took it, a failure retries it for all of them with exponential backoff until it is dead-lettered.
+func (p dispatcher) Dispatch(ctx context.Context) (DispatchRes, error) {
+	claimSec := p.cfg.ClaimSec
+	if claimSec <= 0 {
+		claimSec = defaultOutboxClaimSec
+	}
+	events, err := p.br.ClaimOutbox(ctx, domain.ClaimOutboxReq{
+		Limit: p.cfg.BatchSize,
+		Lease: time.Duration(claimSec) * time.Second,
+	})
+	if err != nil {
+		outboxErrors.Add(1)
+		return DispatchRes{}, err
+	}
+
+	result := DispatchRes{
+		Claimed: len(events),
+	}
+	for _, event := range events {
+		ack := domain.AckOutboxReq{
+			ID: event.ID,
+		}
+		if err := p.handle(ctx, event); err != nil {
+			ack.Error = err.Error()
+			if attempts := event.Attempts + 1; attempts >= p.maxAttempts() {
+				ack.Dead = true
+				result.Dead++
+			} else {
+				ack.RetryAt = p.timeNow().Add(p.backoff(attempts))
+				result.Retried++
+			}
+		} else {
+			result.Delivered++
+		}
+
+		if err := p.br.AckOutbox(ctx, ack); err != nil {
+			outboxErrors.Add(1)
+			return result, err
+		}
+	}
+	outboxDelivered.Add(int64(result.Delivered))
+	outboxRetried.Add(int64(result.Retried))
+	outboxDead.Add(int64(result.Dead))
+	return result, nil
+}
+
+// handle gives event to every handler, even after one of them failed, and reports all failures together.
+func (p dispatcher) handle(ctx context.Context, event domain.OutboxEvent) error {
+	var failures []string
+	for _, h := range p.handlers {
+		if err := h.HandleEvent(ctx, event); err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+func (p dispatcher) maxAttempts() int {
+	if p.cfg.MaxAttempts > 0 {
+		return p.cfg.MaxAttempts
+	}
+	return defaultOutboxMaxAttempts
+}
+
+// backoff doubles the configured delay with every failed attempt, up to the configured maximum.
+func (p dispatcher) backoff(attempts int) time.Duration {
+	base, ceiling := p.cfg.BackoffMS, p.cfg.MaxBackoffMS
+	if base <= 0 {
+		base = defaultOutboxBackoffMS
+	}
+	if ceiling <= 0 {
+		ceiling = defaultOutboxMaxBackoffMS
+	}
+	return exponentialBackoff(time.Duration(base)*time.Millisecond, time.Duration(ceiling)*time.Millisecond, attempts)
+}
+
+// exponentialBackoff returns base after the first failed attempt and doubles it after every other one, up to limit.
+func exponentialBackoff(base, limit time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < limit; i++ {
+		delay *= 2
+	}
+	if delay > limit {
+		delay = limit
+	}
+	return delay
+}
+
+func (p bookService) GetOutboxEvents(ctx context.Context, req GetOutboxEventsReq) ([]OutboxEventRes, error) {
+	switch req.Status {
+	case "", domain.OutboxStatusPending, domain.OutboxStatusDelivered, domain.OutboxStatusDead:
+	default:
+		return nil, invalidRequest("Status must be pending, delivered or dead")
+	}
+
+	events, err := p.br.GetOutbox(ctx, domain.GetOutboxReq{
+		Status: req.Status,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := []OutboxEventRes{}
+	for _, event := range events {
+		result = append(result, newOutboxEventRes(event))
+	}
+	return result, nil
+}
+
+func (p bookService) RequeueOutboxEvent(ctx context.Context, req RequeueOutboxEventReq) (OutboxEventRes, error) {
+	if req.ID == 0 {
+		return OutboxEventRes{}, invalidRequest("Event ID is empty")
+	}
+
+	event, err := p.br.RequeueOutbox(ctx, domain.RequeueOutboxReq{
+		ID: req.ID,
+	})
+	if err != nil {
+		return OutboxEventRes{}, wrapDomainError(err)
+	}
+	return newOutboxEventRes(event), nil
+}
+
+func newOutboxEventRes(event domain.OutboxEvent) OutboxEventRes {
+	result := OutboxEventRes{
+		ID:            event.ID,
+		Type:          event.Type,
+		ReservationID: event.Reservation.ID,
+		UserID:        event.Reservation.UserID,
+		Branch:        event.Reservation.Branch,
+		Status:        event.Status,
+		Attempts:      event.Attempts,
+		LastError:     event.LastError,
+		NextAttemptAt: event.NextAttemptAt,
+		CreatedAt:     event.CreatedAt,
+	}
+	if !event.DeliveredAt.IsZero() {
+		deliveredAt := event.DeliveredAt
+		result.DeliveredAt = &deliveredAt
+	}
+	return result
+}
+
+// newReservationEvent is how reservation events are published outside the service.
+func newReservationEvent(event domain.OutboxEvent) ReservationEventRes {
+	res := event.Reservation
+	return ReservationEventRes{
+		ID:         event.ID,
+		Type:       event.Type,
+		OccurredAt: event.CreatedAt,
+		Reservation: ReservationEventData{
+			ID:         res.ID,
+			BookKey:    res.Book.Key,
+			Title:      res.Book.Title,
+			Branch:     res.Branch,
+			PickUpDate: res.PickUpDate,
+			PickUpSlot: res.PickUpSlot,
+			UserID:     res.UserID,
+			Status:     res.Status,
+		},
+	}
+}
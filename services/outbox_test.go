@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+type eventHandlerFunc func(ctx context.Context, event domain.OutboxEvent) error
+
+func (f eventHandlerFunc) HandleEvent(ctx context.Context, event domain.OutboxEvent) error {
+	return f(ctx, event)
+}
+
+func Test_Dispatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	now := time.Date(2022, 1, 24, 8, 0, 0, 0, time.UTC)
+	cfg := config.Outbox{BatchSize: 10, MaxAttempts: 3, BackoffMS: 1000, MaxBackoffMS: 60000, ClaimSec: 30}
+	failing := eventHandlerFunc(func(ctx context.Context, event domain.OutboxEvent) error {
+		if event.Reservation.ID == 2 {
+			return errors.New("endpoint down")
+		}
+		return nil
+	})
+
+	tests := []struct {
+		name    string
+		fields  func() dispatcher
+		want    DispatchRes
+		wantErr bool
+	}{
+		{
+			name: "delivered, retried and dead-lettered",
+			fields: func() dispatcher {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().ClaimOutbox(gomock.Any(), domain.ClaimOutboxReq{Limit: 10, Lease: 30 * time.Second}).Return([]domain.OutboxEvent{
+					{ID: 1, Reservation: domain.BorrowBookReq{ID: 1}},
+					{ID: 2, Reservation: domain.BorrowBookReq{ID: 2}, Attempts: 1},
+					{ID: 3, Reservation: domain.BorrowBookReq{ID: 2}, Attempts: 2},
+				}, nil)
+				gomock.InOrder(
+					bookMock.EXPECT().AckOutbox(gomock.Any(), domain.AckOutboxReq{ID: 1}).Return(nil),
+					bookMock.EXPECT().AckOutbox(gomock.Any(), domain.AckOutboxReq{ID: 2, Error: "endpoint down", RetryAt: now.Add(2 * time.Second)}).Return(nil),
+					bookMock.EXPECT().AckOutbox(gomock.Any(), domain.AckOutboxReq{ID: 3, Error: "endpoint down", Dead: true}).Return(nil),
+				)
+				return dispatcher{br: bookMock, handlers: []EventHandler{failing}, cfg: cfg, timeNow: func() time.Time { return now }}
+			},
+			want:    DispatchRes{Claimed: 3, Delivered: 1, Retried: 1, Dead: 1},
+			wantErr: false,
+		},
+		{
+			name: "claim error",
+			fields: func() dispatcher {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().ClaimOutbox(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+				return dispatcher{br: bookMock, handlers: []EventHandler{failing}, cfg: cfg, timeNow: func() time.Time { return now }}
+			},
+			want:    DispatchRes{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fields().Dispatch(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Dispatch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Dispatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := NewDispatcher(DispatcherDependencies{}); err == nil {
+		t.Errorf("NewDispatcher() without handlers error = nil")
+	}
+}
+
+func Test_dispatcherBackoff(t *testing.T) {
+	p := dispatcher{cfg: config.Outbox{BackoffMS: 1000, MaxBackoffMS: 5000}}
+	for attempts, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 10: 5 * time.Second} {
+		if got := p.backoff(attempts); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
+
+func Test_RequeueOutboxEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		req      RequeueOutboxEventReq
+		mock     func() BookResource
+		wantCode string
+	}{
+		{
+			name: "success",
+			req:  RequeueOutboxEventReq{ID: 3},
+			mock: func() BookResource {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().RequeueOutbox(gomock.Any(), domain.RequeueOutboxReq{ID: 3}).Return(domain.OutboxEvent{ID: 3, Status: domain.OutboxStatusPending}, nil)
+				return bookMock
+			},
+		},
+		{
+			name: "missing id",
+			req:  RequeueOutboxEventReq{},
+			mock: func() BookResource {
+				return NewMockBookResource(ctrl)
+			},
+			wantCode: ErrCodeInvalidRequest,
+		},
+		{
+			name: "not dead",
+			req:  RequeueOutboxEventReq{ID: 3},
+			mock: func() BookResource {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().RequeueOutbox(gomock.Any(), gomock.Any()).Return(domain.OutboxEvent{}, domain.ErrOutboxEventNotDead)
+				return bookMock
+			},
+			wantCode: ErrCodeConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := bookService{br: tt.mock()}
+			_, err := p.RequeueOutboxEvent(context.Background(), tt.req)
+			if (err != nil) != (tt.wantCode != "") {
+				t.Errorf("RequeueOutboxEvent() error = %v, wantCode %v", err, tt.wantCode)
+				return
+			}
+			if se := (*ServiceError)(nil); tt.wantCode != "" && (!errors.As(err, &se) || se.Code != tt.wantCode) {
+				t.Errorf("RequeueOutboxEvent() error = %v, wantCode %v", err, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+)
+
+// DispatcherDependencies Handlers get every reservation event in the order they are listed.
+type DispatcherDependencies struct {
+	BR       BookResource
+	Cfg      *config.GlobalConfig
+	Handlers []EventHandler
+}
+
+type NotificationDependencies struct {
+	UR       UserResource
+	Notifier Notifier
+	Cfg      *config.GlobalConfig
+}
+
+type DispatchRes struct {
+	Claimed   int `json:"claimed"`
+	Delivered int `json:"delivered"`
+	Retried   int `json:"retried"`
+	Dead      int `json:"dead"`
+}
+
+type GetOutboxEventsReq struct {
+	Status string `json:"status"`
+}
+
+type RequeueOutboxEventReq struct {
+	ID int `json:"id"`
+}
+
+type OutboxEventRes struct {
+	ID            int        `json:"id"`
+	Type          string     `json:"type"`
+	ReservationID int        `json:"reservation_id"`
+	UserID        int        `json:"user_id"`
+	Branch        string     `json:"branch"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	LastError     string     `json:"last_error,omitempty"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	DeliveredAt   *time.Time `json:"delivered_at,omitempty"`
+}
+
+// ReservationEventRes ID is the outbox event ID, it grows with every event.
+type ReservationEventRes struct {
+	ID          int                  `json:"id"`
+	Type        string               `json:"type"`
+	OccurredAt  time.Time            `json:"occurred_at"`
+	Reservation ReservationEventData `json:"reservation"`
+}
+
+type ReservationEventData struct {
+	ID         int    `json:"id"`
+	BookKey    string `json:"key"`
+	Title      string `json:"title"`
+	Branch     string `json:"branch"`
+	PickUpDate string `json:"pickup_date"`
+	PickUpSlot string `json:"pickup_slot,omitempty"`
+	UserID     int    `json:"user_id"`
+	Status     string `json:"status"`
+}
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+// The borrowing policies are checked by the store, under the same lock that stores the reservation.
+const (
+	PolicyMaxActiveReservations = domain.PolicyMaxActiveReservations
+	PolicyMaxPerSubject         = domain.PolicyMaxPerSubject
+	PolicyOnePerWork            = domain.PolicyOnePerWork
+	PolicyBlockedUser           = domain.PolicyBlockedUser
+)
+
+const policyDeniedMessage = "Reservation denied by borrowing policy"
+
+// policyDenied describes every policy that denied a reservation as a detail of a forbidden ServiceError.
+func policyDenied(err *domain.PolicyError) *ServiceError {
+	details := make([]ErrorDetail, 0, len(err.Violations))
+	for _, v := range err.Violations {
+		details = append(details, ErrorDetail{
+			Rule:   v.Rule,
+			Reason: v.Reason,
+		})
+	}
+	return &ServiceError{
+		Code:    ErrCodeForbidden,
+		Message: policyDeniedMessage,
+		Details: details,
+		Err:     err,
+	}
+}
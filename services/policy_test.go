@@ -0,0 +1,33 @@
+package services
+
+import (
+	"errors"
+	reflect "reflect"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+func Test_wrapDomainErrorPolicy(t *testing.T) {
+	err := wrapDomainError(&domain.PolicyError{
+		Violations: []domain.PolicyViolation{
+			{Rule: domain.PolicyBlockedUser, Reason: "User is blocked from borrowing"},
+			{Rule: domain.PolicyOnePerWork, Reason: "User already has a reservation for book with key /works/OL1W"},
+		},
+	})
+
+	se := &ServiceError{}
+	if !errors.As(err, &se) || se.Code != ErrCodeForbidden || se.Message != policyDeniedMessage {
+		t.Fatalf("wrapDomainError() = %v, want forbidden", err)
+	}
+	want := []ErrorDetail{
+		{Rule: PolicyBlockedUser, Reason: "User is blocked from borrowing"},
+		{Rule: PolicyOnePerWork, Reason: "User already has a reservation for book with key /works/OL1W"},
+	}
+	if !reflect.DeepEqual(se.Details, want) {
+		t.Errorf("wrapDomainError() details = %+v, want %+v", se.Details, want)
+	}
+	if !errors.Is(err, domain.ErrPolicyDenied) {
+		t.Errorf("wrapDomainError() = %v, want it to wrap %v", err, domain.ErrPolicyDenied)
+	}
+}
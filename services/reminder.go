@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+const (
+	pickUpDateLayout = "2006-01-02"
+
+	defaultReminderIntervalSec = 3600
+)
+
+// Reminder sends day-before pickup reminders.
+type Reminder interface {
+	Run(ctx context.Context)
+	Remind(ctx context.Context) (RemindRes, error)
+}
+
+type reminder struct {
+	br       BookResource
+	notify   *notificationSender
+	interval time.Duration
+	timeNow  func() time.Time
+}
+
+func NewReminder(dep ReminderDependencies) (Reminder, error) {
+	if dep.Notifier == nil {
+		return nil, errors.New("reminder needs a notifier")
+	}
+
+	var cfg config.Notification
+	if dep.Cfg != nil {
+		cfg = dep.Cfg.Notification
+	}
+	notify, err := newNotificationSender(dep.Notifier, dep.UR, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	sec := cfg.ReminderIntervalSec
+	if sec <= 0 {
+		sec = defaultReminderIntervalSec
+	}
+	return &reminder{
+		br:       dep.BR,
+		notify:   notify,
+		interval: time.Duration(sec) * time.Second,
+		timeNow:  time.Now,
+	}, nil
+}
+
+// Run reminds every configured interval until ctx is done.
+func (p reminder) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.Remind(ctx); err != nil {
+			log.Println("pickup reminder:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Remind notifies the users picking up tomorrow that were not reminded yet. A reminder that could not be delivered
+// is released and tried again on the next run.
+func (p reminder) Remind(ctx context.Context) (RemindRes, error) {
+	result := RemindRes{
+		PickUpDate: p.timeNow().AddDate(0, 0, 1).Format(pickUpDateLayout),
+	}
+
+	claimed, err := p.br.ClaimReminders(ctx, domain.ClaimRemindersReq{
+		PickUpDate: result.PickUpDate,
+	})
+	if err != nil {
+		return result, err
+	}
+	for _, res := range claimed {
+		if err := p.notify.deliver(ctx, domain.NotifyPickupReminder, res); err != nil {
+			log.Printf("notification %s for reservation %d: %v", domain.NotifyPickupReminder, res.ID, err)
+			if err := p.br.ReleaseReminder(ctx, domain.ReleaseReminderReq{ID: res.ID}); err != nil {
+				log.Printf("release reminder of reservation %d: %v", res.ID, err)
+			}
+			continue
+		}
+		result.Reminded++
+	}
+	return result, nil
+}
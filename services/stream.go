@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"expvar"
+	"sync"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+const (
+	defaultReplayBuffer = 1000
+	defaultClientBuffer = 64
+)
+
+// Reservation stream metrics are published on /debug/vars.
+var (
+	streamSubscribers = expvar.NewInt("reservation_stream_subscribers")
+	streamDropped     = expvar.NewInt("reservation_stream_dropped_subscribers")
+)
+
+// ReservationStream fans reservation events out to live subscribers. It is fed as an EventHandler of the outbox
+// dispatcher and keeps the most recent events so reconnecting clients can resume.
+type ReservationStream interface {
+	EventHandler
+	SubscribeReservationEvents(ctx context.Context, req SubscribeReservationEventsReq) (ReservationEventSubscription, error)
+}
+
+type reservationStream struct {
+	mu           sync.Mutex
+	buffer       []ReservationEventRes
+	replaySize   int
+	clientBuffer int
+	subscribers  map[*streamSubscriber]bool
+}
+
+type streamSubscriber struct {
+	userID int
+	branch string
+	events chan ReservationEventRes
+}
+
+func NewReservationStream(dep ReservationStreamDependencies) (ReservationStream, error) {
+	svc := &reservationStream{
+		replaySize:   defaultReplayBuffer,
+		clientBuffer: defaultClientBuffer,
+		subscribers:  map[*streamSubscriber]bool{},
+	}
+	if dep.Cfg != nil {
+		if dep.Cfg.EventStream.ReplayBuffer > 0 {
+			svc.replaySize = dep.Cfg.EventStream.ReplayBuffer
+		}
+		if dep.Cfg.EventStream.ClientBuffer > 0 {
+			svc.clientBuffer = dep.Cfg.EventStream.ClientBuffer
+		}
+	}
+	return svc, nil
+}
+
+// HandleEvent buffers the event and sends it to every matching subscriber. An event the outbox delivers twice is
+// only sent once, and a subscriber that cannot keep up is disconnected instead of holding up the others.
+func (p *reservationStream) HandleEvent(ctx context.Context, event domain.OutboxEvent) error {
+	res := newReservationEvent(event)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, buffered := range p.buffer {
+		if buffered.ID == res.ID {
+			return nil
+		}
+	}
+	p.buffer = append(p.buffer, res)
+	if len(p.buffer) > p.replaySize {
+		p.buffer = append([]ReservationEventRes(nil), p.buffer[len(p.buffer)-p.replaySize:]...)
+	}
+
+	for sub := range p.subscribers {
+		if !sub.matches(res) {
+			continue
+		}
+		select {
+		case sub.events <- res:
+		default:
+			p.remove(sub)
+			streamDropped.Add(1)
+		}
+	}
+	return nil
+}
+
+// SubscribeReservationEvents registers a subscriber until ctx is done.
+func (p *reservationStream) SubscribeReservationEvents(ctx context.Context, req SubscribeReservationEventsReq) (ReservationEventSubscription, error) {
+	if req.UserID < 0 || req.LastEventID < 0 {
+		return ReservationEventSubscription{}, invalidRequest("User ID and last event ID cannot be negative")
+	}
+
+	sub := &streamSubscriber{
+		userID: req.UserID,
+		branch: req.Branch,
+		events: make(chan ReservationEventRes, p.clientBuffer),
+	}
+	result := ReservationEventSubscription{
+		Replay: []ReservationEventRes{},
+		Events: sub.events,
+	}
+
+	// Replaying and subscribing under one lock means no event falls between the two.
+	p.mu.Lock()
+	if req.LastEventID > 0 {
+		result.Gap = len(p.buffer) > 0 && p.buffer[0].ID > req.LastEventID+1
+		for _, res := range p.buffer {
+			if res.ID > req.LastEventID && sub.matches(res) {
+				result.Replay = append(result.Replay, res)
+			}
+		}
+	}
+	p.subscribers[sub] = true
+	streamSubscribers.Add(1)
+	p.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		p.mu.Lock()
+		p.remove(sub)
+		p.mu.Unlock()
+	}()
+	return result, nil
+}
+
+// remove ends a subscription once. Callers must hold p.mu.
+func (p *reservationStream) remove(sub *streamSubscriber) {
+	if !p.subscribers[sub] {
+		return
+	}
+	delete(p.subscribers, sub)
+	close(sub.events)
+	streamSubscribers.Add(-1)
+}
+
+func (s *streamSubscriber) matches(res ReservationEventRes) bool {
+	if s.userID != 0 && res.Reservation.UserID != s.userID {
+		return false
+	}
+	if s.branch != "" && res.Reservation.Branch != s.branch {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+func streamEvent(id int, eventType string, userID int, branch string) domain.OutboxEvent {
+	return domain.OutboxEvent{
+		ID:          id,
+		Type:        eventType,
+		Reservation: domain.BorrowBookReq{ID: id, Book: domain.Book{Key: "123"}, Branch: branch, UserID: userID},
+	}
+}
+
+func eventIDs(events []ReservationEventRes) []int {
+	ids := []int{}
+	for _, event := range events {
+		ids = append(ids, event.ID)
+	}
+	return ids
+}
+
+func Test_SubscribeReservationEvents(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      SubscribeReservationEventsReq
+		wantIDs  []int
+		wantGap  bool
+		wantCode string
+	}{
+		{
+			name:    "no last event id replays nothing",
+			req:     SubscribeReservationEventsReq{},
+			wantIDs: []int{},
+		},
+		{
+			name:    "resume after last event id",
+			req:     SubscribeReservationEventsReq{LastEventID: 3},
+			wantIDs: []int{4, 5},
+		},
+		{
+			name:    "resume with filters",
+			req:     SubscribeReservationEventsReq{LastEventID: 2, UserID: 1, Branch: "central"},
+			wantIDs: []int{5},
+		},
+		{
+			name:    "events before the buffer are reported as a gap",
+			req:     SubscribeReservationEventsReq{LastEventID: 1},
+			wantIDs: []int{3, 4, 5},
+			wantGap: true,
+		},
+		{
+			name:     "negative last event id",
+			req:      SubscribeReservationEventsReq{LastEventID: -1},
+			wantCode: ErrCodeInvalidRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := NewReservationStream(ReservationStreamDependencies{
+				Cfg: &config.GlobalConfig{EventStream: config.EventStream{ReplayBuffer: 3}},
+			})
+			for _, event := range []domain.OutboxEvent{
+				streamEvent(1, domain.EventReservationCreated, 1, "central"),
+				streamEvent(2, domain.EventReservationCreated, 2, "central"),
+				streamEvent(3, domain.EventReservationCreated, 1, "north"),
+				streamEvent(4, domain.EventReservationCancelled, 2, "central"),
+				streamEvent(5, domain.EventReservationConfirmed, 1, "central"),
+			} {
+				got.HandleEvent(context.Background(), event)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			sub, err := got.SubscribeReservationEvents(ctx, tt.req)
+			if se := (*ServiceError)(nil); tt.wantCode != "" && (!errors.As(err, &se) || se.Code != tt.wantCode) {
+				t.Fatalf("SubscribeReservationEvents() error = %v, wantCode %v", err, tt.wantCode)
+			}
+			if tt.wantCode != "" {
+				return
+			}
+			if ids := eventIDs(sub.Replay); len(ids) != len(tt.wantIDs) || sub.Gap != tt.wantGap {
+				t.Errorf("SubscribeReservationEvents() replay = %v gap = %v, want %v gap = %v", ids, sub.Gap, tt.wantIDs, tt.wantGap)
+			} else {
+				for i := range ids {
+					if ids[i] != tt.wantIDs[i] {
+						t.Errorf("SubscribeReservationEvents() replay = %v, want %v", ids, tt.wantIDs)
+					}
+				}
+			}
+		})
+	}
+}
+
+func Test_reservationStreamFanOut(t *testing.T) {
+	got, _ := NewReservationStream(ReservationStreamDependencies{
+		Cfg: &config.GlobalConfig{EventStream: config.EventStream{ClientBuffer: 1}},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	central, _ := got.SubscribeReservationEvents(ctx, SubscribeReservationEventsReq{Branch: "central"})
+	slow, _ := got.SubscribeReservationEvents(context.Background(), SubscribeReservationEventsReq{})
+
+	got.HandleEvent(context.Background(), streamEvent(1, domain.EventReservationCreated, 1, "central"))
+	// A redelivered event is not sent twice.
+	got.HandleEvent(context.Background(), streamEvent(1, domain.EventReservationCreated, 1, "central"))
+	got.HandleEvent(context.Background(), streamEvent(2, domain.EventReservationCreated, 1, "north"))
+
+	if event := <-central.Events; event.ID != 1 || event.Type != domain.EventReservationCreated || event.Reservation.Branch != "central" {
+		t.Errorf("central event = %+v", event)
+	}
+	select {
+	case event := <-central.Events:
+		t.Errorf("central got unexpected event %+v", event)
+	default:
+	}
+
+	// The unfiltered subscriber never read, so the second event overflowed its buffer and ended it.
+	if event := <-slow.Events; event.ID != 1 {
+		t.Errorf("slow event = %+v", event)
+	}
+	if _, ok := <-slow.Events; ok {
+		t.Errorf("slow subscriber was not dropped")
+	}
+
+	cancel()
+	if _, ok := <-central.Events; ok {
+		t.Errorf("central subscriber was not closed after cancel")
+	}
+}
@@ -0,0 +1,23 @@
+package services
+
+import "gihub.com/gadhittana01/book-project/config"
+
+type ReservationStreamDependencies struct {
+	Cfg *config.GlobalConfig
+}
+
+// SubscribeReservationEventsReq UserID and Branch filter the events when they are set. LastEventID resumes after
+// that event.
+type SubscribeReservationEventsReq struct {
+	UserID      int    `json:"user_id"`
+	Branch      string `json:"branch"`
+	LastEventID int    `json:"last_event_id"`
+}
+
+// ReservationEventSubscription Replay holds the buffered events after LastEventID, Gap is set when some of them
+// already left the buffer. Events is closed when the subscription ends or the client falls too far behind.
+type ReservationEventSubscription struct {
+	Replay []ReservationEventRes
+	Gap    bool
+	Events <-chan ReservationEventRes
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+const (
+	sweeperLeaseName = "reservation-sweeper"
+
+	defaultSweepIntervalSec = 300
+)
+
+// Sweeper metrics are published on /debug/vars.
+var (
+	sweepRuns         = expvar.NewInt("reservation_sweeper_runs")
+	sweepSkipped      = expvar.NewInt("reservation_sweeper_skipped")
+	sweepErrors       = expvar.NewInt("reservation_sweeper_errors")
+	sweepExpiredTotal = expvar.NewInt("reservation_sweeper_expired_total")
+	sweepLastExpired  = expvar.NewInt("reservation_sweeper_last_expired")
+)
+
+type Sweeper interface {
+	Run(ctx context.Context)
+	Sweep(ctx context.Context) (SweepRes, error)
+}
+
+type sweeper struct {
+	br     BookResource
+	cfg    config.Sweeper
+	holder string
+}
+
+func NewSweeper(dep SweeperDependencies) (Sweeper, error) {
+	svc := &sweeper{
+		br:     dep.BR,
+		holder: dep.Holder,
+	}
+	if dep.Cfg != nil {
+		svc.cfg = dep.Cfg.Sweeper
+	}
+	if svc.holder == "" {
+		host, _ := os.Hostname()
+		svc.holder = fmt.Sprintf("%s-%d", host, os.Getpid())
+	}
+	return svc, nil
+}
+
+// Run sweeps every configured interval until ctx is done.
+func (p sweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval())
+	defer ticker.Stop()
+
+	for {
+		if res, err := p.Sweep(ctx); err != nil {
+			log.Println("reservation sweeper:", err)
+		} else if res.Expired > 0 {
+			log.Printf("reservation sweeper: expired %d reservations", res.Expired)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep expires uncollected reservations once, provided this replica holds the sweeper lease.
+func (p sweeper) Sweep(ctx context.Context) (SweepRes, error) {
+	sweepRuns.Add(1)
+
+	ok, err := p.br.AcquireLease(ctx, domain.AcquireLeaseReq{
+		Name:   sweeperLeaseName,
+		Holder: p.holder,
+		TTL:    p.leaseTTL(),
+	})
+	if err != nil {
+		sweepErrors.Add(1)
+		return SweepRes{}, err
+	}
+	if !ok {
+		sweepSkipped.Add(1)
+		return SweepRes{Skipped: true}, nil
+	}
+
+	expired, err := p.br.ExpireUncollected(ctx, domain.ExpireUncollectedReq{
+		GraceDays: p.cfg.GraceDays,
+	})
+	if err != nil {
+		sweepErrors.Add(1)
+		return SweepRes{}, err
+	}
+
+	result := SweepRes{
+		Expired:      len(expired),
+		Reservations: []GetBookReservationRes{},
+	}
+	for _, item := range expired {
+		result.Reservations = append(result.Reservations, GetBookReservationRes{
+			ID:         item.ID,
+			BookKey:    item.Book.Key,
+			Branch:     item.Branch,
+			PickUpDate: item.PickUpDate,
+			PickUpSlot: item.PickUpSlot,
+			UserID:     item.UserID,
+			Status:     item.Status,
+		})
+	}
+	sweepExpiredTotal.Add(int64(result.Expired))
+	sweepLastExpired.Set(int64(result.Expired))
+	return result, nil
+}
+
+func (p sweeper) interval() time.Duration {
+	sec := p.cfg.IntervalSec
+	if sec <= 0 {
+		sec = defaultSweepIntervalSec
+	}
+	return time.Duration(sec) * time.Second
+}
+
+// leaseTTL outlives the interval so the holder keeps the lease between runs, other replicas take over once it
+// stops renewing.
+func (p sweeper) leaseTTL() time.Duration {
+	if p.cfg.LeaseSec > 0 {
+		return time.Duration(p.cfg.LeaseSec) * time.Second
+	}
+	return 2 * p.interval()
+}
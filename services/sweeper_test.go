@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"errors"
+	reflect "reflect"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+func Test_Sweep(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cfg := config.Sweeper{IntervalSec: 60, GraceDays: 2}
+
+	tests := []struct {
+		name    string
+		fields  func() sweeper
+		want    SweepRes
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: func() sweeper {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().AcquireLease(gomock.Any(), domain.AcquireLeaseReq{
+					Name:   sweeperLeaseName,
+					Holder: "replica-1",
+					TTL:    2 * time.Minute,
+				}).Return(true, nil)
+				bookMock.EXPECT().ExpireUncollected(gomock.Any(), domain.ExpireUncollectedReq{GraceDays: 2}).Return([]domain.BorrowBookReq{
+					{ID: 4, Book: domain.Book{Key: "123"}, Branch: "central", PickUpDate: "2022-01-01", UserID: 1, Status: domain.ReservationStatusExpired},
+				}, nil)
+				return sweeper{br: bookMock, cfg: cfg, holder: "replica-1"}
+			},
+			want: SweepRes{
+				Expired: 1,
+				Reservations: []GetBookReservationRes{
+					{ID: 4, BookKey: "123", Branch: "central", PickUpDate: "2022-01-01", UserID: 1, Status: domain.ReservationStatusExpired},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "lease held by another replica",
+			fields: func() sweeper {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().AcquireLease(gomock.Any(), gomock.Any()).Return(false, nil)
+				return sweeper{br: bookMock, cfg: cfg, holder: "replica-2"}
+			},
+			want:    SweepRes{Skipped: true},
+			wantErr: false,
+		},
+		{
+			name: "lease error",
+			fields: func() sweeper {
+				bookMock := NewMockBookResource(ctrl)
+				bookMock.EXPECT().AcquireLease(gomock.Any(), gomock.Any()).Return(false, errors.New("error"))
+				return sweeper{br: bookMock, cfg: cfg, holder: "replica-1"}
+			},
+			want:    SweepRes{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := sweepExpiredTotal.Value()
+			got, err := tt.fields().Sweep(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Sweep() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Sweep() = %v, want %v", got, tt.want)
+			}
+			if delta := sweepExpiredTotal.Value() - before; delta != int64(tt.want.Expired) {
+				t.Errorf("expired metric grew by %d, want %d", delta, tt.want.Expired)
+			}
+		})
+	}
+}
+
+func Test_NewSweeper(t *testing.T) {
+	got, _ := NewSweeper(SweeperDependencies{Cfg: &config.GlobalConfig{Sweeper: config.Sweeper{LeaseSec: 30}}})
+	s := got.(*sweeper)
+	if s.holder == "" || s.leaseTTL() != 30*time.Second || s.interval() != defaultSweepIntervalSec*time.Second {
+		t.Errorf("NewSweeper() = %+v", s)
+	}
+}
@@ -0,0 +1,29 @@
+package services
+
+import "gihub.com/gadhittana01/book-project/config"
+
+// SweeperDependencies Holder names this replica when it takes the sweeper lease, hostname and pid by default.
+type SweeperDependencies struct {
+	BR     BookResource
+	Cfg    *config.GlobalConfig
+	Holder string
+}
+
+// SweepRes Skipped is set when another replica holds the sweeper lease.
+type SweepRes struct {
+	Skipped      bool                    `json:"skipped"`
+	Expired      int                     `json:"expired"`
+	Reservations []GetBookReservationRes `json:"reservations"`
+}
+
+type ReminderDependencies struct {
+	BR       BookResource
+	UR       UserResource
+	Notifier Notifier
+	Cfg      *config.GlobalConfig
+}
+
+type RemindRes struct {
+	PickUpDate string `json:"pickup_date"`
+	Reminded   int    `json:"reminded"`
+}
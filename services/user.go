@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"net/mail"
+	"strings"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+)
+
+type UserService interface {
+	RegisterUser(ctx context.Context, req RegisterUserReq) (UserRes, error)
+	GetUser(ctx context.Context, req GetUserReq) (UserRes, error)
+	UpdateUserProfile(ctx context.Context, req UpdateUserProfileReq) (UserRes, error)
+	DeactivateUser(ctx context.Context, req DeactivateUserReq) (UserRes, error)
+	SetNotifyOptOut(ctx context.Context, req SetNotifyOptOutReq) (UserRes, error)
+}
+
+type userService struct {
+	ur UserResource
+}
+
+func NewUserService(dep UserDependencies) (UserService, error) {
+	return &userService{
+		ur: dep.UR,
+	}, nil
+}
+
+func (p userService) RegisterUser(ctx context.Context, req RegisterUserReq) (UserRes, error) {
+	profile, err := validateProfile(req.Name, req.Email, req.Phone)
+	if err != nil {
+		return UserRes{}, err
+	}
+
+	user, err := p.ur.RegisterUser(ctx, domain.RegisterUserReq{
+		Name:            profile.Name,
+		Email:           profile.Email,
+		Phone:           profile.Phone,
+		PreferredBranch: strings.TrimSpace(req.PreferredBranch),
+	})
+	if err != nil {
+		return UserRes{}, wrapDomainError(err)
+	}
+	return newUserRes(user), nil
+}
+
+func (p userService) GetUser(ctx context.Context, req GetUserReq) (UserRes, error) {
+	if req.ID == 0 {
+		return UserRes{}, invalidRequest("User ID is empty")
+	}
+
+	user, err := p.ur.GetUser(ctx, domain.GetUserReq{
+		ID: req.ID,
+	})
+	if err != nil {
+		return UserRes{}, wrapDomainError(err)
+	}
+	return newUserRes(user), nil
+}
+
+func (p userService) UpdateUserProfile(ctx context.Context, req UpdateUserProfileReq) (UserRes, error) {
+	if req.ID == 0 {
+		return UserRes{}, invalidRequest("User ID is empty")
+	}
+	profile, err := validateProfile(req.Name, req.Email, req.Phone)
+	if err != nil {
+		return UserRes{}, err
+	}
+
+	user, err := p.ur.UpdateUserProfile(ctx, domain.UpdateUserProfileReq{
+		ID:              req.ID,
+		Name:            profile.Name,
+		Email:           profile.Email,
+		Phone:           profile.Phone,
+		PreferredBranch: strings.TrimSpace(req.PreferredBranch),
+	})
+	if err != nil {
+		return UserRes{}, wrapDomainError(err)
+	}
+	return newUserRes(user), nil
+}
+
+func (p userService) DeactivateUser(ctx context.Context, req DeactivateUserReq) (UserRes, error) {
+	if req.ID == 0 {
+		return UserRes{}, invalidRequest("User ID is empty")
+	}
+
+	user, err := p.ur.DeactivateUser(ctx, domain.DeactivateUserReq{
+		ID: req.ID,
+	})
+	if err != nil {
+		return UserRes{}, wrapDomainError(err)
+	}
+	return newUserRes(user), nil
+}
+
+// SetNotifyOptOut stops, or resumes, reservation notifications for a user.
+func (p userService) SetNotifyOptOut(ctx context.Context, req SetNotifyOptOutReq) (UserRes, error) {
+	if req.ID == 0 {
+		return UserRes{}, invalidRequest("User ID is empty")
+	}
+
+	user, err := p.ur.SetNotifyOptOut(ctx, domain.SetNotifyOptOutReq{
+		ID:     req.ID,
+		OptOut: req.OptOut,
+	})
+	if err != nil {
+		return UserRes{}, wrapDomainError(err)
+	}
+	return newUserRes(user), nil
+}
+
+// validateProfile trims the profile fields and checks the name, email and phone number.
+func validateProfile(name, email, phone string) (domain.RegisterUserReq, error) {
+	profile := domain.RegisterUserReq{
+		Name:  strings.TrimSpace(name),
+		Email: strings.TrimSpace(email),
+		Phone: strings.TrimSpace(phone),
+	}
+
+	if profile.Name == "" {
+		return profile, invalidRequest("Name is empty")
+	}
+	if addr, err := mail.ParseAddress(profile.Email); err != nil || addr.Address != profile.Email {
+		return profile, invalidRequest("Email is invalid")
+	}
+	for i, r := range profile.Phone {
+		if (r < '0' || r > '9') && !(i == 0 && r == '+') && r != ' ' && r != '-' {
+			return profile, invalidRequest("Phone number is invalid")
+		}
+	}
+	return profile, nil
+}
+
+func newUserRes(user domain.User) UserRes {
+	res := UserRes{
+		ID:              user.ID,
+		Name:            user.Name,
+		Email:           user.Email,
+		Phone:           user.Phone,
+		PreferredBranch: user.PreferredBranch,
+		NotifyOptOut:    user.NotifyOptOut,
+		Active:          user.Active,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
+	}
+	if !user.DeactivatedAt.IsZero() {
+		deactivatedAt := user.DeactivatedAt
+		res.DeactivatedAt = &deactivatedAt
+	}
+	return res
+}
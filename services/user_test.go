@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"errors"
+	reflect "reflect"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+func Test_NewUserService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userMock := NewMockUserResource(ctrl)
+
+	got, err := NewUserService(UserDependencies{UR: userMock})
+	if err != nil {
+		t.Fatalf("NewUserService() error = %v", err)
+	}
+	if want := (&userService{ur: userMock}); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewUserService() = %v, want %v", got, want)
+	}
+}
+
+func Test_validateProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      [3]string
+		want    domain.RegisterUserReq
+		wantErr bool
+	}{
+		{
+			name: "trimmed",
+			in:   [3]string{" Jack ", " jack@example.com", "+62 811-123"},
+			want: domain.RegisterUserReq{Name: "Jack", Email: "jack@example.com", Phone: "+62 811-123"},
+		},
+		{
+			name:    "empty name",
+			in:      [3]string{"", "jack@example.com", ""},
+			wantErr: true,
+		},
+		{
+			name:    "display name in email",
+			in:      [3]string{"Jack", "Jack <jack@example.com>", ""},
+			wantErr: true,
+		},
+		{
+			name:    "letters in phone",
+			in:      [3]string{"Jack", "jack@example.com", "call me"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validateProfile(tt.in[0], tt.in[1], tt.in[2])
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateProfile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validateProfile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_RegisterUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	now := time.Date(2022, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		fields   func() userService
+		req      RegisterUserReq
+		want     UserRes
+		wantCode string
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			fields: func() userService {
+				userMock := NewMockUserResource(ctrl)
+				userMock.EXPECT().RegisterUser(gomock.Any(), domain.RegisterUserReq{Name: "Jack", Email: "jack@example.com", PreferredBranch: "central"}).Return(domain.User{
+					ID:              1,
+					Name:            "Jack",
+					Email:           "jack@example.com",
+					PreferredBranch: "central",
+					Active:          true,
+					CreatedAt:       now,
+					UpdatedAt:       now,
+				}, nil)
+				return userService{ur: userMock}
+			},
+			req: RegisterUserReq{Name: "Jack", Email: "jack@example.com", PreferredBranch: "central"},
+			want: UserRes{
+				ID:              1,
+				Name:            "Jack",
+				Email:           "jack@example.com",
+				PreferredBranch: "central",
+				Active:          true,
+				CreatedAt:       now,
+				UpdatedAt:       now,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid email",
+			fields: func() userService {
+				return userService{ur: NewMockUserResource(ctrl)}
+			},
+			req:      RegisterUserReq{Name: "Jack", Email: "jack"},
+			want:     UserRes{},
+			wantCode: ErrCodeInvalidRequest,
+			wantErr:  true,
+		},
+		{
+			name: "email taken",
+			fields: func() userService {
+				userMock := NewMockUserResource(ctrl)
+				userMock.EXPECT().RegisterUser(gomock.Any(), gomock.Any()).Return(domain.User{}, domain.ErrEmailTaken)
+				return userService{ur: userMock}
+			},
+			req:      RegisterUserReq{Name: "Jack", Email: "jack@example.com"},
+			want:     UserRes{},
+			wantCode: ErrCodeConflict,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.fields()
+			got, err := m.RegisterUser(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RegisterUser() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if se := (*ServiceError)(nil); tt.wantCode != "" && (!errors.As(err, &se) || se.Code != tt.wantCode) {
+				t.Errorf("RegisterUser() error = %v, want code %v", err, tt.wantCode)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RegisterUser() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_UserProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	now := time.Date(2022, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	userMock := NewMockUserResource(ctrl)
+	userMock.EXPECT().GetUser(gomock.Any(), domain.GetUserReq{ID: 1}).Return(domain.User{ID: 1, Active: true}, nil)
+	userMock.EXPECT().GetUser(gomock.Any(), domain.GetUserReq{ID: 2}).Return(domain.User{}, domain.ErrUserNotFound)
+	userMock.EXPECT().UpdateUserProfile(gomock.Any(), domain.UpdateUserProfileReq{ID: 1, Name: "Jack", Email: "jack@example.com"}).Return(domain.User{ID: 1, Name: "Jack"}, nil)
+	userMock.EXPECT().DeactivateUser(gomock.Any(), domain.DeactivateUserReq{ID: 1}).Return(domain.User{ID: 1, DeactivatedAt: now}, nil)
+	userMock.EXPECT().SetNotifyOptOut(gomock.Any(), domain.SetNotifyOptOutReq{ID: 1, OptOut: true}).Return(domain.User{ID: 1, NotifyOptOut: true}, nil)
+
+	p := userService{ur: userMock}
+	ctx := context.Background()
+
+	if got, err := p.GetUser(ctx, GetUserReq{ID: 1}); err != nil || !got.Active {
+		t.Errorf("GetUser() = %v, %v", got, err)
+	}
+	se := &ServiceError{}
+	if _, err := p.GetUser(ctx, GetUserReq{ID: 2}); !errors.As(err, &se) || se.Code != ErrCodeNotFound {
+		t.Errorf("GetUser() unknown error = %v", err)
+	}
+	if _, err := p.UpdateUserProfile(ctx, UpdateUserProfileReq{Name: "Jack", Email: "jack@example.com"}); !errors.As(err, &se) || se.Code != ErrCodeInvalidRequest {
+		t.Errorf("UpdateUserProfile() without id error = %v", err)
+	}
+	if got, err := p.UpdateUserProfile(ctx, UpdateUserProfileReq{ID: 1, Name: "Jack", Email: "jack@example.com"}); err != nil || got.Name != "Jack" {
+		t.Errorf("UpdateUserProfile() = %v, %v", got, err)
+	}
+	if got, err := p.DeactivateUser(ctx, DeactivateUserReq{ID: 1}); err != nil || got.DeactivatedAt == nil || !got.DeactivatedAt.Equal(now) {
+		t.Errorf("DeactivateUser() = %v, %v", got, err)
+	}
+	if got, err := p.SetNotifyOptOut(ctx, SetNotifyOptOutReq{ID: 1, OptOut: true}); err != nil || !got.NotifyOptOut {
+		t.Errorf("SetNotifyOptOut() = %v, %v", got, err)
+	}
+	if _, err := p.SetNotifyOptOut(ctx, SetNotifyOptOutReq{OptOut: true}); !errors.As(err, &se) || se.Code != ErrCodeInvalidRequest {
+		t.Errorf("SetNotifyOptOut() without id error = %v", err)
+	}
+}
+
+func Test_BorrowBookUserCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		user     domain.User
+		userErr  error
+		wantCode string
+		wantErr  bool
+	}{
+		{
+			name:    "active user",
+			user:    domain.User{ID: 1, Active: true},
+			wantErr: false,
+		},
+		{
+			name:     "unknown user",
+			userErr:  domain.ErrUserNotFound,
+			wantCode: ErrCodeNotFound,
+			wantErr:  true,
+		},
+		{
+			name:     "deactivated user",
+			user:     domain.User{ID: 1},
+			wantCode: ErrCodeForbidden,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userMock := NewMockUserResource(ctrl)
+			userMock.EXPECT().GetUser(gomock.Any(), domain.GetUserReq{ID: 1}).Return(tt.user, tt.userErr)
+			bookMock := NewMockBookResource(ctrl)
+			if !tt.wantErr {
+				bookMock.EXPECT().GetBookByKey(gomock.Any(), gomock.Any()).Return(domain.Book{Key: "123"}, nil)
+				bookMock.EXPECT().BorrowBook(gomock.Any(), gomock.Any()).Return(domain.BorrowBookReq{}, nil)
+			}
+
+			svc, _ := NewBookService(BookDependencies{
+				BR: bookMock,
+				UR: userMock,
+			})
+			_, err := svc.BorrowBook(context.Background(), BorrowBookReq{BookKey: "123", Branch: "central", UserID: 1})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BorrowBook() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if se := (*ServiceError)(nil); tt.wantCode != "" && (!errors.As(err, &se) || se.Code != tt.wantCode) {
+				t.Errorf("BorrowBook() error = %v, want code %v", err, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package services
+
+import "time"
+
+type UserDependencies struct {
+	UR UserResource
+}
+
+type RegisterUserReq struct {
+	Name            string `json:"name"`
+	Email           string `json:"email"`
+	Phone           string `json:"phone"`
+	PreferredBranch string `json:"preferred_branch"`
+}
+
+type UpdateUserProfileReq struct {
+	ID              int    `json:"id"`
+	Name            string `json:"name"`
+	Email           string `json:"email"`
+	Phone           string `json:"phone"`
+	PreferredBranch string `json:"preferred_branch"`
+}
+
+type GetUserReq struct {
+	ID int `json:"id"`
+}
+
+type DeactivateUserReq struct {
+	ID int `json:"id"`
+}
+
+type SetNotifyOptOutReq struct {
+	ID     int  `json:"id"`
+	OptOut bool `json:"opt_out"`
+}
+
+type UserRes struct {
+	ID              int        `json:"id"`
+	Name            string     `json:"name"`
+	Email           string     `json:"email"`
+	Phone           string     `json:"phone"`
+	PreferredBranch string     `json:"preferred_branch"`
+	NotifyOptOut    bool       `json:"notify_opt_out"`
+	Active          bool       `json:"active"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	DeactivatedAt   *time.Time `json:"deactivated_at,omitempty"`
+}
@@ -0,0 +1,402 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+	"gihub.com/gadhittana01/book-project/pkg/webhook"
+)
+
+const (
+	defaultWebhookIntervalMS   = 1000
+	defaultWebhookMaxAttempts  = 10
+	defaultWebhookBackoffMS    = 5000
+	defaultWebhookMaxBackoffMS = 3600000
+	defaultWebhookClaimSec     = 60
+)
+
+// Webhook delivery metrics are published on /debug/vars.
+var (
+	webhookSucceeded = expvar.NewInt("webhook_deliveries_succeeded_total")
+	webhookRetried   = expvar.NewInt("webhook_deliveries_retried_total")
+	webhookFailed    = expvar.NewInt("webhook_deliveries_failed_total")
+	webhookErrors    = expvar.NewInt("webhook_deliverer_errors")
+)
+
+// reservationEventTypes are the events a webhook can subscribe to.
+var reservationEventTypes = map[string]bool{
+	domain.EventReservationCreated:   true,
+	domain.EventReservationConfirmed: true,
+	domain.EventReservationCancelled: true,
+	domain.EventReservationPickedUp:  true,
+	domain.EventReservationReturned:  true,
+	domain.EventReservationExpired:   true,
+}
+
+// WebhookService manages webhook subscriptions and their delivery log.
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, req CreateWebhookReq) (WebhookRes, error)
+	GetWebhooks(ctx context.Context) ([]WebhookRes, error)
+	UpdateWebhook(ctx context.Context, req UpdateWebhookReq) (WebhookRes, error)
+	DeleteWebhook(ctx context.Context, req DeleteWebhookReq) error
+	GetWebhookDeliveries(ctx context.Context, req GetWebhookDeliveriesReq) ([]WebhookDeliveryRes, error)
+	ReplayWebhookDelivery(ctx context.Context, req ReplayWebhookDeliveryReq) (WebhookDeliveryRes, error)
+}
+
+type webhookService struct {
+	wr           WebhookResource
+	allowedHosts []string
+}
+
+func NewWebhookService(dep WebhookDependencies) (WebhookService, error) {
+	svc := &webhookService{
+		wr: dep.WR,
+	}
+	if dep.Cfg != nil {
+		svc.allowedHosts = dep.Cfg.Webhooks.AllowedHosts
+	}
+	return svc, nil
+}
+
+func (p webhookService) CreateWebhook(ctx context.Context, req CreateWebhookReq) (WebhookRes, error) {
+	if err := validateWebhook(req.URL, req.Events, p.allowedHosts); err != nil {
+		return WebhookRes{}, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		var err error
+		if secret, err = newWebhookSecret(); err != nil {
+			return WebhookRes{}, err
+		}
+	}
+
+	sub, err := p.wr.CreateWebhook(ctx, domain.CreateWebhookReq{
+		URL:    strings.TrimSpace(req.URL),
+		Secret: secret,
+		Events: req.Events,
+	})
+	if err != nil {
+		return WebhookRes{}, wrapDomainError(err)
+	}
+
+	result := newWebhookRes(sub)
+	result.Secret = sub.Secret
+	return result, nil
+}
+
+func (p webhookService) GetWebhooks(ctx context.Context) ([]WebhookRes, error) {
+	subs, err := p.wr.GetWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []WebhookRes{}
+	for _, sub := range subs {
+		result = append(result, newWebhookRes(sub))
+	}
+	return result, nil
+}
+
+func (p webhookService) UpdateWebhook(ctx context.Context, req UpdateWebhookReq) (WebhookRes, error) {
+	if req.ID == 0 {
+		return WebhookRes{}, invalidRequest("Webhook ID is empty")
+	}
+	if err := validateWebhook(req.URL, req.Events, p.allowedHosts); err != nil {
+		return WebhookRes{}, err
+	}
+
+	sub, err := p.wr.UpdateWebhook(ctx, domain.UpdateWebhookReq{
+		ID:     req.ID,
+		URL:    strings.TrimSpace(req.URL),
+		Events: req.Events,
+		Active: req.Active,
+	})
+	if err != nil {
+		return WebhookRes{}, wrapDomainError(err)
+	}
+	return newWebhookRes(sub), nil
+}
+
+func (p webhookService) DeleteWebhook(ctx context.Context, req DeleteWebhookReq) error {
+	if req.ID == 0 {
+		return invalidRequest("Webhook ID is empty")
+	}
+
+	err := p.wr.DeleteWebhook(ctx, domain.DeleteWebhookReq{
+		ID: req.ID,
+	})
+	if err != nil {
+		return wrapDomainError(err)
+	}
+	return nil
+}
+
+func (p webhookService) GetWebhookDeliveries(ctx context.Context, req GetWebhookDeliveriesReq) ([]WebhookDeliveryRes, error) {
+	switch req.Status {
+	case "", domain.WebhookDeliveryPending, domain.WebhookDeliverySucceeded, domain.WebhookDeliveryFailed:
+	default:
+		return nil, invalidRequest("Status must be pending, succeeded or failed")
+	}
+
+	items, err := p.wr.GetWebhookDeliveries(ctx, domain.GetWebhookDeliveriesReq{
+		SubscriptionID: req.SubscriptionID,
+		Status:         req.Status,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := []WebhookDeliveryRes{}
+	for _, item := range items {
+		result = append(result, newWebhookDeliveryRes(item))
+	}
+	return result, nil
+}
+
+func (p webhookService) ReplayWebhookDelivery(ctx context.Context, req ReplayWebhookDeliveryReq) (WebhookDeliveryRes, error) {
+	if req.ID == 0 {
+		return WebhookDeliveryRes{}, invalidRequest("Delivery ID is empty")
+	}
+
+	item, err := p.wr.ReplayWebhookDelivery(ctx, domain.ReplayWebhookDeliveryReq{
+		ID: req.ID,
+	})
+	if err != nil {
+		return WebhookDeliveryRes{}, wrapDomainError(err)
+	}
+	return newWebhookDeliveryRes(item), nil
+}
+
+// webhookEventHandler queues reservation events for the webhook subscriptions, the deliverer sends them.
+type webhookEventHandler struct {
+	wr WebhookResource
+}
+
+func NewWebhookEventHandler(dep WebhookDependencies) (EventHandler, error) {
+	return &webhookEventHandler{
+		wr: dep.WR,
+	}, nil
+}
+
+func (h *webhookEventHandler) HandleEvent(ctx context.Context, event domain.OutboxEvent) error {
+	payload, err := json.Marshal(newReservationEvent(event))
+	if err != nil {
+		return err
+	}
+
+	_, err = h.wr.EnqueueWebhook(ctx, domain.EnqueueWebhookReq{
+		EventID:   event.ID,
+		EventType: event.Type,
+		Payload:   string(payload),
+	})
+	return err
+}
+
+// WebhookDeliverer sends queued webhook deliveries.
+type WebhookDeliverer interface {
+	Run(ctx context.Context)
+	Deliver(ctx context.Context) (DeliverWebhooksRes, error)
+}
+
+type webhookDeliverer struct {
+	wr      WebhookResource
+	cfg     config.Webhooks
+	timeNow func() time.Time
+}
+
+func NewWebhookDeliverer(dep WebhookDependencies) (WebhookDeliverer, error) {
+	svc := &webhookDeliverer{
+		wr:      dep.WR,
+		timeNow: time.Now,
+	}
+	if dep.Cfg != nil {
+		svc.cfg = dep.Cfg.Webhooks
+	}
+	return svc, nil
+}
+
+// Run delivers every configured interval until ctx is done.
+func (p webhookDeliverer) Run(ctx context.Context) {
+	ms := p.cfg.IntervalMS
+	if ms <= 0 {
+		ms = defaultWebhookIntervalMS
+	}
+	ticker := time.NewTicker(time.Duration(ms) * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		if res, err := p.Deliver(ctx); err != nil {
+			log.Println("webhook deliverer:", err)
+		} else if res.Failed > 0 {
+			log.Printf("webhook deliverer: gave up on %d deliveries", res.Failed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Deliver sends the due deliveries once. Failures are retried with exponential backoff until the attempts run
+// out, and endpoints failing too often in a row are disabled.
+func (p webhookDeliverer) Deliver(ctx context.Context) (DeliverWebhooksRes, error) {
+	claimSec := p.cfg.ClaimSec
+	if claimSec <= 0 {
+		claimSec = defaultWebhookClaimSec
+	}
+	items, err := p.wr.ClaimWebhookDeliveries(ctx, domain.ClaimWebhookDeliveriesReq{
+		Limit: p.cfg.BatchSize,
+		Lease: time.Duration(claimSec) * time.Second,
+	})
+	if err != nil {
+		webhookErrors.Add(1)
+		return DeliverWebhooksRes{}, err
+	}
+
+	result := DeliverWebhooksRes{
+		Claimed: len(items),
+	}
+	for _, item := range items {
+		attempt := domain.RecordWebhookAttemptReq{
+			ID:           item.ID,
+			DisableAfter: p.cfg.DisableAfter,
+		}
+
+		sub, err := p.wr.GetWebhook(ctx, domain.GetWebhookReq{
+			ID: item.SubscriptionID,
+		})
+		if err == nil {
+			attempt.ResponseStatus, err = p.wr.SendWebhook(ctx, domain.SendWebhookReq{
+				URL:        sub.URL,
+				Secret:     sub.Secret,
+				EventType:  item.EventType,
+				DeliveryID: item.ID,
+				Payload:    item.Payload,
+			})
+		}
+
+		switch {
+		case err == nil:
+			result.Succeeded++
+		case item.Attempts+1 >= p.maxAttempts():
+			attempt.Error = err.Error()
+			attempt.GiveUp = true
+			result.Failed++
+		default:
+			attempt.Error = err.Error()
+			attempt.RetryAt = p.timeNow().Add(p.backoff(item.Attempts + 1))
+			result.Retried++
+		}
+
+		if _, err := p.wr.RecordWebhookAttempt(ctx, attempt); err != nil {
+			webhookErrors.Add(1)
+			return result, err
+		}
+	}
+	webhookSucceeded.Add(int64(result.Succeeded))
+	webhookRetried.Add(int64(result.Retried))
+	webhookFailed.Add(int64(result.Failed))
+	return result, nil
+}
+
+func (p webhookDeliverer) maxAttempts() int {
+	if p.cfg.MaxAttempts > 0 {
+		return p.cfg.MaxAttempts
+	}
+	return defaultWebhookMaxAttempts
+}
+
+func (p webhookDeliverer) backoff(attempts int) time.Duration {
+	base, ceiling := p.cfg.BackoffMS, p.cfg.MaxBackoffMS
+	if base <= 0 {
+		base = defaultWebhookBackoffMS
+	}
+	if ceiling <= 0 {
+		ceiling = defaultWebhookMaxBackoffMS
+	}
+	return exponentialBackoff(time.Duration(base)*time.Millisecond, time.Duration(ceiling)*time.Millisecond, attempts)
+}
+
+// validateWebhook checks that rawURL is an absolute http(s) URL outside the internal network, unless allowedHosts
+// permits it, and events are known reservation events.
+func validateWebhook(rawURL string, events []string, allowedHosts []string) error {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return invalidRequest("URL must be an absolute http or https URL")
+	}
+	if !webhook.HostAllowed(u.Hostname(), allowedHosts) {
+		return invalidRequest("URL must not point at an internal address")
+	}
+	for _, e := range events {
+		if !reservationEventTypes[e] {
+			return invalidRequest(fmt.Sprintf("Unknown event type %q", e))
+		}
+	}
+	return nil
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.New("cannot generate a webhook secret")
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+func newWebhookRes(sub domain.WebhookSubscription) WebhookRes {
+	events := sub.Events
+	if events == nil {
+		events = []string{}
+	}
+	result := WebhookRes{
+		ID:                  sub.ID,
+		URL:                 sub.URL,
+		Events:              events,
+		Active:              sub.Active,
+		ConsecutiveFailures: sub.ConsecutiveFailures,
+		DisabledReason:      sub.DisabledReason,
+		CreatedAt:           sub.CreatedAt,
+		UpdatedAt:           sub.UpdatedAt,
+	}
+	if !sub.DisabledAt.IsZero() {
+		disabledAt := sub.DisabledAt
+		result.DisabledAt = &disabledAt
+	}
+	return result
+}
+
+func newWebhookDeliveryRes(item domain.WebhookDelivery) WebhookDeliveryRes {
+	result := WebhookDeliveryRes{
+		ID:             item.ID,
+		SubscriptionID: item.SubscriptionID,
+		EventID:        item.EventID,
+		EventType:      item.EventType,
+		Payload:        json.RawMessage(item.Payload),
+		Status:         item.Status,
+		Attempts:       item.Attempts,
+		ResponseStatus: item.ResponseStatus,
+		LastError:      item.LastError,
+		NextAttemptAt:  item.NextAttemptAt,
+		CreatedAt:      item.CreatedAt,
+		ReplayOf:       item.ReplayOf,
+	}
+	if !item.CompletedAt.IsZero() {
+		completedAt := item.CompletedAt
+		result.CompletedAt = &completedAt
+	}
+	return result
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+	"gihub.com/gadhittana01/book-project/pkg/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+func Test_CreateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name         string
+		req          CreateWebhookReq
+		allowedHosts []string
+		mock         func() WebhookResource
+		wantCode     string
+	}{
+		{
+			name: "success with generated secret",
+			req:  CreateWebhookReq{URL: " https://kiosk.example.com/hook ", Events: []string{domain.EventReservationCreated}},
+			mock: func() WebhookResource {
+				webhookMock := NewMockWebhookResource(ctrl)
+				webhookMock.EXPECT().CreateWebhook(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req domain.CreateWebhookReq) (domain.WebhookSubscription, error) {
+					if req.URL != "https://kiosk.example.com/hook" || !strings.HasPrefix(req.Secret, "whsec_") {
+						t.Errorf("CreateWebhook() req = %+v", req)
+					}
+					return domain.WebhookSubscription{ID: 1, URL: req.URL, Secret: req.Secret, Events: req.Events, Active: true}, nil
+				})
+				return webhookMock
+			},
+		},
+		{
+			name: "relative url",
+			req:  CreateWebhookReq{URL: "/hook"},
+			mock: func() WebhookResource {
+				return NewMockWebhookResource(ctrl)
+			},
+			wantCode: ErrCodeInvalidRequest,
+		},
+		{
+			name: "internal address",
+			req:  CreateWebhookReq{URL: "http://169.254.169.254/latest/meta-data"},
+			mock: func() WebhookResource {
+				return NewMockWebhookResource(ctrl)
+			},
+			wantCode: ErrCodeInvalidRequest,
+		},
+		{
+			name:         "allowed internal address",
+			req:          CreateWebhookReq{URL: "http://10.0.0.5/hook", Secret: "s"},
+			allowedHosts: []string{"10.0.0.0/8"},
+			mock: func() WebhookResource {
+				webhookMock := NewMockWebhookResource(ctrl)
+				webhookMock.EXPECT().CreateWebhook(gomock.Any(), gomock.Any()).Return(domain.WebhookSubscription{ID: 2, Secret: "s"}, nil)
+				return webhookMock
+			},
+		},
+		{
+			name: "unknown event",
+			req:  CreateWebhookReq{URL: "https://kiosk.example.com/hook", Events: []string{"reservation.lost"}},
+			mock: func() WebhookResource {
+				return NewMockWebhookResource(ctrl)
+			},
+			wantCode: ErrCodeInvalidRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := webhookService{wr: tt.mock(), allowedHosts: tt.allowedHosts}
+			got, err := p.CreateWebhook(context.Background(), tt.req)
+			if (err != nil) != (tt.wantCode != "") {
+				t.Errorf("CreateWebhook() error = %v, wantCode %v", err, tt.wantCode)
+				return
+			}
+			if se := (*ServiceError)(nil); tt.wantCode != "" && (!errors.As(err, &se) || se.Code != tt.wantCode) {
+				t.Errorf("CreateWebhook() error = %v, wantCode %v", err, tt.wantCode)
+			}
+			if err == nil && got.Secret == "" {
+				t.Errorf("CreateWebhook() secret is not returned")
+			}
+		})
+	}
+}
+
+func Test_GetWebhooksHidesSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	webhookMock := NewMockWebhookResource(ctrl)
+	webhookMock.EXPECT().GetWebhooks(gomock.Any()).Return([]domain.WebhookSubscription{{ID: 1, URL: "https://kiosk.example.com/hook", Secret: "s1", Active: true}}, nil)
+
+	got, err := webhookService{wr: webhookMock}.GetWebhooks(context.Background())
+	want := []WebhookRes{{ID: 1, URL: "https://kiosk.example.com/hook", Events: []string{}, Active: true}}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("GetWebhooks() = %+v, %v, want %+v", got, err, want)
+	}
+}
+
+func Test_webhookHandleEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	occurred := time.Date(2022, 6, 1, 9, 0, 0, 0, time.UTC)
+	event := domain.OutboxEvent{
+		ID:          12,
+		Type:        domain.EventReservationCreated,
+		Reservation: domain.BorrowBookReq{ID: 4, Book: domain.Book{Key: "123", Title: "Matilda"}, Branch: "central", PickUpDate: "2022-06-02", UserID: 1, Status: domain.ReservationStatusActive},
+		CreatedAt:   occurred,
+	}
+
+	webhookMock := NewMockWebhookResource(ctrl)
+	webhookMock.EXPECT().EnqueueWebhook(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req domain.EnqueueWebhookReq) ([]domain.WebhookDelivery, error) {
+		var payload ReservationEventRes
+		if err := json.Unmarshal([]byte(req.Payload), &payload); err != nil {
+			t.Fatalf("payload is not JSON: %v", err)
+		}
+		want := ReservationEventRes{
+			ID:          12,
+			Type:        domain.EventReservationCreated,
+			OccurredAt:  occurred,
+			Reservation: ReservationEventData{ID: 4, BookKey: "123", Title: "Matilda", Branch: "central", PickUpDate: "2022-06-02", UserID: 1, Status: domain.ReservationStatusActive},
+		}
+		if req.EventID != 12 || req.EventType != domain.EventReservationCreated || !reflect.DeepEqual(payload, want) {
+			t.Errorf("EnqueueWebhook() req = %+v", req)
+		}
+		return nil, nil
+	})
+
+	h, _ := NewWebhookEventHandler(WebhookDependencies{WR: webhookMock})
+	if err := h.HandleEvent(context.Background(), event); err != nil {
+		t.Errorf("HandleEvent() error = %v", err)
+	}
+}
+
+func Test_Deliver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	now := time.Date(2022, 6, 1, 9, 0, 0, 0, time.UTC)
+	cfg := config.Webhooks{BatchSize: 5, MaxAttempts: 3, BackoffMS: 1000, MaxBackoffMS: 10000, ClaimSec: 30, DisableAfter: 10}
+	sub := domain.WebhookSubscription{ID: 2, URL: "https://kiosk.example.com/hook", Secret: "s1", Active: true}
+
+	webhookMock := NewMockWebhookResource(ctrl)
+	webhookMock.EXPECT().ClaimWebhookDeliveries(gomock.Any(), domain.ClaimWebhookDeliveriesReq{Limit: 5, Lease: 30 * time.Second}).Return([]domain.WebhookDelivery{
+		{ID: 1, SubscriptionID: 2, EventType: domain.EventReservationCreated, Payload: `{"id":1}`},
+		{ID: 2, SubscriptionID: 2, EventType: domain.EventReservationCreated, Payload: `{"id":2}`, Attempts: 1},
+		{ID: 3, SubscriptionID: 2, EventType: domain.EventReservationCreated, Payload: `{"id":3}`, Attempts: 2},
+	}, nil)
+	webhookMock.EXPECT().GetWebhook(gomock.Any(), domain.GetWebhookReq{ID: 2}).Return(sub, nil).Times(3)
+	webhookMock.EXPECT().SendWebhook(gomock.Any(), domain.SendWebhookReq{URL: sub.URL, Secret: "s1", EventType: domain.EventReservationCreated, DeliveryID: 1, Payload: `{"id":1}`}).Return(200, nil)
+	webhookMock.EXPECT().SendWebhook(gomock.Any(), gomock.Any()).Return(503, errors.New("webhook answered 503 Service Unavailable")).Times(2)
+	gomock.InOrder(
+		webhookMock.EXPECT().RecordWebhookAttempt(gomock.Any(), domain.RecordWebhookAttemptReq{ID: 1, ResponseStatus: 200, DisableAfter: 10}),
+		webhookMock.EXPECT().RecordWebhookAttempt(gomock.Any(), domain.RecordWebhookAttemptReq{ID: 2, ResponseStatus: 503, Error: "webhook answered 503 Service Unavailable", RetryAt: now.Add(2 * time.Second), DisableAfter: 10}),
+		webhookMock.EXPECT().RecordWebhookAttempt(gomock.Any(), domain.RecordWebhookAttemptReq{ID: 3, ResponseStatus: 503, Error: "webhook answered 503 Service Unavailable", GiveUp: true, DisableAfter: 10}),
+	)
+
+	p := webhookDeliverer{wr: webhookMock, cfg: cfg, timeNow: func() time.Time { return now }}
+	got, err := p.Deliver(context.Background())
+	if want := (DeliverWebhooksRes{Claimed: 3, Succeeded: 1, Retried: 1, Failed: 1}); err != nil || got != want {
+		t.Errorf("Deliver() = %+v, %v, want %+v", got, err, want)
+	}
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"encoding/json"
+	"time"
+
+	"gihub.com/gadhittana01/book-project/config"
+)
+
+type WebhookDependencies struct {
+	WR  WebhookResource
+	Cfg *config.GlobalConfig
+}
+
+// CreateWebhookReq Secret signs the deliveries, a random one is made when it is empty. Events lists the event
+// types to send, all of them when it is empty.
+type CreateWebhookReq struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events"`
+}
+
+type UpdateWebhookReq struct {
+	ID     int      `json:"id"`
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Active bool     `json:"active"`
+}
+
+type DeleteWebhookReq struct {
+	ID int `json:"id"`
+}
+
+// WebhookRes Secret is only returned when the subscription is created.
+type WebhookRes struct {
+	ID                  int        `json:"id"`
+	URL                 string     `json:"url"`
+	Secret              string     `json:"secret,omitempty"`
+	Events              []string   `json:"events"`
+	Active              bool       `json:"active"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	DisabledReason      string     `json:"disabled_reason,omitempty"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+type GetWebhookDeliveriesReq struct {
+	SubscriptionID int    `json:"subscription_id"`
+	Status         string `json:"status"`
+}
+
+type ReplayWebhookDeliveryReq struct {
+	ID int `json:"id"`
+}
+
+type WebhookDeliveryRes struct {
+	ID             int             `json:"id"`
+	SubscriptionID int             `json:"subscription_id"`
+	EventID        int             `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus int             `json:"response_status,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+	CompletedAt    *time.Time      `json:"completed_at,omitempty"`
+	ReplayOf       int             `json:"replay_of,omitempty"`
+}
+
+type DeliverWebhooksRes struct {
+	Claimed   int `json:"claimed"`
+	Succeeded int `json:"succeeded"`
+	Retried   int `json:"retried"`
+	Failed    int `json:"failed"`
+}